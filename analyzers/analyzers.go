@@ -0,0 +1,64 @@
+// Package analyzers reúne os analisadores estáticos do FubanGo.
+//
+// Cada analisador detecta em código real um dos formatos mostrados nos
+// arquivos ruim.go de exemplos/. Os analisadores ficam em subpacotes, no
+// estilo de golang.org/x/tools/go/analysis/passes, e este pacote apenas os
+// agrupa para o binário multichecker (cmd/fubango-vet).
+package analyzers
+
+import (
+	"slices"
+
+	"github.com/lucasrafaldini/fubango/analyzers/chanloop"
+	"github.com/lucasrafaldini/fubango/analyzers/ctxcancel"
+	"github.com/lucasrafaldini/fubango/analyzers/ctxiface"
+	"github.com/lucasrafaldini/fubango/analyzers/doubleclose"
+	"github.com/lucasrafaldini/fubango/analyzers/errdiscard"
+	"github.com/lucasrafaldini/fubango/analyzers/errwrap"
+	"github.com/lucasrafaldini/fubango/analyzers/goloopvar"
+	"github.com/lucasrafaldini/fubango/analyzers/handleropen"
+	"github.com/lucasrafaldini/fubango/analyzers/lockcopy"
+	"github.com/lucasrafaldini/fubango/analyzers/logreturn"
+	"github.com/lucasrafaldini/fubango/analyzers/panicvalidate"
+	"github.com/lucasrafaldini/fubango/analyzers/recoverall"
+	"github.com/lucasrafaldini/fubango/analyzers/rowsclose"
+	"github.com/lucasrafaldini/fubango/analyzers/sqlconcat"
+	"github.com/lucasrafaldini/fubango/analyzers/sqlloop"
+	"github.com/lucasrafaldini/fubango/analyzers/uncheckedassert"
+	"github.com/lucasrafaldini/fubango/analyzers/wgadd"
+	"golang.org/x/tools/go/analysis"
+)
+
+// Concurrency agrupa os analisadores de concorrência, goroutines e canais
+var Concurrency = []*analysis.Analyzer{
+	goloopvar.Analyzer,
+	doubleclose.Analyzer,
+	lockcopy.Analyzer,
+	wgadd.Analyzer,
+	chanloop.Analyzer,
+}
+
+// Database agrupa os analisadores de acesso a banco e design de APIs
+var Database = []*analysis.Analyzer{
+	sqlconcat.Analyzer,
+	rowsclose.Analyzer,
+	sqlloop.Analyzer,
+	handleropen.Analyzer,
+	uncheckedassert.Analyzer,
+}
+
+// Errors agrupa os analisadores de tratamento de erros e uso de context
+var Errors = []*analysis.Analyzer{
+	errdiscard.Analyzer,
+	panicvalidate.Analyzer,
+	errwrap.Analyzer,
+	logreturn.Analyzer,
+	recoverall.Analyzer,
+	ctxcancel.Analyzer,
+	ctxiface.Analyzer,
+}
+
+// All devolve todos os analisadores do FubanGo
+func All() []*analysis.Analyzer {
+	return slices.Concat(Concurrency, Database, Errors)
+}
@@ -0,0 +1,158 @@
+// Package chanloop define um Analyzer que detecta loops infinitos sobre
+// canais sem nenhum caminho de saída.
+//
+// Um for sem condição que envia, recebe ou faz select em canais e nunca
+// executa return, break para o próprio loop ou goto prende a goroutine para
+// sempre:
+//
+//	for {
+//		select {
+//		case v := <-ch:
+//			process(v)
+//		} // sem ctx.Done(), sem return
+//	}
+//
+// Um break dentro do select encerra apenas o select, não o for; esse caso
+// recebe uma mensagem específica.
+//
+// Lição: exemplos/03-avancado/channels/analise.md, seção 7.
+package chanloop
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer detecta for { ... } sobre canais sem caminho de saída
+var Analyzer = &analysis.Analyzer{
+	Name:     "chanloop",
+	Doc:      "detecta loops infinitos sobre canais sem caminho de saída",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.WithStack([]ast.Node{(*ast.ForStmt)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		loop := n.(*ast.ForStmt)
+		if loop.Cond != nil {
+			return true
+		}
+		var label *ast.Ident
+		if l, ok := stack[len(stack)-2].(*ast.LabeledStmt); ok {
+			label = l.Label
+		}
+
+		w := &walker{info: pass.TypesInfo, label: label}
+		w.walk(loop.Body, 0)
+		if !w.usesChan || w.exits {
+			return true
+		}
+		if w.selectBreak {
+			pass.Reportf(loop.For, "loop infinito sobre canal: o break dentro do select não encerra o for; use return ou um label")
+		} else {
+			pass.Reportf(loop.For, "loop infinito sobre canal sem caminho de saída; trate ctx.Done() ou o fechamento do canal")
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// walker percorre o corpo do loop procurando operações em canais e saídas
+type walker struct {
+	info        *types.Info
+	label       *ast.Ident
+	usesChan    bool
+	exits       bool
+	selectBreak bool
+}
+
+// walk visita n; depth conta quantos for/switch/select aninhados capturam
+// um break sem label
+func (w *walker) walk(n ast.Node, depth int) {
+	ast.Inspect(n, func(n ast.Node) bool {
+		if n == nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.SendStmt, *ast.SelectStmt:
+			w.usesChan = true
+		case *ast.UnaryExpr:
+			if n.Op == token.ARROW {
+				w.usesChan = true
+			}
+		case *ast.ReturnStmt:
+			w.exits = true
+		case *ast.CallExpr:
+			if analysisutil.Terminates(w.info, n) {
+				w.exits = true
+			}
+		case *ast.BranchStmt:
+			w.branch(n, depth)
+		}
+
+		switch n := n.(type) {
+		case *ast.SelectStmt:
+			w.walkNested(n.Body, depth, true)
+			return false
+		case *ast.RangeStmt:
+			if _, ok := w.info.TypeOf(n.X).Underlying().(*types.Chan); ok {
+				w.usesChan = true
+			}
+			w.walkNested(n.Body, depth, false)
+			return false
+		case *ast.ForStmt:
+			w.walkNested(n.Body, depth, false)
+			return false
+		case *ast.SwitchStmt:
+			w.walkNested(n.Body, depth, false)
+			return false
+		case *ast.TypeSwitchStmt:
+			w.walkNested(n.Body, depth, false)
+			return false
+		}
+		return true
+	})
+}
+
+func (w *walker) walkNested(body *ast.BlockStmt, depth int, isSelect bool) {
+	if isSelect && depth == 0 {
+		// depth -1 marca um break sem label que só sai do select externo
+		w.walk(body, -1)
+		return
+	}
+	if depth < 0 {
+		depth = 0
+	}
+	w.walk(body, depth+1)
+}
+
+func (w *walker) branch(b *ast.BranchStmt, depth int) {
+	switch b.Tok {
+	case token.GOTO:
+		w.exits = true
+	case token.BREAK:
+		switch {
+		case b.Label != nil:
+			if w.label != nil && b.Label.Name == w.label.Name {
+				w.exits = true
+			}
+		case depth == 0:
+			w.exits = true
+		case depth == -1:
+			w.selectBreak = true
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package chanloop_test
+
+import (
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers/chanloop"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), chanloop.Analyzer, "channels", "selectbreak")
+}
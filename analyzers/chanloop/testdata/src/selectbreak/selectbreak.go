@@ -0,0 +1,40 @@
+package selectbreak
+
+import "context"
+
+func BreakInsideSelect(ctx context.Context, ch <-chan int) {
+	for { // want `o break dentro do select não encerra o for`
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			break
+		}
+	}
+}
+
+func LabeledBreak(ctx context.Context, ch <-chan int) {
+loop:
+	for {
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			break loop
+		}
+	}
+}
+
+func BreakOutsideSelect(ch <-chan int) {
+	for {
+		v := <-ch
+		if v < 0 {
+			break
+		}
+	}
+}
+
+func NoChannels() {
+	n := 0
+	for {
+		n++
+	}
+}
@@ -0,0 +1,160 @@
+// Package ctxcancel define um Analyzer que detecta funções cancel de
+// context e timers descartados.
+//
+// context.WithTimeout, WithDeadline e WithCancel registram o contexto filho
+// no pai e, no caso dos dois primeiros, criam um timer. Sem chamar cancel
+// esses recursos só são liberados quando o pai termina:
+//
+//	ctx, _ := context.WithTimeout(parent, time.Second) // cancel perdido
+//
+// O mesmo vale para time.AfterFunc quando o *time.Timer é guardado apenas
+// para ser descartado:
+//
+//	_ = time.AfterFunc(time.Second, func() {})
+//
+// Chame defer cancel() logo após criar o contexto, ou Stop() no timer.
+// Atribuir a variável a _ não conta como uso. Quando a chamada é um comando
+// isolado dentro de um bloco, o analisador sugere dar nome ao cancel
+// descartado e inserir o defer na linha seguinte.
+//
+// Lição: exemplos/03-avancado/context/analise.md, seções 1 e 3.
+package ctxcancel
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer detecta cancel de context e timers nunca usados
+var Analyzer = &analysis.Analyzer{
+	Name:     "ctxcancel",
+	Doc:      "detecta funções cancel de context e timers descartados",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		name := analysisutil.Render(pass.Fset, call.Fun)
+
+		var (
+			index               int
+			discarded, unusedFn string
+			release             string
+		)
+		switch {
+		case analysisutil.IsFunc(pass.TypesInfo, call, "context",
+			"WithCancel", "WithTimeout", "WithDeadline", "WithCancelCause", "WithTimeoutCause", "WithDeadlineCause"):
+			index = 1
+			discarded = "função cancel retornada por %s descartada; adicione defer cancel() para liberar o contexto"
+			unusedFn = "%s nunca é chamada; adicione defer %s() para liberar o contexto"
+			release = "%s()"
+		case analysisutil.IsFunc(pass.TypesInfo, call, "time", "AfterFunc", "NewTimer"):
+			discarded = "timer retornado por %s descartado; guarde-o e chame Stop() ou use context.WithTimeout"
+			unusedFn = "timer %s nunca é parado; chame defer %s.Stop() ou use context.WithTimeout"
+			release = "%s.Stop()"
+		default:
+			return true
+		}
+
+		var lhs ast.Expr
+		switch parent := stack[len(stack)-2].(type) {
+		case *ast.ExprStmt:
+			if index > 0 {
+				pass.Reportf(call.Pos(), discarded, name)
+			}
+			return true
+		case *ast.AssignStmt:
+			if len(parent.Rhs) == 1 && index < len(parent.Lhs) {
+				lhs = parent.Lhs[index]
+			}
+		case *ast.ValueSpec:
+			if len(parent.Values) == 1 && index < len(parent.Names) {
+				lhs = parent.Names[index]
+			}
+		}
+		id, ok := lhs.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		if id.Name == "_" {
+			var fixes []analysis.SuggestedFix
+			if index > 0 {
+				fixes = nameCancel(pass, stack, id)
+			}
+			pass.Report(analysis.Diagnostic{
+				Pos:            call.Pos(),
+				End:            call.End(),
+				Message:        fmt.Sprintf(discarded, name),
+				SuggestedFixes: fixes,
+			})
+			return true
+		}
+
+		v, ok := pass.TypesInfo.ObjectOf(id).(*types.Var)
+		if !ok || v.Parent() == v.Pkg().Scope() {
+			return true
+		}
+		var body *ast.BlockStmt
+		switch f := enclosingFunc(stack).(type) {
+		case *ast.FuncLit:
+			body = f.Body
+		case *ast.FuncDecl:
+			body = f.Body
+		}
+		if body != nil && !used(pass.TypesInfo, body, v) {
+			pass.Report(analysis.Diagnostic{
+				Pos:            id.Pos(),
+				End:            id.End(),
+				Message:        fmt.Sprintf(unusedFn, id.Name, id.Name),
+				SuggestedFixes: deferRelease(pass, stack, fmt.Sprintf(release, id.Name)),
+			})
+		}
+		return true
+	})
+	return nil, nil
+}
+
+func enclosingFunc(stack []ast.Node) ast.Node {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch stack[i].(type) {
+		case *ast.FuncLit, *ast.FuncDecl:
+			return stack[i]
+		}
+	}
+	return nil
+}
+
+// used informa se v aparece em body fora de atribuições a _
+func used(info *types.Info, body *ast.BlockStmt, v *types.Var) bool {
+	blank := make(map[ast.Expr]bool)
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok && id.Name == "_" && i < len(n.Rhs) {
+					blank[ast.Unparen(n.Rhs[i])] = true
+				}
+			}
+		case *ast.Ident:
+			if info.Uses[n] == v && !blank[n] {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
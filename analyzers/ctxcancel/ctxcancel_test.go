@@ -0,0 +1,12 @@
+package ctxcancel_test
+
+import (
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers/ctxcancel"
+	"github.com/lucasrafaldini/fubango/analyzers/internal/fixtest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	fixtest.Run(t, ctxcancel.Analyzer, "contextx", "cancels")
+}
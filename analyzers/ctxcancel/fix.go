@@ -0,0 +1,46 @@
+package ctxcancel
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+)
+
+// cancelName é o nome dado à função cancel que estava descartada
+const cancelName = "cancel"
+
+// nameCancel sugere trocar o _ que descarta a função cancel por uma
+// variável e chamá-la com defer logo após a declaração
+func nameCancel(pass *analysis.Pass, stack []ast.Node, blank *ast.Ident) []analysis.SuggestedFix {
+	if assign, ok := stack[len(stack)-2].(*ast.AssignStmt); ok && assign.Tok != token.DEFINE {
+		return nil // com = a variável precisaria ser declarada antes
+	}
+	if analysisutil.Declared(pass, blank.Pos(), cancelName) {
+		return nil
+	}
+	stmt, ok := analysisutil.DeclaringStmt(stack)
+	if !ok {
+		return nil
+	}
+	return []analysis.SuggestedFix{{
+		Message: "Guardar a função cancel e adicionar defer " + cancelName + "()",
+		TextEdits: []analysis.TextEdit{
+			{Pos: blank.Pos(), End: blank.End(), NewText: []byte(cancelName)},
+			analysisutil.InsertAfter(pass, stmt, "defer "+cancelName+"()"),
+		},
+	}}
+}
+
+// deferRelease sugere inserir "defer release" logo após a declaração
+func deferRelease(pass *analysis.Pass, stack []ast.Node, release string) []analysis.SuggestedFix {
+	stmt, ok := analysisutil.DeclaringStmt(stack)
+	if !ok {
+		return nil
+	}
+	return []analysis.SuggestedFix{{
+		Message:   "Adicionar defer " + release,
+		TextEdits: []analysis.TextEdit{analysisutil.InsertAfter(pass, stmt, "defer "+release)},
+	}}
+}
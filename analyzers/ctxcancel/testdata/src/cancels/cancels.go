@@ -0,0 +1,64 @@
+package cancels
+
+import (
+	"context"
+	"time"
+)
+
+func work(ctx context.Context) error { return ctx.Err() }
+
+func Discarded(parent context.Context) error {
+	ctx, _ := context.WithTimeout(parent, time.Second) // want `função cancel retornada por context.WithTimeout descartada`
+	return work(ctx)
+}
+
+func Unused(parent context.Context) error {
+	ctx, cancel := context.WithCancel(parent) // want `cancel nunca é chamada; adicione defer cancel\(\)`
+	_ = cancel
+	return work(ctx)
+}
+
+func Var(parent context.Context) error {
+	var ctx, stop = context.WithDeadline(parent, time.Now()) // want `stop nunca é chamada`
+	_ = stop
+	return work(ctx)
+}
+
+func Deferred(parent context.Context) error {
+	ctx, cancel := context.WithTimeout(parent, time.Second)
+	defer cancel()
+	return work(ctx)
+}
+
+func Returned(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	return ctx, cancel
+}
+
+func Closure(parent context.Context) {
+	go func() {
+		ctx, cancel := context.WithCancel(parent) // want `cancel nunca é chamada`
+		_, _ = work(ctx), cancel
+	}()
+}
+
+func Timer() {
+	t := time.NewTimer(time.Second)
+	defer t.Stop()
+	<-t.C
+
+	time.AfterFunc(time.Second, func() {}) // disparo sem necessidade de cancelamento
+}
+
+func InitStmt(parent context.Context) error {
+	if ctx, _ := context.WithTimeout(parent, time.Second); work(ctx) != nil { // want `função cancel retornada por context.WithTimeout descartada`
+		return ctx.Err()
+	}
+	return nil
+}
+
+func Taken(parent context.Context, cancel func()) error {
+	defer cancel()
+	ctx, _ := context.WithCancel(parent) // want `função cancel retornada por context.WithCancel descartada`
+	return work(ctx)
+}
@@ -0,0 +1,132 @@
+// Package ctxiface define um Analyzer que detecta parâmetros interface{}
+// usados como context.Context.
+//
+// Declarar o contexto como interface{} (ou any) apaga o tipo: a função não
+// consegue chamar Done, Err ou Deadline sem uma type assertion e o
+// compilador aceita qualquer valor no lugar de um contexto:
+//
+//	func ContextAsValueOnly(ctx interface{}) {
+//		_ = ctx
+//	}
+//
+// Um parâmetro é considerado contexto quando se chama ctx (ou termina em
+// Ctx), quando é convertido para context.Context no corpo da função ou
+// quando alguma chamada no pacote passa um context.Context para ele.
+//
+// Lição: exemplos/03-avancado/context/analise.md, seções 4 e 5.
+package ctxiface
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// Analyzer detecta parâmetros interface{} que carregam contextos
+var Analyzer = &analysis.Analyzer{
+	Name:     "ctxiface",
+	Doc:      "detecta parâmetros interface{} usados como context.Context",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// parâmetros que recebem um context.Context em alguma chamada do pacote
+	receives := make(map[*types.Var]bool)
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() != pass.Pkg {
+			return
+		}
+		params := fn.Signature().Params()
+		for i, arg := range call.Args {
+			if i < params.Len() && isContext(pass.TypesInfo.TypeOf(arg)) {
+				receives[params.At(i)] = true
+			}
+		}
+	})
+
+	inspect.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		decl := n.(*ast.FuncDecl)
+		for _, field := range decl.Type.Params.List {
+			if !isEmptyInterface(pass.TypesInfo.TypeOf(field.Type)) {
+				continue
+			}
+			for _, name := range field.Names {
+				param, ok := pass.TypesInfo.Defs[name].(*types.Var)
+				if !ok {
+					continue
+				}
+				if contextName(name.Name) || receives[param] || (decl.Body != nil && asserted(pass.TypesInfo, decl.Body, param)) {
+					pass.Reportf(name.Pos(), "parâmetro %s do tipo %s usado como contexto; declare-o como context.Context",
+						name.Name, analysisutil.Render(pass.Fset, field.Type))
+				}
+			}
+		}
+	})
+	return nil, nil
+}
+
+func isContext(t types.Type) bool {
+	return t != nil && analysisutil.IsNamedType(t, "context", "Context")
+}
+
+func isEmptyInterface(t types.Type) bool {
+	iface, ok := types.Unalias(t).(*types.Interface)
+	return ok && iface.Empty()
+}
+
+// contextName informa se name segue a convenção de nome de contextos
+func contextName(name string) bool {
+	return name == "ctx" || strings.HasSuffix(name, "Ctx")
+}
+
+// asserted informa se param é convertido para context.Context em body
+func asserted(info *types.Info, body *ast.BlockStmt, param *types.Var) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.TypeAssertExpr:
+			id, ok := ast.Unparen(n.X).(*ast.Ident)
+			if ok && n.Type != nil && info.Uses[id] == param && isContext(info.TypeOf(n.Type)) {
+				found = true
+			}
+		case *ast.TypeSwitchStmt:
+			for _, clause := range n.Body.List {
+				for _, expr := range clause.(*ast.CaseClause).List {
+					if isContext(info.TypeOf(expr)) && switchesOn(info, n, param) {
+						found = true
+					}
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// switchesOn informa se o type switch é feito sobre param
+func switchesOn(info *types.Info, sw *ast.TypeSwitchStmt, param *types.Var) bool {
+	var x ast.Expr
+	switch a := sw.Assign.(type) {
+	case *ast.AssignStmt:
+		x = a.Rhs[0]
+	case *ast.ExprStmt:
+		x = a.X
+	}
+	assert, ok := x.(*ast.TypeAssertExpr)
+	if !ok {
+		return false
+	}
+	id, ok := ast.Unparen(assert.X).(*ast.Ident)
+	return ok && info.Uses[id] == param
+}
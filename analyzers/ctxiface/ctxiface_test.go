@@ -0,0 +1,12 @@
+package ctxiface_test
+
+import (
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers/ctxiface"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), ctxiface.Analyzer, "contextx", "iface")
+}
@@ -0,0 +1,40 @@
+package iface
+
+import (
+	"context"
+	"fmt"
+)
+
+func Run(parentCtx any) { // want `parâmetro parentCtx do tipo any usado como contexto`
+	_ = parentCtx
+}
+
+func Deadline(c interface{}) { // want `parâmetro c do tipo interface\{\} usado como contexto`
+	if ctx, ok := c.(context.Context); ok {
+		<-ctx.Done()
+	}
+}
+
+func Switch(c any) { // want `parâmetro c do tipo any usado como contexto`
+	switch v := c.(type) {
+	case context.Context:
+		<-v.Done()
+	}
+}
+
+func Store(key string, value any) { // want `parâmetro value do tipo any usado como contexto`
+	fmt.Println(key, value)
+}
+
+func Caller(ctx context.Context) {
+	Store("ctx", ctx)
+	fmt.Println(ctx) // fmt é de outro pacote
+}
+
+func Plain(v interface{}, s fmt.Stringer) {
+	fmt.Println(v, s)
+}
+
+func Typed(ctx context.Context) error {
+	return ctx.Err()
+}
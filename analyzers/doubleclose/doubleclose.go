@@ -0,0 +1,153 @@
+// Package doubleclose define um Analyzer que detecta canais fechados mais de
+// uma vez dentro da mesma função.
+//
+// Fechar um canal já fechado causa panic. O padrão mais comum é fechar o
+// mesmo canal em duas goroutines diferentes:
+//
+//	go func() { close(ch) }()
+//	go func() { close(ch) }() // panic: close of closed channel
+//
+// O analisador considera conflitantes dois close do mesmo canal quando
+// estão em goroutines diferentes, quando um deles é adiado com defer ou
+// quando o segundo é alcançável logo após o primeiro no mesmo bloco. Fechar
+// em ramos exclusivos (if/else seguidos de return) não é reportado.
+//
+// Lição: exemplos/03-avancado/channels/analise.md, seção 2.
+package doubleclose
+
+import (
+	"go/ast"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer detecta close duplicado sobre o mesmo canal
+var Analyzer = &analysis.Analyzer{
+	Name:     "doubleclose",
+	Doc:      "detecta canais fechados mais de uma vez",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// site é uma chamada close(ch) e o caminho da AST até ela
+type site struct {
+	call     *ast.CallExpr
+	stack    []ast.Node
+	flow     ast.Node // goroutine (FuncLit) ou função que executa o close
+	deferred bool
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	sites := make(map[ast.Node]map[analysisutil.Key][]site)
+	inspect.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		if !analysisutil.IsBuiltin(pass.TypesInfo, call, "close") || len(call.Args) != 1 {
+			return true
+		}
+		key, ok := analysisutil.KeyOf(pass.TypesInfo, call.Args[0])
+		if !ok {
+			return true
+		}
+		decl, ok := analysisutil.Enclosing[*ast.FuncDecl](stack)
+		if !ok {
+			return true
+		}
+		s := site{
+			call:  call,
+			stack: append([]ast.Node(nil), stack...),
+			flow:  flowOf(stack),
+		}
+		if d, ok := stack[len(stack)-2].(*ast.DeferStmt); ok && d.Call == call {
+			s.deferred = true
+		}
+		if sites[decl] == nil {
+			sites[decl] = make(map[analysisutil.Key][]site)
+		}
+		sites[decl][key] = append(sites[decl][key], s)
+		return true
+	})
+
+	for _, byKey := range sites {
+		for _, list := range byKey {
+			for j := 1; j < len(list); j++ {
+				for i := 0; i < j; i++ {
+					if conflict(list[i], list[j]) {
+						first := pass.Fset.Position(list[i].call.Pos())
+						pass.Reportf(list[j].call.Pos(), "canal %s fechado mais de uma vez (também fechado na linha %d)",
+							analysisutil.Render(pass.Fset, list[j].call.Args[0]), first.Line)
+						break
+					}
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// flowOf devolve a goroutine que executa o último nó de stack: o FuncLit
+// iniciado por um comando go mais próximo ou, na falta dele, a FuncDecl.
+func flowOf(stack []ast.Node) ast.Node {
+	for i := len(stack) - 1; i >= 2; i-- {
+		if lit, ok := stack[i].(*ast.FuncLit); ok {
+			if _, ok := stack[i-2].(*ast.GoStmt); ok {
+				return lit
+			}
+		}
+	}
+	for _, n := range stack {
+		if d, ok := n.(*ast.FuncDecl); ok {
+			return d
+		}
+	}
+	return nil
+}
+
+func conflict(a, b site) bool {
+	if a.flow != b.flow || a.deferred || b.deferred {
+		return true
+	}
+	return reachableAfter(a, b)
+}
+
+// reachableAfter informa se b está em um comando posterior a a no mesmo
+// bloco de a, sem return, break, continue ou goto entre eles.
+func reachableAfter(a, b site) bool {
+	for d := len(a.stack) - 1; d >= 0; d-- {
+		block, ok := a.stack[d].(*ast.BlockStmt)
+		if !ok {
+			continue
+		}
+		if d+1 >= len(b.stack) || d >= len(b.stack) || b.stack[d] != block {
+			return false
+		}
+		ia, ib := indexOf(block.List, a.stack[d+1]), indexOf(block.List, b.stack[d+1])
+		if ia < 0 || ib <= ia {
+			return false
+		}
+		for _, stmt := range block.List[ia:ib] {
+			switch stmt.(type) {
+			case *ast.ReturnStmt, *ast.BranchStmt:
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func indexOf(list []ast.Stmt, n ast.Node) int {
+	for i, s := range list {
+		if s == n {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,12 @@
+package doubleclose_test
+
+import (
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers/doubleclose"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), doubleclose.Analyzer, "channels", "sequential")
+}
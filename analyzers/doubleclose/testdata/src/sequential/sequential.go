@@ -0,0 +1,25 @@
+package sequential
+
+func CloseTwice() {
+	ch := make(chan int)
+	close(ch)
+	if true {
+		close(ch) // want `canal ch fechado mais de uma vez`
+	}
+}
+
+func DeferAndClose() {
+	ch := make(chan int)
+	defer close(ch)
+	close(ch) // want `canal ch fechado mais de uma vez`
+}
+
+type pipe struct{ out chan int }
+
+func (p *pipe) ExclusiveBranches(ok bool) {
+	if !ok {
+		close(p.out)
+		return
+	}
+	close(p.out)
+}
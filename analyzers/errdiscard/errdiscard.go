@@ -0,0 +1,119 @@
+// Package errdiscard define um Analyzer que detecta resultados do tipo error
+// descartados.
+//
+// Atribuir o erro a _ ou chamar uma função que retorna error sem olhar o
+// resultado esconde falhas e deixa o programa seguir com valores inválidos:
+//
+//	file, _ := os.Open("arquivo.txt") // file pode ser nil
+//	defer file.Close()
+//	_, _ = file.Read(data)
+//
+// Chamadas de impressão (fmt.Print*, log.Print*) e escritas em
+// strings.Builder e bytes.Buffer, que não falham na prática, são ignoradas.
+//
+// Lição: exemplos/02-intermediario/error-handling/analise.md, seção 1.
+package errdiscard
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer detecta erros descartados com _ ou ignorados em chamadas soltas
+var Analyzer = &analysis.Analyzer{
+	Name:     "errdiscard",
+	Doc:      "detecta resultados do tipo error descartados",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	filter := []ast.Node{(*ast.AssignStmt)(nil), (*ast.ExprStmt)(nil)}
+	inspect.Preorder(filter, func(n ast.Node) {
+		switch stmt := n.(type) {
+		case *ast.AssignStmt:
+			checkAssign(pass, stmt)
+		case *ast.ExprStmt:
+			call, ok := ast.Unparen(stmt.X).(*ast.CallExpr)
+			if !ok || ignored(pass.TypesInfo, call) {
+				return
+			}
+			for _, t := range results(pass.TypesInfo, call) {
+				if analysisutil.IsError(t) {
+					pass.Reportf(call.Pos(), "erro retornado por %s ignorado; verifique-o ou propague-o", analysisutil.Render(pass.Fset, call.Fun))
+					return
+				}
+			}
+		}
+	})
+	return nil, nil
+}
+
+// checkAssign reporta cada _ que recebe um error
+func checkAssign(pass *analysis.Pass, stmt *ast.AssignStmt) {
+	report := func(lhs ast.Expr, call *ast.CallExpr) {
+		pass.Reportf(lhs.Pos(), "erro retornado por %s descartado com _; verifique-o ou propague-o", analysisutil.Render(pass.Fset, call.Fun))
+	}
+
+	if len(stmt.Rhs) == 1 {
+		call, ok := ast.Unparen(stmt.Rhs[0]).(*ast.CallExpr)
+		if !ok || ignored(pass.TypesInfo, call) {
+			return
+		}
+		res := results(pass.TypesInfo, call)
+		if len(res) != len(stmt.Lhs) {
+			return
+		}
+		for i, lhs := range stmt.Lhs {
+			if isBlank(lhs) && analysisutil.IsError(res[i]) {
+				report(lhs, call)
+			}
+		}
+		return
+	}
+
+	for i, lhs := range stmt.Lhs {
+		if i >= len(stmt.Rhs) || !isBlank(lhs) {
+			continue
+		}
+		call, ok := ast.Unparen(stmt.Rhs[i]).(*ast.CallExpr)
+		if ok && !ignored(pass.TypesInfo, call) && analysisutil.IsError(pass.TypesInfo.TypeOf(call)) {
+			report(lhs, call)
+		}
+	}
+}
+
+// results devolve os tipos de resultado de call
+func results(info *types.Info, call *ast.CallExpr) []types.Type {
+	switch t := info.TypeOf(call).(type) {
+	case nil:
+		return nil
+	case *types.Tuple:
+		out := make([]types.Type, t.Len())
+		for i := range t.Len() {
+			out[i] = t.At(i).Type()
+		}
+		return out
+	default:
+		return []types.Type{t}
+	}
+}
+
+// ignored informa se call é uma chamada cujo erro pode ser deixado de lado
+func ignored(info *types.Info, call *ast.CallExpr) bool {
+	return analysisutil.IsPrint(info, call) ||
+		analysisutil.IsMethod(info, call, "strings", "Builder", "Write", "WriteByte", "WriteRune", "WriteString") ||
+		analysisutil.IsMethod(info, call, "bytes", "Buffer", "Write", "WriteByte", "WriteRune", "WriteString")
+}
+
+func isBlank(expr ast.Expr) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && id.Name == "_"
+}
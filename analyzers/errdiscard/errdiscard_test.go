@@ -0,0 +1,12 @@
+package errdiscard_test
+
+import (
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers/errdiscard"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), errdiscard.Analyzer, "errorhandling", "calls")
+}
@@ -0,0 +1,43 @@
+package calls
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func save(path string) error {
+	return os.WriteFile(path, nil, 0o644)
+}
+
+func Bare() {
+	save("a.txt")      // want `erro retornado por save ignorado`
+	os.Remove("a.txt") // want `erro retornado por os.Remove ignorado`
+}
+
+func Multi() {
+	_, _ = len("ok"), save("b.txt") // want `erro retornado por save descartado com _`
+	_ = save("c.txt")               // want `erro retornado por save descartado com _`
+}
+
+func Ignored() {
+	fmt.Println("impressão não conta")
+	fmt.Fprintf(os.Stderr, "nem em stderr\n")
+
+	var sb strings.Builder
+	sb.WriteString("builder")
+	var buf bytes.Buffer
+	buf.WriteString("buffer")
+
+	defer save("d.txt")
+}
+
+func Checked() error {
+	if err := save("e.txt"); err != nil {
+		return fmt.Errorf("salvando: %w", err)
+	}
+	n, _ := fmt.Println("n")
+	_ = n
+	return nil
+}
@@ -0,0 +1,188 @@
+// Package errwrap define um Analyzer que detecta erros embrulhados sem %w.
+//
+// fmt.Errorf com %v ou %s transforma o erro original em texto: a mensagem
+// continua lá, mas errors.Is e errors.As deixam de enxergá-lo. Pior ainda é
+// criar um erro novo que nem menciona o original:
+//
+//	if err := checkPermissions(); err != nil {
+//		return fmt.Errorf("erro de permissão: %v", err) // use %w
+//	}
+//
+//	_, err := os.Stat(path)
+//	if err != nil {
+//		return fmt.Errorf("falha ao verificar arquivo") // err perdido
+//	}
+//
+// Quando o formato é um literal, o analisador sugere trocar o verbo por %w
+// ou acrescentar ": %w" e o erro aos argumentos.
+//
+// Lições: exemplos/02-intermediario/error-handling/analise.md, seções 4 e 6.
+package errwrap
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer detecta fmt.Errorf que embrulha erros com %v/%s ou os descarta
+var Analyzer = &analysis.Analyzer{
+	Name:     "errwrap",
+	Doc:      "detecta fmt.Errorf que embrulha erros sem %w ou descarta o erro verificado",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		errorf := analysisutil.IsFunc(pass.TypesInfo, call, "fmt", "Errorf")
+		if !errorf && !analysisutil.IsFunc(pass.TypesInfo, call, "errors", "New") {
+			return true
+		}
+
+		if errorf && len(call.Args) > 0 {
+			if verbs, ok := formatVerbs(pass.TypesInfo, call.Args[0]); ok {
+				var wrong []verb
+				for _, v := range verbs {
+					if v.arg+1 >= len(call.Args) || (v.verb != 'v' && v.verb != 's') {
+						continue
+					}
+					if analysisutil.ImplementsError(pass.TypesInfo.TypeOf(call.Args[v.arg+1])) {
+						wrong = append(wrong, v)
+					}
+				}
+				fixes := useW(call.Args[0], wrong)
+				for _, v := range wrong {
+					arg := call.Args[v.arg+1]
+					pass.Report(analysis.Diagnostic{
+						Pos: arg.Pos(),
+						End: arg.End(),
+						Message: fmt.Sprintf("fmt.Errorf formata o erro %s com %%%c; use %%w para preservar a cadeia de erros (errors.Is/As)",
+							analysisutil.Render(pass.Fset, arg), v.verb),
+						SuggestedFixes: fixes,
+					})
+				}
+			}
+		}
+
+		if _, ok := stack[len(stack)-2].(*ast.ReturnStmt); !ok {
+			return true
+		}
+		if errVar := checkedError(pass.TypesInfo, stack); errVar != nil && !mentions(pass.TypesInfo, call, errVar) {
+			pass.Report(analysis.Diagnostic{
+				Pos: call.Pos(),
+				End: call.End(),
+				Message: fmt.Sprintf("erro %s descartado: %s cria um erro novo sem o original; inclua-o com %%w",
+					errVar.Name(), analysisutil.Render(pass.Fset, call.Fun)),
+				SuggestedFixes: includeErr(pass, call, errorf, errVar),
+			})
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// verb é um verbo de formatação, sua posição (em runas) no formato e o
+// índice do argumento que ele consome
+type verb struct {
+	verb rune
+	pos  int
+	arg  int
+}
+
+// formatVerbs interpreta a string de formato constante de fmt.Errorf.
+// Devolve false se o formato não for constante ou usar índices explícitos
+// ([n]), que este analisador não tenta acompanhar.
+func formatVerbs(info *types.Info, format ast.Expr) ([]verb, bool) {
+	tv, ok := info.Types[format]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return nil, false
+	}
+	s := []rune(constant.StringVal(tv.Value))
+
+	var verbs []verb
+	arg := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			continue
+		}
+		i++
+		for i < len(s) && (s[i] == '+' || s[i] == '-' || s[i] == '#' || s[i] == ' ' || s[i] == '0') {
+			i++
+		}
+		for i < len(s) && (s[i] == '*' || s[i] == '.' || (s[i] >= '0' && s[i] <= '9') || s[i] == '[') {
+			switch s[i] {
+			case '*':
+				arg++
+			case '[':
+				return nil, false
+			}
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		if s[i] == '%' {
+			continue
+		}
+		verbs = append(verbs, verb{verb: s[i], pos: i, arg: arg})
+		arg++
+	}
+	return verbs, true
+}
+
+// checkedError devolve a variável err quando o nó está dentro do corpo de
+// um "if err != nil" da mesma função
+func checkedError(info *types.Info, stack []ast.Node) *types.Var {
+	for i := len(stack) - 2; i > 0; i-- {
+		switch node := stack[i].(type) {
+		case *ast.FuncLit, *ast.FuncDecl:
+			return nil
+		case *ast.IfStmt:
+			if stack[i+1] != node.Body {
+				continue
+			}
+			cond, ok := node.Cond.(*ast.BinaryExpr)
+			if !ok || cond.Op != token.NEQ || !isNil(info, cond.Y) {
+				continue
+			}
+			id, ok := ast.Unparen(cond.X).(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if v, ok := info.Uses[id].(*types.Var); ok && analysisutil.IsError(v.Type()) {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+func isNil(info *types.Info, expr ast.Expr) bool {
+	return info.Types[expr].IsNil()
+}
+
+// mentions informa se v é usado em algum ponto de node
+func mentions(info *types.Info, node ast.Node, v *types.Var) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && info.Uses[id] == v {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,12 @@
+package errwrap_test
+
+import (
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers/errwrap"
+	"github.com/lucasrafaldini/fubango/analyzers/internal/fixtest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	fixtest.Run(t, errwrap.Analyzer, "errorhandling", "wrapping")
+}
@@ -0,0 +1,99 @@
+package errwrap
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+)
+
+// useW sugere trocar por %w os verbos de format que recebem erros. A mesma
+// correção cobre todos os verbos da chamada, para que os diagnósticos não
+// gerem edições conflitantes sobre o literal.
+func useW(format ast.Expr, verbs []verb) []analysis.SuggestedFix {
+	lit, s, ok := stringLit(format)
+	if !ok || len(verbs) == 0 {
+		return nil
+	}
+	runes := []rune(s)
+	for _, v := range verbs {
+		runes[v.pos] = 'w'
+	}
+	return []analysis.SuggestedFix{{
+		Message: "Usar %w para embrulhar o erro",
+		TextEdits: []analysis.TextEdit{
+			{Pos: lit.Pos(), End: lit.End(), NewText: []byte(quote(lit, string(runes)))},
+		},
+	}}
+}
+
+// includeErr sugere embrulhar errVar na mensagem do erro novo, acrescentando
+// ": %w" ao formato. errors.New vira fmt.Errorf, e o import de fmt é
+// adicionado quando falta.
+func includeErr(pass *analysis.Pass, call *ast.CallExpr, errorf bool, errVar *types.Var) []analysis.SuggestedFix {
+	if call.Ellipsis.IsValid() || len(call.Args) == 0 || !visible(pass, call.Rparen, errVar) {
+		return nil
+	}
+	lit, s, ok := stringLit(call.Args[0])
+	if !ok {
+		return nil
+	}
+
+	var edits []analysis.TextEdit
+	if !errorf {
+		file := analysisutil.EnclosingFile(pass, call.Pos())
+		if file == nil {
+			return nil
+		}
+		name, imports, ok := analysisutil.Import(file, "fmt")
+		if !ok {
+			return nil
+		}
+		edits = append(edits, imports...)
+		edits = append(edits, analysis.TextEdit{Pos: call.Fun.Pos(), End: call.Fun.End(), NewText: []byte(name + ".Errorf")})
+		s = strings.ReplaceAll(s, "%", "%%")
+	}
+	edits = append(edits,
+		analysis.TextEdit{Pos: lit.Pos(), End: lit.End(), NewText: []byte(quote(lit, s+": %w"))},
+		analysis.TextEdit{Pos: call.Rparen, End: call.Rparen, NewText: []byte(", " + errVar.Name())},
+	)
+	return []analysis.SuggestedFix{{
+		Message:   "Incluir " + errVar.Name() + " no erro com %w",
+		TextEdits: edits,
+	}}
+}
+
+// stringLit devolve o valor de expr quando ele é um literal de string
+func stringLit(expr ast.Expr) (*ast.BasicLit, string, bool) {
+	lit, ok := ast.Unparen(expr).(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return nil, "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return nil, "", false
+	}
+	return lit, s, true
+}
+
+// quote escreve s no mesmo estilo de lit (com crases ou aspas)
+func quote(lit *ast.BasicLit, s string) string {
+	if strings.HasPrefix(lit.Value, "`") && !strings.Contains(s, "`") {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+// visible informa se o nome de v se refere a v em pos
+func visible(pass *analysis.Pass, pos token.Pos, v *types.Var) bool {
+	scope := pass.Pkg.Scope().Innermost(pos)
+	if scope == nil {
+		return false
+	}
+	_, obj := scope.LookupParent(v.Name(), pos)
+	return obj == v
+}
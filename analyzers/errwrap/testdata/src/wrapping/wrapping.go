@@ -0,0 +1,82 @@
+package wrapping
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+type NotFound struct{ Key string }
+
+func (e NotFound) Error() string { return e.Key + " não encontrado" }
+
+func Verbs(path string, n int) error {
+	_, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("%d: %-10s", n, err) // want `fmt.Errorf formata o erro err com %s; use %w`
+	}
+	return nil
+}
+
+func Width(err error) error {
+	return fmt.Errorf("%*d%% %+v", 3, 10, err) // want `fmt.Errorf formata o erro err com %v`
+}
+
+func Custom(key string) error {
+	return fmt.Errorf("buscando: %v", NotFound{key}) // want `fmt.Errorf formata o erro NotFound\{key\} com %v`
+}
+
+func Indexed(err error) error {
+	return fmt.Errorf("%[1]v", err) // índices explícitos não são analisados
+}
+
+func Wrapped(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("verificando %s: %w", path, err)
+	}
+	return nil
+}
+
+func New(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return errors.New("arquivo inválido") // want `erro err descartado: errors.New cria um erro novo sem o original`
+	}
+	return nil
+}
+
+func Nested(path string) error {
+	_, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("ausente") // want `erro err descartado`
+		}
+		return fmt.Errorf("stat: %w", err)
+	}
+	return nil
+}
+
+func Else(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		fmt.Println(err)
+	} else {
+		return errors.New("já existe")
+	}
+	return nil
+}
+
+func Raw(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return errors.New(`100% inválido`) // want `erro err descartado: errors.New`
+	}
+	return nil
+}
+
+func Twice(a, b error) error {
+	return fmt.Errorf("%v; %v", a, b) // want `fmt.Errorf formata o erro a com %v` `fmt.Errorf formata o erro b com %v`
+}
+
+const prefix = "carregando: %v"
+
+func Named(err error) error {
+	return fmt.Errorf(prefix, err) // want `fmt.Errorf formata o erro err com %v`
+}
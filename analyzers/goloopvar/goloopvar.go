@@ -0,0 +1,157 @@
+// Package goloopvar define um Analyzer que detecta goroutines que capturam
+// variáveis de loop em closures.
+//
+// Antes do Go 1.22 as variáveis declaradas por for e range eram
+// compartilhadas por todas as iterações, então cada goroutine via o valor
+// da última iteração (ou um valor intermediário qualquer):
+//
+//	for i := 0; i < 10; i++ {
+//		go func() {
+//			fmt.Println(i) // todas veem o mesmo i
+//		}()
+//	}
+//
+// A correção, sugerida automaticamente, é passar a variável como argumento
+// da goroutine. Arquivos compilados com semântica Go 1.22 ou posterior são
+// ignorados, pois nesse caso cada iteração tem sua própria variável.
+//
+// Lição: exemplos/03-avancado/goroutines/analise.md, seção 2.
+package goloopvar
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"go/version"
+	"strings"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer detecta variáveis de loop capturadas por goroutines
+var Analyzer = &analysis.Analyzer{
+	Name:     "goloopvar",
+	Doc:      "detecta variáveis de loop capturadas por closures de goroutines",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// perIterationVersion é a primeira versão da linguagem em que cada iteração
+// declara novas variáveis de loop
+const perIterationVersion = "go1.22"
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.WithStack([]ast.Node{(*ast.GoStmt)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		file := stack[0].(*ast.File)
+		if v := pass.TypesInfo.FileVersions[file]; v != "" && version.Compare(v, perIterationVersion) >= 0 {
+			return true
+		}
+
+		call := n.(*ast.GoStmt).Call
+		lit, ok := ast.Unparen(call.Fun).(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+		vars := loopVars(pass.TypesInfo, stack)
+		if len(vars) == 0 {
+			return true
+		}
+
+		var captured []*ast.Ident
+		seen := make(map[types.Object]bool)
+		ast.Inspect(lit.Body, func(n ast.Node) bool {
+			id, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			obj := pass.TypesInfo.Uses[id]
+			if obj == nil || !vars[obj] || seen[obj] {
+				return true
+			}
+			seen[obj] = true
+			captured = append(captured, id)
+			return true
+		})
+
+		fixes := passAsArgs(pass, file, call, lit, captured)
+		for _, id := range captured {
+			pass.Report(analysis.Diagnostic{
+				Pos:            id.Pos(),
+				End:            id.End(),
+				Message:        fmt.Sprintf("variável de loop %s capturada pela goroutine; passe-a como argumento", id.Name),
+				SuggestedFixes: fixes,
+			})
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// passAsArgs sugere declarar as variáveis capturadas como parâmetros da
+// closure e passá-las na chamada. A mesma correção cobre todas as variáveis
+// da goroutine, para que os diagnósticos não gerem edições conflitantes.
+func passAsArgs(pass *analysis.Pass, file *ast.File, call *ast.CallExpr, lit *ast.FuncLit, captured []*ast.Ident) []analysis.SuggestedFix {
+	if call.Ellipsis.IsValid() {
+		return nil
+	}
+	var params, args []string
+	for _, id := range captured {
+		typ, ok := analysisutil.TypeString(file, pass.Pkg, pass.TypesInfo.Uses[id].Type())
+		if !ok {
+			return nil
+		}
+		params = append(params, id.Name+" "+typ)
+		args = append(args, id.Name)
+	}
+	paramText, argText := strings.Join(params, ", "), strings.Join(args, ", ")
+	if lit.Type.Params.NumFields() > 0 {
+		paramText = ", " + paramText
+	}
+	if len(call.Args) > 0 {
+		argText = ", " + argText
+	}
+	closing := lit.Type.Params.Closing
+	return []analysis.SuggestedFix{{
+		Message: "Passar as variáveis de loop como argumentos da goroutine",
+		TextEdits: []analysis.TextEdit{
+			{Pos: closing, End: closing, NewText: []byte(paramText)},
+			{Pos: call.Rparen, End: call.Rparen, NewText: []byte(argText)},
+		},
+	}}
+}
+
+// loopVars coleta as variáveis declaradas pelos loops que envolvem o último
+// nó de stack, parando na fronteira da função atual.
+func loopVars(info *types.Info, stack []ast.Node) map[types.Object]bool {
+	vars := make(map[types.Object]bool)
+	add := func(exprs ...ast.Expr) {
+		for _, e := range exprs {
+			if id, ok := e.(*ast.Ident); ok && id.Name != "_" {
+				if obj := info.Defs[id]; obj != nil {
+					vars[obj] = true
+				}
+			}
+		}
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.FuncLit, *ast.FuncDecl:
+			return vars
+		case *ast.ForStmt:
+			if init, ok := n.Init.(*ast.AssignStmt); ok {
+				add(init.Lhs...)
+			}
+		case *ast.RangeStmt:
+			add(n.Key, n.Value)
+		}
+	}
+	return vars
+}
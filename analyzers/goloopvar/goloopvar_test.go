@@ -0,0 +1,12 @@
+package goloopvar_test
+
+import (
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers/goloopvar"
+	"github.com/lucasrafaldini/fubango/analyzers/internal/fixtest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	fixtest.Run(t, goloopvar.Analyzer, "goroutines", "args", "modern")
+}
@@ -0,0 +1,27 @@
+package args
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+type job struct{ id int }
+
+func Range(jobs map[string]*job, wg *sync.WaitGroup) {
+	for name, j := range jobs {
+		wg.Add(1)
+		go func(wg *sync.WaitGroup) {
+			defer wg.Done()
+			fmt.Println(name, j.id) // want `variável de loop name capturada` `variável de loop j capturada`
+		}(wg)
+	}
+}
+
+func Imported(links []*url.URL) {
+	for _, u := range links {
+		go func() {
+			fmt.Println(u.Host) // want `variável de loop u capturada`
+		}()
+	}
+}
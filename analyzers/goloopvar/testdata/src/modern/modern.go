@@ -0,0 +1,15 @@
+//go:build go1.22
+
+// Com semântica Go 1.22+ cada iteração declara um novo i, então a captura
+// não é um problema e nenhum diagnóstico é esperado.
+package modern
+
+import "fmt"
+
+func ClosureVariableSharing() {
+	for i := 0; i < 10; i++ {
+		go func() {
+			fmt.Println(i)
+		}()
+	}
+}
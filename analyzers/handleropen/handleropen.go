@@ -0,0 +1,87 @@
+// Package handleropen define um Analyzer que detecta sql.Open chamado
+// dentro de handlers HTTP.
+//
+// sql.Open cria um pool de conexões inteiro. Chamá-lo a cada requisição
+// descarta o pool logo em seguida, abre uma conexão nova por requisição e
+// mistura acesso a dados com a camada HTTP:
+//
+//	func BadHandler(w http.ResponseWriter, r *http.Request) {
+//		db, _ := sql.Open("postgres", "conn-string")
+//		defer db.Close()
+//		...
+//	}
+//
+// O *sql.DB deve ser criado uma vez na inicialização e injetado no handler.
+//
+// Lições: exemplos/04-casos-reais/api-design/analise.md, seção 4, e
+// exemplos/04-casos-reais/database/analise.md, seção 1.
+package handleropen
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer detecta sql.Open dentro de handlers HTTP
+var Analyzer = &analysis.Analyzer{
+	Name:     "handleropen",
+	Doc:      "detecta sql.Open chamado dentro de handlers HTTP",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		if !analysisutil.IsFunc(pass.TypesInfo, call, "database/sql", "Open", "OpenDB") {
+			return true
+		}
+		for i := len(stack) - 1; i >= 0; i-- {
+			var ftype *ast.FuncType
+			switch f := stack[i].(type) {
+			case *ast.FuncLit:
+				ftype = f.Type
+			case *ast.FuncDecl:
+				ftype = f.Type
+			default:
+				continue
+			}
+			if isHandler(pass.TypesInfo, ftype) {
+				pass.Reportf(call.Pos(), "sql.Open dentro de handler HTTP abre um pool por requisição; crie o *sql.DB uma vez e injete-o no handler")
+				return true
+			}
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// isHandler informa se a assinatura é (http.ResponseWriter, *http.Request)
+func isHandler(info *types.Info, ftype *ast.FuncType) bool {
+	var params []types.Type
+	for _, field := range ftype.Params.List {
+		t := info.TypeOf(field.Type)
+		n := max(len(field.Names), 1)
+		for range n {
+			params = append(params, t)
+		}
+	}
+	if len(params) != 2 {
+		return false
+	}
+	if _, ok := params[1].(*types.Pointer); !ok {
+		return false
+	}
+	return analysisutil.IsNamedType(params[0], "net/http", "ResponseWriter") &&
+		analysisutil.IsNamedType(params[1], "net/http", "Request")
+}
@@ -0,0 +1,12 @@
+package handleropen_test
+
+import (
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers/handleropen"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), handleropen.Analyzer, "apidesign")
+}
@@ -0,0 +1,210 @@
+package analysisutil
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Indent devolve a indentação da linha que contém pos, para que o texto
+// inserido por uma correção fique alinhado ao código ao redor
+func Indent(pass *analysis.Pass, pos token.Pos) string {
+	file := pass.Fset.File(pos)
+	line := file.Line(pos)
+	start := file.Offset(file.LineStart(line))
+	if pass.ReadFile != nil {
+		if src, err := pass.ReadFile(file.Name()); err == nil && start < len(src) {
+			i := start
+			for i < len(src) && (src[i] == '\t' || src[i] == ' ') {
+				i++
+			}
+			return string(src[start:i])
+		}
+	}
+	return strings.Repeat("\t", max(pass.Fset.Position(pos).Column-1, 0))
+}
+
+// InsertAfter devolve uma edição que insere as linhas de code logo após
+// stmt, com a mesma indentação dele. Um comentário // no fim da linha de
+// stmt continua nela.
+func InsertAfter(pass *analysis.Pass, stmt ast.Node, code ...string) analysis.TextEdit {
+	indent := Indent(pass, stmt.Pos())
+	var b strings.Builder
+	for _, line := range code {
+		b.WriteString("\n")
+		if line != "" {
+			b.WriteString(indent + line)
+		}
+	}
+	pos := lineEnd(pass, stmt.End())
+	return analysis.TextEdit{Pos: pos, End: pos, NewText: []byte(b.String())}
+}
+
+// lineEnd devolve o fim da linha de pos quando o resto dela contém apenas
+// espaços e um comentário //; caso contrário devolve o próprio pos
+func lineEnd(pass *analysis.Pass, pos token.Pos) token.Pos {
+	if pass.ReadFile == nil {
+		return pos
+	}
+	file := pass.Fset.File(pos)
+	src, err := pass.ReadFile(file.Name())
+	if err != nil {
+		return pos
+	}
+	start := file.Offset(pos)
+	end := start
+	for end < len(src) && src[end] != '\n' {
+		end++
+	}
+	rest := strings.TrimSpace(string(src[start:end]))
+	if rest != "" && !strings.HasPrefix(rest, "//") {
+		return pos
+	}
+	return file.Pos(end)
+}
+
+// EnclosingFile devolve o arquivo de pass que contém pos
+func EnclosingFile(pass *analysis.Pass, pos token.Pos) *ast.File {
+	for _, f := range pass.Files {
+		if f.FileStart <= pos && pos < f.FileEnd {
+			return f
+		}
+	}
+	return nil
+}
+
+// StmtInBlock devolve o comando de stack mais interno que está diretamente
+// dentro de um bloco ({...}, case ou select), junto com a lista que o contém
+func StmtInBlock(stack []ast.Node) (ast.Stmt, []ast.Stmt, bool) {
+	for i := len(stack) - 1; i > 0; i-- {
+		stmt, ok := stack[i].(ast.Stmt)
+		if !ok {
+			continue
+		}
+		switch parent := stack[i-1].(type) {
+		case *ast.BlockStmt:
+			return stmt, parent.List, true
+		case *ast.CaseClause:
+			return stmt, parent.Body, true
+		case *ast.CommClause:
+			return stmt, parent.Body, true
+		}
+	}
+	return nil, nil, false
+}
+
+// DeclaringStmt devolve o comando que atribui ou declara o resultado da
+// chamada no topo de stack, desde que ele esteja diretamente dentro de um
+// bloco. Em inicializações de if, switch ou for não há onde inserir código
+// logo depois.
+func DeclaringStmt(stack []ast.Node) (ast.Stmt, bool) {
+	stmt, _, ok := StmtInBlock(stack)
+	if !ok {
+		return nil, false
+	}
+	switch parent := stack[len(stack)-2].(type) {
+	case *ast.AssignStmt:
+		return stmt, stmt == parent
+	case *ast.ValueSpec:
+		decl, ok := stmt.(*ast.DeclStmt)
+		return stmt, ok && len(stack) >= 4 && stack[len(stack)-4] == decl
+	}
+	return nil, false
+}
+
+// Declared informa se name já está declarado no escopo visível em pos
+func Declared(pass *analysis.Pass, pos token.Pos, name string) bool {
+	scope := pass.Pkg.Scope().Innermost(pos)
+	if scope == nil {
+		return false
+	}
+	_, obj := scope.LookupParent(name, pos)
+	return obj != nil || types.Universe.Lookup(name) != nil
+}
+
+// Import devolve o nome pelo qual o pacote pkgPath é referenciado em file
+// e, se ele ainda não for importado, a edição que adiciona o import.
+// Devolve false quando o pacote só é importado com _ ou ponto.
+func Import(file *ast.File, pkgPath string) (string, []analysis.TextEdit, bool) {
+	for _, spec := range file.Imports {
+		p, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || p != pkgPath {
+			continue
+		}
+		if spec.Name == nil {
+			return path.Base(pkgPath), nil, true
+		}
+		if spec.Name.Name == "_" || spec.Name.Name == "." {
+			return "", nil, false
+		}
+		return spec.Name.Name, nil, true
+	}
+
+	quoted := strconv.Quote(pkgPath)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		if gen.Lparen.IsValid() {
+			pos := gen.Lparen + 1
+			return path.Base(pkgPath), []analysis.TextEdit{{Pos: pos, End: pos, NewText: []byte("\n\t" + quoted)}}, true
+		}
+		return path.Base(pkgPath), []analysis.TextEdit{{Pos: gen.Pos(), End: gen.Pos(), NewText: []byte("import " + quoted + "\n")}}, true
+	}
+	pos := file.Name.End()
+	return path.Base(pkgPath), []analysis.TextEdit{{Pos: pos, End: pos, NewText: []byte("\n\nimport " + quoted)}}, true
+}
+
+// TypeString formata t como ele deve ser escrito em file. Devolve false se
+// t depender de um pacote que file não importa.
+func TypeString(file *ast.File, pkg *types.Package, t types.Type) (string, bool) {
+	ok := true
+	s := types.TypeString(t, func(p *types.Package) string {
+		if p == pkg {
+			return ""
+		}
+		for _, spec := range file.Imports {
+			if path, err := strconv.Unquote(spec.Path.Value); err == nil && path == p.Path() {
+				if spec.Name != nil {
+					if spec.Name.Name == "_" || spec.Name.Name == "." {
+						break
+					}
+					return spec.Name.Name
+				}
+				return p.Name()
+			}
+		}
+		ok = false
+		return p.Name()
+	})
+	return s, ok
+}
+
+// ZeroValue devolve a expressão do valor zero de t escrita em file
+func ZeroValue(file *ast.File, pkg *types.Package, t types.Type) (string, bool) {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "false", true
+		case u.Info()&types.IsString != 0:
+			return `""`, true
+		case u.Info()&types.IsNumeric != 0:
+			return "0", true
+		case u.Kind() == types.UnsafePointer:
+			return "nil", true
+		}
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		return "nil", true
+	case *types.Struct, *types.Array:
+		s, ok := TypeString(file, pkg, t)
+		return s + "{}", ok
+	}
+	return "", false
+}
@@ -0,0 +1,187 @@
+// Package analysisutil reúne funções auxiliares compartilhadas pelos
+// analisadores do FubanGo.
+package analysisutil
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// IsFunc informa se call chama uma das funções de pacote pkgPath.names
+func IsFunc(info *types.Info, call *ast.CallExpr, pkgPath string, names ...string) bool {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != pkgPath {
+		return false
+	}
+	if fn.Signature().Recv() != nil {
+		return false
+	}
+	return slices.Contains(names, fn.Name())
+}
+
+// IsMethod informa se call chama um dos métodos names do tipo
+// pkgPath.typeName (com receptor por valor ou ponteiro)
+func IsMethod(info *types.Info, call *ast.CallExpr, pkgPath, typeName string, names ...string) bool {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || !slices.Contains(names, fn.Name()) {
+		return false
+	}
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return false
+	}
+	return IsNamedType(recv.Type(), pkgPath, typeName)
+}
+
+// IsNamedType informa se t (ou *t) é o tipo nomeado pkgPath.name
+func IsNamedType(t types.Type, pkgPath, name string) bool {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath && obj.Name() == name
+}
+
+// IsBuiltin informa se call chama a função embutida name (ex: close, panic)
+func IsBuiltin(info *types.Info, call *ast.CallExpr, name string) bool {
+	id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	b, ok := info.Uses[id].(*types.Builtin)
+	return ok && b.Name() == name
+}
+
+// Terminates informa se call encerra o fluxo atual de forma incondicional
+// (panic, os.Exit, log.Fatal*, runtime.Goexit...)
+func Terminates(info *types.Info, call *ast.CallExpr) bool {
+	return IsBuiltin(info, call, "panic") ||
+		IsFunc(info, call, "os", "Exit") ||
+		IsFunc(info, call, "runtime", "Goexit") ||
+		IsFunc(info, call, "log", "Fatal", "Fatalf", "Fatalln", "Panic", "Panicf", "Panicln")
+}
+
+// Key identifica uma expressão simples formada por um identificador
+// seguido de seletores de campo (ex: ch, p.output, s.pipe.done).
+type Key struct {
+	Root types.Object
+	Path string
+}
+
+// KeyOf devolve a Key de expr e false se expr não for uma expressão simples
+func KeyOf(info *types.Info, expr ast.Expr) (Key, bool) {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		obj := info.ObjectOf(e)
+		if obj == nil {
+			return Key{}, false
+		}
+		return Key{Root: obj}, true
+	case *ast.SelectorExpr:
+		if sel, ok := info.Selections[e]; !ok || sel.Kind() != types.FieldVal {
+			return Key{}, false
+		}
+		k, ok := KeyOf(info, e.X)
+		if !ok {
+			return Key{}, false
+		}
+		k.Path += "." + e.Sel.Name
+		return k, true
+	}
+	return Key{}, false
+}
+
+// Enclosing devolve o nó mais interno de stack que seja do tipo T
+func Enclosing[T ast.Node](stack []ast.Node) (T, bool) {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if n, ok := stack[i].(T); ok {
+			return n, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Render formata uma expressão como código-fonte
+func Render(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, expr); err != nil {
+		return types.ExprString(expr)
+	}
+	return buf.String()
+}
+
+// sqlReceivers são os tipos de database/sql que executam consultas
+var sqlReceivers = []string{"DB", "Tx", "Conn", "Stmt"}
+
+// SQLMethod devolve o nome do método de database/sql chamado por call
+// (Query, ExecContext...) quando o receptor é *sql.DB, *sql.Tx, *sql.Conn
+// ou *sql.Stmt
+func SQLMethod(info *types.Info, call *ast.CallExpr) (string, bool) {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok {
+		return "", false
+	}
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return "", false
+	}
+	for _, name := range sqlReceivers {
+		if IsNamedType(recv.Type(), "database/sql", name) {
+			return fn.Name(), true
+		}
+	}
+	return "", false
+}
+
+// SQLQueryArg devolve o argumento que contém o texto SQL de uma chamada a
+// Query/Exec/Prepare (e variantes *Context) de database/sql
+func SQLQueryArg(info *types.Info, call *ast.CallExpr) (ast.Expr, bool) {
+	name, ok := SQLMethod(info, call)
+	if !ok {
+		return nil, false
+	}
+	i := 0
+	switch name {
+	case "Query", "QueryRow", "Exec", "Prepare":
+	case "QueryContext", "QueryRowContext", "ExecContext", "PrepareContext":
+		i = 1
+	default:
+		return nil, false
+	}
+	fn := typeutil.Callee(info, call).(*types.Func)
+	if IsNamedType(fn.Signature().Recv().Type(), "database/sql", "Stmt") || i >= len(call.Args) {
+		return nil, false // Stmt já carrega o SQL preparado
+	}
+	return call.Args[i], true
+}
+
+var errorType = types.Universe.Lookup("error").Type()
+
+// IsError informa se t é exatamente o tipo error
+func IsError(t types.Type) bool {
+	return t != nil && types.Identical(t, errorType)
+}
+
+// ImplementsError informa se t satisfaz a interface error
+func ImplementsError(t types.Type) bool {
+	return t != nil && types.Implements(t, errorType.Underlying().(*types.Interface))
+}
+
+// IsPrint informa se call imprime na saída ou em log: fmt.Print*,
+// fmt.Fprint*, log.Print* ou os métodos Print* de *log.Logger
+func IsPrint(info *types.Info, call *ast.CallExpr) bool {
+	return IsFunc(info, call, "fmt", "Print", "Printf", "Println", "Fprint", "Fprintf", "Fprintln") ||
+		IsFunc(info, call, "log", "Print", "Printf", "Println") ||
+		IsMethod(info, call, "log", "Logger", "Print", "Printf", "Println")
+}
@@ -0,0 +1,81 @@
+// Package fixtest verifica as correções sugeridas pelos analisadores do
+// FubanGo nos testes.
+package fixtest
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// Run executa a sobre os pacotes de testdata, compara as correções
+// sugeridas com os arquivos .golden e analisa de novo o código corrigido.
+// Depois de aplicadas as correções, o código precisa compilar e nenhum
+// diagnóstico corrigível pode sobrar.
+func Run(t *testing.T, a *analysis.Analyzer, pkgs ...string) {
+	t.Helper()
+	dir := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, dir, a, pkgs...)
+
+	fixed := t.TempDir()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || strings.HasSuffix(path, ".golden") {
+			return err
+		}
+		src := path
+		if _, err := os.Stat(path + ".golden"); err == nil {
+			src = path + ".golden"
+		}
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(fixed, rel)
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		return os.WriteFile(dst, data, 0o644)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// As anotações // want continuam no código corrigido; aqui só importa
+	// o que sobrou, então as divergências com elas são ignoradas.
+	rec := new(recorder)
+	results := analysistest.Run(rec, fixed, a, pkgs...)
+	if len(results) == 0 {
+		t.Fatalf("código corrigido não pôde ser carregado:\n%s", strings.Join(rec.errors, "\n"))
+	}
+	for _, r := range results {
+		if r.Action.Err != nil {
+			t.Errorf("código corrigido de %s: %v", r.Action.Package.PkgPath, r.Action.Err)
+			continue
+		}
+		for _, d := range r.Action.Diagnostics {
+			if len(d.SuggestedFixes) > 0 {
+				posn := r.Action.Package.Fset.Position(d.Pos)
+				t.Errorf("%s: diagnóstico corrigível persiste após aplicar as correções: %s", posn, d.Message)
+			}
+		}
+	}
+}
+
+// recorder guarda os erros reportados por analysistest sem falhar o teste
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
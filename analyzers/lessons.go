@@ -0,0 +1,152 @@
+package analyzers
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/lucasrafaldini/fubango/analyzers/chanloop"
+	"github.com/lucasrafaldini/fubango/analyzers/ctxcancel"
+	"github.com/lucasrafaldini/fubango/analyzers/ctxiface"
+	"github.com/lucasrafaldini/fubango/analyzers/doubleclose"
+	"github.com/lucasrafaldini/fubango/analyzers/errdiscard"
+	"github.com/lucasrafaldini/fubango/analyzers/errwrap"
+	"github.com/lucasrafaldini/fubango/analyzers/goloopvar"
+	"github.com/lucasrafaldini/fubango/analyzers/handleropen"
+	"github.com/lucasrafaldini/fubango/analyzers/lockcopy"
+	"github.com/lucasrafaldini/fubango/analyzers/logreturn"
+	"github.com/lucasrafaldini/fubango/analyzers/panicvalidate"
+	"github.com/lucasrafaldini/fubango/analyzers/recoverall"
+	"github.com/lucasrafaldini/fubango/analyzers/rowsclose"
+	"github.com/lucasrafaldini/fubango/analyzers/sqlconcat"
+	"github.com/lucasrafaldini/fubango/analyzers/sqlloop"
+	"github.com/lucasrafaldini/fubango/analyzers/uncheckedassert"
+	"github.com/lucasrafaldini/fubango/analyzers/wgadd"
+	"github.com/lucasrafaldini/fubango/catalog"
+	"github.com/lucasrafaldini/fubango/exemplos"
+	"golang.org/x/tools/go/analysis"
+)
+
+// Severity é a gravidade dos achados de um analisador. Os nomes seguem os
+// níveis do SARIF.
+type Severity int
+
+const (
+	Note Severity = iota
+	Warning
+	Error
+)
+
+var severityNames = []string{"note", "warning", "error"}
+
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return "Severity(" + strconv.Itoa(int(s)) + ")"
+	}
+	return severityNames[s]
+}
+
+// ParseSeverity interpreta "note", "warning" ou "error"
+func ParseSeverity(s string) (Severity, error) {
+	for i, name := range severityNames {
+		if s == name {
+			return Severity(i), nil
+		}
+	}
+	return 0, fmt.Errorf("gravidade desconhecida %q", s)
+}
+
+// Lesson aponta para uma seção numerada de um analise.md
+type Lesson struct {
+	Topic   string // nível/categoria, ex: 04-casos-reais/database
+	Section int
+}
+
+// Category devolve a categoria do tópico (ex: database)
+func (l Lesson) Category() string {
+	return path.Base(l.Topic)
+}
+
+// Link devolve o caminho da seção a partir da raiz do repositório,
+// ex: exemplos/04-casos-reais/database/analise.md#2
+func (l Lesson) Link() string {
+	return fmt.Sprintf("exemplos/%s/analise.md#%d", l.Topic, l.Section)
+}
+
+// Info descreve um analisador para os relatórios: a gravidade dos achados
+// e as lições que explicam o anti-padrão, da principal para as demais.
+type Info struct {
+	Severity Severity
+	Lessons  []Lesson
+}
+
+// Category devolve a categoria da lição principal
+func (i Info) Category() string {
+	if len(i.Lessons) == 0 {
+		return ""
+	}
+	return i.Lessons[0].Category()
+}
+
+var severities = map[*analysis.Analyzer]Severity{
+	goloopvar.Analyzer:   Error,
+	doubleclose.Analyzer: Error,
+	lockcopy.Analyzer:    Error,
+	wgadd.Analyzer:       Error,
+	chanloop.Analyzer:    Warning,
+
+	sqlconcat.Analyzer:       Error,
+	rowsclose.Analyzer:       Warning,
+	sqlloop.Analyzer:         Warning,
+	handleropen.Analyzer:     Warning,
+	uncheckedassert.Analyzer: Warning,
+
+	errdiscard.Analyzer:    Warning,
+	panicvalidate.Analyzer: Warning,
+	errwrap.Analyzer:       Note,
+	logreturn.Analyzer:     Note,
+	recoverall.Analyzer:    Warning,
+	ctxcancel.Analyzer:     Warning,
+	ctxiface.Analyzer:      Note,
+}
+
+// lessons liga cada analisador às seções que o citam nos manifestos
+// embutidos, na ordem do catálogo: tópicos por nível/categoria e seções por
+// número. A primeira é a lição principal.
+var lessons = sync.OnceValue(func() map[string][]Lesson {
+	manifests, err := catalog.LoadManifests(exemplos.Manifests)
+	if err != nil {
+		panic(err) // os manifestos são validados por fubango catalog check
+	}
+	out := make(map[string][]Lesson)
+	for topic, m := range manifests {
+		for _, e := range m.AntiPatterns {
+			for _, name := range e.Analyzers {
+				out[name] = append(out[name], Lesson{Topic: topic, Section: e.Number})
+			}
+		}
+	}
+	for _, ls := range out {
+		slices.SortStableFunc(ls, func(a, b Lesson) int {
+			if a.Topic != b.Topic {
+				return strings.Compare(a.Topic, b.Topic)
+			}
+			return a.Section - b.Section
+		})
+	}
+	return out
+})
+
+// Describe devolve a gravidade e as lições de a. As lições vêm dos
+// manifesto.json dos tópicos (campo analyzers). Analisadores que não são
+// do FubanGo recebem Warning e nenhuma lição.
+func Describe(a *analysis.Analyzer) Info {
+	severity, ok := severities[a]
+	if !ok {
+		return Info{Severity: Warning}
+	}
+	return Info{Severity: severity, Lessons: lessons()[a.Name]}
+}
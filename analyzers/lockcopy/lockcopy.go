@@ -0,0 +1,150 @@
+// Package lockcopy define um Analyzer que detecta cópias por valor de
+// tipos de sincronização (sync.Mutex, sync.WaitGroup...).
+//
+// Copiar um mutex cria um lock independente: a cópia não protege os mesmos
+// dados que o original. Copiar um WaitGroup faz Done operar sobre outro
+// contador, e Wait nunca termina:
+//
+//	m2 := m      // m contém sync.Mutex
+//	myWg := wg   // cópia de sync.WaitGroup
+//
+// São reportadas atribuições, declarações var, variáveis de range e
+// parâmetros ou receptores que copiam um valor contendo esses tipos.
+//
+// Lições: exemplos/02-intermediario/concorrencia/analise.md, seções 7 e 8.
+package lockcopy
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer detecta cópias de valores que contêm locks
+var Analyzer = &analysis.Analyzer{
+	Name:     "lockcopy",
+	Doc:      "detecta sync.Mutex, sync.WaitGroup e afins copiados por valor",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// noCopy lista os tipos que não podem ser copiados após o primeiro uso
+var noCopy = map[string][]string{
+	"sync":        {"Mutex", "RWMutex", "WaitGroup", "Once", "Cond", "Map", "Pool"},
+	"sync/atomic": {"Bool", "Int32", "Int64", "Uint32", "Uint64", "Uintptr", "Value", "Pointer"},
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.RangeStmt)(nil),
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, rhs := range n.Rhs {
+				checkCopy(pass, rhs, "atribuição")
+			}
+		case *ast.ValueSpec:
+			for _, v := range n.Values {
+				checkCopy(pass, v, "declaração")
+			}
+		case *ast.RangeStmt:
+			if n.Value != nil {
+				if lock := lockPath(pass.TypesInfo.TypeOf(n.Value), nil); lock != "" {
+					pass.Reportf(n.Value.Pos(), "variável de range %s copia um valor que contém %s",
+						analysisutil.Render(pass.Fset, n.Value), lock)
+				}
+			}
+		case *ast.FuncDecl:
+			if n.Recv != nil {
+				checkFields(pass, n.Recv, "receptor")
+			}
+			checkFields(pass, n.Type.Params, "parâmetro")
+		case *ast.FuncLit:
+			checkFields(pass, n.Type.Params, "parâmetro")
+		}
+	})
+	return nil, nil
+}
+
+// checkCopy reporta expr quando ela lê (e portanto copia) uma variável
+// existente cujo tipo contém um lock
+func checkCopy(pass *analysis.Pass, expr ast.Expr, what string) {
+	switch ast.Unparen(expr).(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.IndexExpr, *ast.StarExpr:
+	default:
+		return // literais, chamadas e &x não copiam um lock existente
+	}
+	tv, ok := pass.TypesInfo.Types[expr]
+	if !ok || !tv.IsValue() {
+		return
+	}
+	if lock := lockPath(tv.Type, nil); lock != "" {
+		pass.Reportf(expr.Pos(), "%s copia %s, que contém %s; use um ponteiro",
+			what, analysisutil.Render(pass.Fset, expr), lock)
+	}
+}
+
+func checkFields(pass *analysis.Pass, fields *ast.FieldList, what string) {
+	if fields == nil {
+		return
+	}
+	for _, field := range fields.List {
+		if lock := lockPath(pass.TypesInfo.TypeOf(field.Type), nil); lock != "" {
+			pass.Reportf(field.Type.Pos(), "%s recebe %s por valor, que contém %s; use um ponteiro",
+				what, analysisutil.Render(pass.Fset, field.Type), lock)
+		}
+	}
+}
+
+// lockPath devolve o nome do tipo de sincronização contido em t por valor
+// (ex: "sync.Mutex") ou "" se não houver nenhum
+func lockPath(t types.Type, seen map[types.Type]bool) string {
+	if t == nil {
+		return ""
+	}
+	if seen == nil {
+		seen = make(map[types.Type]bool)
+	}
+	if seen[t] {
+		return ""
+	}
+	seen[t] = true
+
+	for pkg, names := range noCopy {
+		for _, name := range names {
+			if isValueOf(t, pkg, name) {
+				return pkg + "." + name
+			}
+		}
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Struct:
+		for i := range u.NumFields() {
+			if lock := lockPath(u.Field(i).Type(), seen); lock != "" {
+				return lock
+			}
+		}
+	case *types.Array:
+		return lockPath(u.Elem(), seen)
+	}
+	return ""
+}
+
+func isValueOf(t types.Type, pkgPath, name string) bool {
+	if _, ok := t.(*types.Pointer); ok {
+		return false
+	}
+	return analysisutil.IsNamedType(t, pkgPath, name)
+}
@@ -0,0 +1,12 @@
+package lockcopy_test
+
+import (
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers/lockcopy"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), lockcopy.Analyzer, "concorrencia", "params")
+}
@@ -0,0 +1,33 @@
+package params
+
+import "sync"
+
+type Counter struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (c Counter) Value() int { // want `receptor recebe Counter por valor, que contém sync.Mutex`
+	return c.n
+}
+
+func Sum(counters []Counter) int {
+	total := 0
+	for _, c := range counters { // want `variável de range c copia um valor que contém sync.Mutex`
+		total += c.n
+	}
+	return total
+}
+
+func Wait(wg sync.WaitGroup) { // want `parâmetro recebe sync.WaitGroup por valor`
+	wg.Wait()
+}
+
+func Fine(c *Counter, counters []Counter) *Counter {
+	var other = &Counter{}
+	for i := range counters {
+		counters[i].n++
+	}
+	_ = other
+	return c
+}
@@ -0,0 +1,84 @@
+// Package logreturn define um Analyzer que detecta erros registrados em log
+// e também retornados.
+//
+// Quem recebe o erro vai tratá-lo (e provavelmente registrá-lo) de novo. O
+// resultado são mensagens duplicadas em cada camada e logs que não dizem
+// qual delas realmente tratou a falha:
+//
+//	if value == 0 {
+//		fmt.Println("Erro: divisão por zero")
+//		return 0, fmt.Errorf("divisão por zero")
+//	}
+//
+// Trate cada erro uma única vez: retorne-o com contexto ou registre-o e siga
+// em frente.
+//
+// Lição: exemplos/02-intermediario/error-handling/analise.md, seção 5.
+package logreturn
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer detecta um log seguido do retorno de um erro no mesmo bloco
+var Analyzer = &analysis.Analyzer{
+	Name:     "logreturn",
+	Doc:      "detecta erros registrados em log e também retornados",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.Preorder([]ast.Node{(*ast.BlockStmt)(nil)}, func(n ast.Node) {
+		var logged *ast.CallExpr
+		for _, stmt := range n.(*ast.BlockStmt).List {
+			switch stmt := stmt.(type) {
+			case *ast.ExprStmt:
+				if call, ok := stmt.X.(*ast.CallExpr); ok && isLog(pass.TypesInfo, call) {
+					logged = call
+				}
+			case *ast.ReturnStmt:
+				if logged != nil && returnsError(pass.TypesInfo, stmt) {
+					pass.Reportf(logged.Pos(), "erro registrado com %s e também retornado; trate-o uma única vez (retorne ou registre)",
+						analysisutil.Render(pass.Fset, logged.Fun))
+				}
+				return
+			}
+		}
+	})
+	return nil, nil
+}
+
+// isLog informa se call registra uma mensagem: impressão na saída padrão,
+// em os.Stderr, pelo pacote log ou por log/slog nos níveis Warn e Error
+func isLog(info *types.Info, call *ast.CallExpr) bool {
+	if analysisutil.IsFunc(info, call, "fmt", "Fprint", "Fprintf", "Fprintln") {
+		sel, ok := ast.Unparen(call.Args[0]).(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		v, ok := info.Uses[sel.Sel].(*types.Var)
+		return ok && v.Pkg() != nil && v.Pkg().Path() == "os" && (v.Name() == "Stderr" || v.Name() == "Stdout")
+	}
+	return analysisutil.IsPrint(info, call) ||
+		analysisutil.IsFunc(info, call, "log/slog", "Error", "Warn", "ErrorContext", "WarnContext") ||
+		analysisutil.IsMethod(info, call, "log/slog", "Logger", "Error", "Warn", "ErrorContext", "WarnContext")
+}
+
+// returnsError informa se ret devolve um error diferente de nil
+func returnsError(info *types.Info, ret *ast.ReturnStmt) bool {
+	if len(ret.Results) == 0 {
+		return false
+	}
+	last := ret.Results[len(ret.Results)-1]
+	tv, ok := info.Types[last]
+	return ok && !tv.IsNil() && analysisutil.ImplementsError(tv.Type)
+}
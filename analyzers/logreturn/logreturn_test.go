@@ -0,0 +1,12 @@
+package logreturn_test
+
+import (
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers/logreturn"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), logreturn.Analyzer, "errorhandling", "logging")
+}
@@ -0,0 +1,56 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+)
+
+func Load(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Printf("lendo %s: %v", path, err) // want `erro registrado com log.Printf e também retornado`
+		return nil, err
+	}
+	return data, nil
+}
+
+func Stderr(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		fmt.Fprintln(os.Stderr, err) // want `erro registrado com fmt.Fprintln`
+		return fmt.Errorf("stat: %w", err)
+	}
+	return nil
+}
+
+func Structured(logger *slog.Logger, id int) error {
+	if id < 0 {
+		logger.Error("id inválido", "id", id) // want `erro registrado com logger.Error`
+		return errors.New("id inválido")
+	}
+	slog.Info("ok", "id", id)
+	return nil
+}
+
+func Handler(w http.ResponseWriter, id int) error {
+	if id < 0 {
+		fmt.Fprintln(w, "id inválido") // resposta ao cliente, não log
+		return errors.New("id inválido")
+	}
+	return nil
+}
+
+func LogOnly(path string) {
+	if _, err := os.Stat(path); err != nil {
+		log.Println(err)
+		return
+	}
+}
+
+func Success(n int) (int, error) {
+	fmt.Println("resultado:", n)
+	return n, nil
+}
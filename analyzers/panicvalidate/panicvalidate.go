@@ -0,0 +1,101 @@
+// Package panicvalidate define um Analyzer que detecta panic usado para
+// validar parâmetros.
+//
+// Entrada inválida é uma condição esperada, não uma falha do programa.
+// Entrar em panic obriga o chamador a usar recover para se proteger e
+// derruba o processo inteiro quando ninguém o faz:
+//
+//	func PanicInsteadOfError(value int) int {
+//		if value < 0 {
+//			panic("valor negativo não permitido")
+//		}
+//		return value * 2
+//	}
+//
+// Retorne um error em vez disso. Funções Must*, que por convenção entram em
+// panic quando falham, e init são ignoradas.
+//
+// Lição: exemplos/02-intermediario/error-handling/analise.md, seção 2.
+package panicvalidate
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer detecta panic dentro de um if que valida parâmetros
+var Analyzer = &analysis.Analyzer{
+	Name:     "panicvalidate",
+	Doc:      "detecta panic usado para validar parâmetros no lugar de um error",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		if !analysisutil.IsBuiltin(pass.TypesInfo, call, "panic") {
+			return true
+		}
+
+		var ifs []*ast.IfStmt
+		for i := len(stack) - 2; i >= 0; i-- {
+			switch node := stack[i].(type) {
+			case *ast.IfStmt:
+				ifs = append(ifs, node)
+			case *ast.FuncLit:
+				return true
+			case *ast.FuncDecl:
+				if name := node.Name.Name; name == "init" || strings.HasPrefix(name, "Must") {
+					return true
+				}
+				params := paramObjects(pass.TypesInfo, node)
+				for _, stmt := range ifs {
+					if param := usedParam(pass.TypesInfo, stmt.Cond, params); param != "" {
+						pass.Reportf(call.Pos(), "panic usado para validar o parâmetro %s; retorne um error ao chamador", param)
+						return true
+					}
+				}
+				return true
+			}
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// paramObjects devolve os parâmetros declarados por fn
+func paramObjects(info *types.Info, fn *ast.FuncDecl) map[types.Object]bool {
+	params := make(map[types.Object]bool)
+	for _, field := range fn.Type.Params.List {
+		for _, name := range field.Names {
+			if obj := info.Defs[name]; obj != nil {
+				params[obj] = true
+			}
+		}
+	}
+	return params
+}
+
+// usedParam devolve o nome do primeiro parâmetro referenciado em cond
+func usedParam(info *types.Info, cond ast.Expr, params map[types.Object]bool) string {
+	var name string
+	ast.Inspect(cond, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && name == "" && params[info.Uses[id]] {
+			name = id.Name
+		}
+		return name == ""
+	})
+	return name
+}
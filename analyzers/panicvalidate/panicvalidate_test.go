@@ -0,0 +1,12 @@
+package panicvalidate_test
+
+import (
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers/panicvalidate"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), panicvalidate.Analyzer, "errorhandling", "validation")
+}
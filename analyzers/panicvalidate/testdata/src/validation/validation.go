@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+)
+
+type Config struct {
+	Workers int
+}
+
+func (c *Config) Validate(max int) {
+	if c.Workers > max {
+		panic("workers acima do limite") // want `panic usado para validar o parâmetro max`
+	}
+}
+
+func Name(s string) string {
+	switch {
+	case len(s) == 0:
+		panic("nome vazio") // não está em um if
+	}
+	if len(s) > 10 {
+		if s[0] == ' ' {
+			panic(fmt.Sprintf("nome inválido: %q", s)) // want `panic usado para validar o parâmetro s`
+		}
+	}
+	return s
+}
+
+func MustCompile(expr string) *regexp.Regexp {
+	re, err := regexp.Compile(expr)
+	if err != nil || expr == "" {
+		panic(err)
+	}
+	return re
+}
+
+var table = map[string]int{}
+
+func init() {
+	if len(table) != 0 {
+		panic("tabela já inicializada")
+	}
+}
+
+func Invariant(n int) int {
+	total := n * 2
+	if total < 0 {
+		panic("overflow") // invariante interna, não validação de parâmetro
+	}
+	go func() {
+		if n < 0 {
+			panic("dentro de goroutine")
+		}
+	}()
+	return total
+}
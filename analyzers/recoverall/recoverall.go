@@ -0,0 +1,165 @@
+// Package recoverall define um Analyzer que detecta recover() que engole
+// qualquer panic.
+//
+// Recuperar todo panic sem distinção esconde bugs reais (nil pointer, índice
+// fora do intervalo) e deixa o programa seguir com estado inconsistente:
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			fmt.Println("Recuperado de:", r)
+//		}
+//	}()
+//
+// Um recover é considerado seletivo quando inspeciona o valor recuperado
+// (type switch, type assertion ou comparação) ou volta a chamar panic para
+// os casos que não sabe tratar. Também é seletivo o recover que entrega o
+// valor a quem chamou, retornando-o, enviando-o a um canal ou guardando-o,
+// mesmo embrulhado em um error, em um resultado nomeado ou em um campo:
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			err = fmt.Errorf("panic: %v", r)
+//		}
+//	}()
+//
+// Apenas registrar o valor (log.Println(r), logger.Error("panic", r)) não
+// basta: o panic continua engolido.
+//
+// Lição: exemplos/02-intermediario/error-handling/analise.md, seção 7.
+package recoverall
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer detecta recover() sem filtro e sem re-panic
+var Analyzer = &analysis.Analyzer{
+	Name:     "recoverall",
+	Doc:      "detecta recover() que engole qualquer panic sem distinção",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		if !analysisutil.IsBuiltin(pass.TypesInfo, call, "recover") {
+			return true
+		}
+
+		var body *ast.BlockStmt
+		for i := len(stack) - 2; i >= 0 && body == nil; i-- {
+			switch f := stack[i].(type) {
+			case *ast.FuncLit:
+				body = f.Body
+			case *ast.FuncDecl:
+				body = f.Body
+			}
+		}
+		if body == nil {
+			return true
+		}
+
+		var recovered *types.Var
+		if assign, ok := stack[len(stack)-2].(*ast.AssignStmt); ok && len(assign.Lhs) == 1 {
+			if id, ok := assign.Lhs[0].(*ast.Ident); ok {
+				recovered, _ = pass.TypesInfo.ObjectOf(id).(*types.Var)
+			}
+		}
+		if !selective(pass.TypesInfo, body, recovered) {
+			pass.Reportf(call.Pos(), "recover() engole qualquer panic; trate apenas os panics esperados e chame panic novamente para os demais")
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// selective informa se body volta a entrar em panic, inspeciona o valor
+// recuperado em r ou o entrega a quem chamou. Funções aninhadas não são
+// consideradas.
+func selective(info *types.Info, body *ast.BlockStmt, r *types.Var) bool {
+	isR := func(expr ast.Expr) bool {
+		id, ok := ast.Unparen(expr).(*ast.Ident)
+		return ok && r != nil && info.Uses[id] == r
+	}
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			found = found || analysisutil.IsBuiltin(info, n, "panic")
+		case *ast.TypeAssertExpr:
+			found = found || isR(n.X)
+		case *ast.BinaryExpr:
+			found = found || (isR(n.X) && !info.Types[n.Y].IsNil()) || (isR(n.Y) && !info.Types[n.X].IsNil())
+		}
+		return !found
+	})
+	return found || (r != nil && propagated(info, body, r))
+}
+
+// propagated informa se body entrega o valor recuperado em r a quem chamou:
+// retornando-o, enviando-o a um canal ou atribuindo-o (mesmo embrulhado,
+// como em fmt.Errorf("...: %v", r)) a um campo ou a uma variável local da
+// função externa, como um resultado nomeado. Passar r a uma chamada
+// qualquer (um logger, por exemplo) não conta: o panic continua engolido.
+func propagated(info *types.Info, body *ast.BlockStmt, r *types.Var) bool {
+	uses := func(expr ast.Expr) bool {
+		found := false
+		ast.Inspect(expr, func(n ast.Node) bool {
+			if _, ok := n.(*ast.FuncLit); ok {
+				return false
+			}
+			if id, ok := n.(*ast.Ident); ok && info.Uses[id] == r {
+				found = true
+			}
+			return !found
+		})
+		return found
+	}
+	escapes := func(lhs ast.Expr) bool {
+		switch lhs := ast.Unparen(lhs).(type) {
+		case *ast.SelectorExpr:
+			return true
+		case *ast.Ident:
+			v, ok := info.ObjectOf(lhs).(*types.Var)
+			global := ok && v.Pkg() != nil && v.Parent() == v.Pkg().Scope()
+			return ok && !global && (v.Pos() < body.Pos() || v.Pos() >= body.End())
+		}
+		return false
+	}
+
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			for _, res := range n.Results {
+				found = found || uses(res)
+			}
+		case *ast.SendStmt:
+			found = found || uses(n.Value)
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for i, rhs := range n.Rhs {
+					found = found || (uses(rhs) && escapes(n.Lhs[i]))
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
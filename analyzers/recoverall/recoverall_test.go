@@ -0,0 +1,12 @@
+package recoverall_test
+
+import (
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers/recoverall"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), recoverall.Analyzer, "errorhandling", "recovering")
+}
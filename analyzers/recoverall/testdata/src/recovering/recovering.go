@@ -0,0 +1,113 @@
+package recovering
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+var errAbort = errors.New("abortado")
+
+func Discard() {
+	defer func() {
+		recover() // want `recover\(\) engole qualquer panic`
+	}()
+}
+
+// Named devolve o panic como error a quem chamou
+func Named() (err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return nil
+}
+
+type panicError struct{ value any }
+
+func Field() (pe *panicError) {
+	defer func() {
+		if r := recover(); r != nil {
+			pe = &panicError{value: r}
+		}
+	}()
+	return nil
+}
+
+func Sent(errs chan<- any) {
+	defer func() {
+		if r := recover(); r != nil {
+			errs <- r
+		}
+	}()
+}
+
+func logError(msg string, keyvals ...any) {}
+
+// Logged só registra o panic com um logger próprio: continua engolido
+func Logged() {
+	defer func() {
+		if r := recover(); r != nil { // want `recover\(\) engole qualquer panic`
+			logError("recuperado", "panic", r)
+		}
+	}()
+}
+
+// Local embrulha o panic em uma variável que ninguém vê
+func Local() {
+	defer func() {
+		if r := recover(); r != nil { // want `recover\(\) engole qualquer panic`
+			err := fmt.Errorf("panic: %v", r)
+			log.Println(err)
+		}
+	}()
+}
+
+var lastPanic any
+
+func Global() {
+	defer func() {
+		if r := recover(); r != nil { // want `recover\(\) engole qualquer panic`
+			lastPanic = r
+		}
+	}()
+}
+
+func Sentinel() {
+	defer func() {
+		if r := recover(); r != errAbort {
+			panic(r)
+		}
+	}()
+}
+
+func Compare() {
+	defer func() {
+		if r := recover(); r == errAbort {
+			log.Println("abortado")
+		}
+	}()
+}
+
+func Assert() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			}
+		}
+	}()
+	return nil
+}
+
+func Nested() {
+	defer func() {
+		if r := recover(); r != nil { // want `recover\(\) engole qualquer panic`
+			go func() {
+				panic(r) // em outra goroutine, não propaga para quem chamou
+			}()
+		}
+	}()
+}
@@ -0,0 +1,122 @@
+package rowsclose
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+)
+
+// deferClose sugere inserir defer rows.Close() logo após a declaração de
+// rows ou, se ela for seguida por "if err != nil", logo após essa
+// verificação, como em bom.go
+func deferClose(pass *analysis.Pass, stack []ast.Node, rows *types.Var, errVar types.Object) []analysis.SuggestedFix {
+	stmt, ok := analysisutil.DeclaringStmt(stack)
+	if !ok {
+		return nil
+	}
+	_, list, _ := analysisutil.StmtInBlock(stack)
+	if i := slices.Index(list, stmt); errVar != nil && i+1 < len(list) && checksErr(pass.TypesInfo, list[i+1], errVar) {
+		stmt = list[i+1]
+	}
+	return []analysis.SuggestedFix{{
+		Message:   "Adicionar defer " + rows.Name() + ".Close()",
+		TextEdits: []analysis.TextEdit{analysisutil.InsertAfter(pass, stmt, "defer "+rows.Name()+".Close()")},
+	}}
+}
+
+// checksErr informa se stmt é um "if err != nil" sobre errVar
+func checksErr(info *types.Info, stmt ast.Stmt, errVar types.Object) bool {
+	ifStmt, ok := stmt.(*ast.IfStmt)
+	if !ok || ifStmt.Init != nil {
+		return false
+	}
+	cond, ok := ifStmt.Cond.(*ast.BinaryExpr)
+	if !ok || cond.Op != token.NEQ || !info.Types[cond.Y].IsNil() {
+		return false
+	}
+	id, ok := ast.Unparen(cond.X).(*ast.Ident)
+	return ok && info.Uses[id] == errVar
+}
+
+// checkErr sugere verificar rows.Err() logo após o último loop de
+// rows.Next() de decl. Se a função devolve error, o erro é devolvido com
+// os demais resultados zerados; se ela não tem resultados, o erro é
+// registrado com log.Print. Nos outros casos não há correção mecânica.
+func checkErr(pass *analysis.Pass, decl *ast.FuncDecl, rows *types.Var) []analysis.SuggestedFix {
+	var loop *ast.ForStmt
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ForStmt:
+			if callsNext(pass.TypesInfo, n.Cond, rows) {
+				loop = n
+			}
+		}
+		return true
+	})
+	fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+	if loop == nil || !ok {
+		return nil
+	}
+	file := analysisutil.EnclosingFile(pass, decl.Pos())
+	if file == nil {
+		return nil
+	}
+
+	var (
+		handle string
+		edits  []analysis.TextEdit
+	)
+	results := fn.Signature().Results()
+	switch {
+	case results.Len() == 0:
+		name, imports, ok := analysisutil.Import(file, "log")
+		if !ok {
+			return nil
+		}
+		handle = name + ".Print(err)"
+		edits = imports
+	case analysisutil.IsError(results.At(results.Len() - 1).Type()):
+		var values []string
+		for i := range results.Len() - 1 {
+			zero, ok := analysisutil.ZeroValue(file, pass.Pkg, results.At(i).Type())
+			if !ok {
+				return nil
+			}
+			values = append(values, zero)
+		}
+		handle = "return " + strings.Join(append(values, "err"), ", ")
+	default:
+		return nil
+	}
+
+	edits = append(edits, analysisutil.InsertAfter(pass, loop,
+		"if err := "+rows.Name()+".Err(); err != nil {",
+		"\t"+handle,
+		"}",
+	))
+	return []analysis.SuggestedFix{{
+		Message:   "Verificar " + rows.Name() + ".Err() após o loop",
+		TextEdits: edits,
+	}}
+}
+
+// callsNext informa se cond é a chamada rows.Next()
+func callsNext(info *types.Info, cond ast.Expr, rows *types.Var) bool {
+	call, ok := ast.Unparen(cond).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Next" {
+		return false
+	}
+	id, ok := ast.Unparen(sel.X).(*ast.Ident)
+	return ok && info.Uses[id] == rows
+}
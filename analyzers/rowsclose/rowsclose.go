@@ -0,0 +1,157 @@
+// Package rowsclose define um Analyzer que detecta *sql.Rows que nunca são
+// fechados ou cuja iteração termina sem verificar rows.Err().
+//
+// Cada *sql.Rows aberto segura uma conexão do pool até ser fechado. Esquecer
+// rows.Close() (ou descartar o resultado de db.Query) esgota o pool aos
+// poucos; esquecer rows.Err() esconde falhas que interromperam a iteração:
+//
+//	rows, _ := db.Query("SELECT * FROM users")
+//	for rows.Next() {
+//		rows.Scan(&name)
+//	}
+//	// rows nunca fechado, rows.Err() nunca verificado
+//
+// Quando possível, o analisador sugere inserir defer rows.Close() após a
+// verificação do erro de Query e um "if err := rows.Err()" após o loop.
+//
+// Variáveis que escapam da função (retornadas ou passadas adiante) são
+// ignoradas, pois a responsabilidade de fechá-las é de quem as recebe.
+//
+// Lições: exemplos/04-casos-reais/database/analise.md, seções 3 e 5.
+package rowsclose
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer detecta *sql.Rows não fechados ou sem verificação de Err
+var Analyzer = &analysis.Analyzer{
+	Name:     "rowsclose",
+	Doc:      "detecta *sql.Rows não fechados ou sem verificação de rows.Err()",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		if name, ok := analysisutil.SQLMethod(pass.TypesInfo, call); !ok || (name != "Query" && name != "QueryContext") {
+			return true
+		}
+		method := analysisutil.Render(pass.Fset, call.Fun)
+
+		var lhs, errLHS ast.Expr
+		switch parent := stack[len(stack)-2].(type) {
+		case *ast.ExprStmt:
+			pass.Reportf(call.Pos(), "resultado de %s descartado; o *sql.Rows nunca é fechado e a conexão vaza", method)
+			return true
+		case *ast.AssignStmt:
+			if len(parent.Rhs) == 1 && parent.Rhs[0] == call {
+				lhs, errLHS = parent.Lhs[0], parent.Lhs[len(parent.Lhs)-1]
+			}
+		case *ast.ValueSpec:
+			if len(parent.Values) == 1 && parent.Values[0] == call {
+				lhs, errLHS = parent.Names[0], parent.Names[len(parent.Names)-1]
+			}
+		}
+		id, ok := lhs.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		if id.Name == "_" {
+			pass.Reportf(call.Pos(), "resultado de %s descartado; o *sql.Rows nunca é fechado e a conexão vaza", method)
+			return true
+		}
+		rows, ok := pass.TypesInfo.ObjectOf(id).(*types.Var)
+		if !ok {
+			return true
+		}
+		decl, ok := analysisutil.Enclosing[*ast.FuncDecl](stack)
+		if !ok {
+			return true
+		}
+
+		u := uses(pass.TypesInfo, decl.Body, rows)
+		if u.escapes {
+			return true
+		}
+		if !u.closed {
+			var errVar types.Object
+			if errID, ok := errLHS.(*ast.Ident); ok && errID != id {
+				errVar = pass.TypesInfo.ObjectOf(errID)
+			}
+			pass.Report(analysis.Diagnostic{
+				Pos:            id.Pos(),
+				End:            id.End(),
+				Message:        fmt.Sprintf("%s nunca é fechado; adicione defer %s.Close() após verificar o erro", id.Name, id.Name),
+				SuggestedFixes: deferClose(pass, stack, rows, errVar),
+			})
+		}
+		if u.iterated && !u.errChecked {
+			pass.Report(analysis.Diagnostic{
+				Pos:            id.Pos(),
+				End:            id.End(),
+				Message:        fmt.Sprintf("%s.Err() não é verificado após o loop de %s.Next()", id.Name, id.Name),
+				SuggestedFixes: checkErr(pass, decl, rows),
+			})
+		}
+		return true
+	})
+	return nil, nil
+}
+
+type usage struct {
+	closed, iterated, errChecked, escapes bool
+}
+
+// uses resume como rows é usado em body
+func uses(info *types.Info, body *ast.BlockStmt, rows *types.Var) usage {
+	var u usage
+	methodRecv := make(map[*ast.Ident]bool)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		id, ok := ast.Unparen(sel.X).(*ast.Ident)
+		if !ok || info.Uses[id] != rows {
+			return true
+		}
+		methodRecv[id] = true
+		switch sel.Sel.Name {
+		case "Close":
+			u.closed = true
+		case "Next", "NextResultSet":
+			u.iterated = true
+		case "Err":
+			u.errChecked = true
+		}
+		return true
+	})
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if ok && info.Uses[id] == rows && !methodRecv[id] {
+			u.escapes = true
+		}
+		return true
+	})
+	return u
+}
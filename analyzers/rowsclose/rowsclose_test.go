@@ -0,0 +1,12 @@
+package rowsclose_test
+
+import (
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/fixtest"
+	"github.com/lucasrafaldini/fubango/analyzers/rowsclose"
+)
+
+func TestAnalyzer(t *testing.T) {
+	fixtest.Run(t, rowsclose.Analyzer, "banco", "fixes")
+}
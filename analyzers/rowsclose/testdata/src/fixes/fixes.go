@@ -0,0 +1,50 @@
+package fixes
+
+import (
+	"context"
+	"database/sql"
+)
+
+type user struct{ name string }
+
+func Names(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, "SELECT name FROM users") // want `rows nunca é fechado` `rows.Err\(\) não é verificado`
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func First(db *sql.DB) (user, int, error) {
+	var rows, err = db.Query("SELECT name FROM users") // want `rows.Err\(\) não é verificado`
+	if err != nil {
+		return user{}, 0, err
+	}
+	defer rows.Close()
+
+	var u user
+	n := 0
+	for rows.Next() {
+		n++
+		rows.Scan(&u.name)
+	}
+	return u, n, nil
+}
+
+func Count(db *sql.DB) int {
+	rows, _ := db.Query("SELECT 1") // want `rows nunca é fechado` `rows.Err\(\) não é verificado`
+	n := 0
+	for rows.Next() {
+		n++
+	}
+	return n
+}
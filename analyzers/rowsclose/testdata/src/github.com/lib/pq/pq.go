@@ -0,0 +1,3 @@
+// Package pq é um stub de github.com/lib/pq usado apenas para que os
+// fixtures compilem no modo GOPATH do analysistest.
+package pq
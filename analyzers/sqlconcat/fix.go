@@ -0,0 +1,288 @@
+package sqlconcat
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+)
+
+// placeholders sugere trocar a consulta dinâmica por uma constante com
+// placeholders $n e acrescentar os valores interpolados como argumentos de
+// call. Devolve nil quando a reescrita não é mecânica: nomes interpolados
+// (tabelas, colunas de ORDER BY), consultas montadas em vários passos (+=),
+// variáveis reaproveitadas ou valores colados a outros caracteres (ex:
+// LIKE '%...%').
+func placeholders(pass *analysis.Pass, body *ast.BlockStmt, call *ast.CallExpr, arg ast.Expr) []analysis.SuggestedFix {
+	if call.Ellipsis.IsValid() {
+		return nil
+	}
+	source := ast.Unparen(arg)
+	if id, ok := source.(*ast.Ident); ok {
+		if source = definition(pass.TypesInfo, body, id); source == nil {
+			return nil
+		}
+	}
+
+	argIndex := 0
+	for i, a := range call.Args {
+		if a == arg {
+			argIndex = i
+		}
+	}
+	q := &query{next: len(call.Args) - argIndex}
+	if !q.build(pass.TypesInfo, source) {
+		return nil
+	}
+	text, ok := q.text()
+	if !ok {
+		return nil
+	}
+
+	var values []string
+	for _, v := range q.values {
+		values = append(values, analysisutil.Render(pass.Fset, v))
+	}
+	return []analysis.SuggestedFix{{
+		Message: "Usar placeholders e passar os valores como argumentos",
+		TextEdits: []analysis.TextEdit{
+			{Pos: source.Pos(), End: source.End(), NewText: []byte(strconv.Quote(text))},
+			{Pos: call.Rparen, End: call.Rparen, NewText: []byte(", " + strings.Join(values, ", "))},
+		},
+	}}
+}
+
+// definition devolve a expressão atribuída a id quando ela é a única
+// atribuição da variável em body e id é o único uso dela
+func definition(info *types.Info, body *ast.BlockStmt, id *ast.Ident) ast.Expr {
+	obj, ok := info.Uses[id].(*types.Var)
+	if !ok || body == nil {
+		return nil
+	}
+	var (
+		def         ast.Expr
+		assignments int
+		uses        int
+	)
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if lid, ok := lhs.(*ast.Ident); ok && info.ObjectOf(lid) == obj {
+					assignments++
+					if n.Tok != token.DEFINE {
+						uses--
+					}
+					if n.Tok != token.ADD_ASSIGN && len(n.Lhs) == len(n.Rhs) {
+						def = n.Rhs[i]
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if info.Defs[name] == obj {
+					assignments++
+					if i < len(n.Values) {
+						def = n.Values[i]
+					}
+				}
+			}
+		case *ast.Ident:
+			if info.Uses[n] == obj {
+				uses++
+			}
+		}
+		return true
+	})
+	if assignments != 1 || uses != 1 {
+		return nil
+	}
+	return def
+}
+
+// query acumula o texto SQL em segmentos: literais consecutivos são
+// unidos e cada valor interpolado vira um placeholder
+type query struct {
+	segments []segment
+	values   []ast.Expr
+	next     int // número do próximo placeholder
+}
+
+type segment struct {
+	text  string
+	value bool
+}
+
+func (q *query) literal(s string) {
+	if n := len(q.segments); n > 0 && !q.segments[n-1].value {
+		q.segments[n-1].text += s
+		return
+	}
+	q.segments = append(q.segments, segment{text: s})
+}
+
+func (q *query) value(v ast.Expr) {
+	q.segments = append(q.segments, segment{value: true})
+	q.values = append(q.values, v)
+}
+
+// build decompõe uma concatenação ou chamada a fmt.Sprintf
+func (q *query) build(info *types.Info, expr ast.Expr) bool {
+	expr = ast.Unparen(expr)
+	if tv, ok := info.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+		q.literal(constant.StringVal(tv.Value))
+		return true
+	}
+	switch e := expr.(type) {
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return false
+		}
+		return q.build(info, e.X) && q.build(info, e.Y)
+	case *ast.CallExpr:
+		if analysisutil.IsFunc(info, e, "fmt", "Sprintf") && !e.Ellipsis.IsValid() {
+			return q.sprintf(info, e)
+		}
+	}
+	q.value(expr)
+	return true
+}
+
+// sprintf aceita apenas verbos simples (%s, %d, %v, %f), um por argumento
+func (q *query) sprintf(info *types.Info, call *ast.CallExpr) bool {
+	tv, ok := info.Types[call.Args[0]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return false
+	}
+	format, args := constant.StringVal(tv.Value), call.Args[1:]
+	var lit strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			lit.WriteByte(format[i])
+			continue
+		}
+		if i+1 >= len(format) {
+			return false
+		}
+		i++
+		switch format[i] {
+		case '%':
+			lit.WriteByte('%')
+		case 's', 'd', 'v', 'f':
+			if len(args) == 0 {
+				return false
+			}
+			q.literal(lit.String())
+			lit.Reset()
+			q.value(args[0])
+			args = args[1:]
+		default:
+			return false
+		}
+	}
+	q.literal(lit.String())
+	return len(args) == 0
+}
+
+// text monta a consulta final com os placeholders numerados
+func (q *query) text() (string, bool) {
+	segs := q.segments
+	var b strings.Builder
+	n := q.next
+	for i, seg := range segs {
+		if !seg.value {
+			b.WriteString(seg.text)
+			continue
+		}
+		prev, next := b.String(), ""
+		if i+1 < len(segs) && !segs[i+1].value {
+			next = segs[i+1].text
+		}
+		if strings.HasSuffix(prev, "'") && strings.HasPrefix(next, "'") {
+			prev, next = prev[:len(prev)-1], next[1:]
+			b.Reset()
+			b.WriteString(prev)
+			segs[i+1].text = next
+		}
+		if !valuePosition(prev) || !boundary(firstByte(next), " ),;") {
+			return "", false
+		}
+		b.WriteString("$" + strconv.Itoa(n))
+		n++
+	}
+	return b.String(), true
+}
+
+// Palavras-chave seguidas de nomes (tabelas, colunas) e não de valores.
+// Um placeholder nessas posições vira um literal: "ORDER BY $1" ordena por
+// uma constante e "FROM $1" nem é SQL válido.
+var nameClauses = map[string]bool{
+	"SELECT": true, "FROM": true, "JOIN": true, "INTO": true,
+	"UPDATE": true, "TABLE": true, "BY": true,
+}
+
+// Palavras-chave que encerram uma cláusula de nomes
+var valueClauses = map[string]bool{
+	"WHERE": true, "AND": true, "OR": true, "ON": true, "SET": true,
+	"HAVING": true, "IN": true, "LIKE": true, "VALUES": true,
+	"LIMIT": true, "OFFSET": true,
+}
+
+// valuePosition informa se um placeholder pode vir logo depois de prev:
+// depois de um operador de comparação, de VALUES, LIMIT, OFFSET ou LIKE, ou
+// de "(" e "," fora de uma cláusula de nomes (FROM, JOIN, INTO, UPDATE,
+// TABLE, ORDER BY, GROUP BY ou a lista do SELECT)
+func valuePosition(prev string) bool {
+	words := strings.FieldsFunc(strings.ToUpper(prev), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '_'
+	})
+	clause := ""
+	for _, w := range words {
+		if nameClauses[w] || valueClauses[w] {
+			clause = w
+		}
+	}
+	trimmed := strings.TrimRight(prev, " ")
+	switch c := lastByte(trimmed); {
+	case c == 0:
+		return false
+	case strings.IndexByte("=<>", c) >= 0:
+		return true
+	case c == '(' || c == ',':
+		return !nameClauses[clause]
+	case len(trimmed) == len(prev) || !unicode.IsLetter(rune(c)):
+		// valor colado a outro texto (ex: LIKE '%...')
+		return false
+	}
+	switch words[len(words)-1] {
+	case "VALUES", "LIMIT", "OFFSET", "LIKE":
+		return true
+	}
+	return false
+}
+
+// boundary informa se c pode ficar ao lado de um placeholder; 0 indica o
+// início ou o fim da consulta
+func boundary(c byte, allowed string) bool {
+	return c == 0 || strings.IndexByte(allowed, c) >= 0
+}
+
+func lastByte(s string) byte {
+	if s == "" {
+		return 0
+	}
+	return s[len(s)-1]
+}
+
+func firstByte(s string) byte {
+	if s == "" {
+		return 0
+	}
+	return s[0]
+}
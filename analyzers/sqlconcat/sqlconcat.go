@@ -0,0 +1,133 @@
+// Package sqlconcat define um Analyzer que detecta consultas SQL montadas
+// por concatenação de strings ou fmt.Sprintf e enviadas a database/sql.
+//
+// Valores interpolados no texto SQL permitem SQL injection e impedem que o
+// banco reaproveite o plano da consulta:
+//
+//	query := "SELECT * FROM users WHERE name = '" + userName + "'"
+//	db.Query(query)
+//
+//	query := fmt.Sprintf("SELECT * FROM users WHERE id = %d", id)
+//	db.Query(query)
+//
+// O correto é usar placeholders ($1, ?) e passar os valores como argumentos.
+// Concatenações de constantes são ignoradas. Quando a consulta é montada em
+// uma única expressão, o analisador sugere reescrevê-la com placeholders no
+// formato $n (PostgreSQL), removendo as aspas em volta dos valores. Só
+// valores viram placeholders: sem sugestão quando o trecho interpolado está
+// depois de FROM, JOIN, INTO, UPDATE, TABLE, ORDER BY ou GROUP BY.
+//
+// Lições: exemplos/04-casos-reais/database/analise.md, seções 2 e 6.
+package sqlconcat
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer detecta SQL dinâmico montado com concatenação ou Sprintf
+var Analyzer = &analysis.Analyzer{
+	Name:     "sqlconcat",
+	Doc:      "detecta SQL montado por concatenação ou fmt.Sprintf passado a database/sql",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		arg, ok := analysisutil.SQLQueryArg(pass.TypesInfo, call)
+		if !ok {
+			return true
+		}
+		body := enclosingBody(stack)
+		if how := dynamicSQL(pass.TypesInfo, body, arg); how != "" {
+			pass.Report(analysis.Diagnostic{
+				Pos:            arg.Pos(),
+				End:            arg.End(),
+				Message:        fmt.Sprintf("consulta SQL montada por %s; use placeholders ($1, ?) e passe os valores como argumentos", how),
+				SuggestedFixes: placeholders(pass, body, call, arg),
+			})
+		}
+		return true
+	})
+	return nil, nil
+}
+
+func enclosingBody(stack []ast.Node) *ast.BlockStmt {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.FuncLit:
+			return n.Body
+		case *ast.FuncDecl:
+			return n.Body
+		}
+	}
+	return nil
+}
+
+// dynamicSQL informa como expr foi montada ("concatenação" ou
+// "fmt.Sprintf") ou "" se ela for constante ou de origem desconhecida.
+// Identificadores locais são seguidos até as atribuições em body.
+func dynamicSQL(info *types.Info, body *ast.BlockStmt, expr ast.Expr) string {
+	expr = ast.Unparen(expr)
+	if tv, ok := info.Types[expr]; ok && tv.Value != nil {
+		return "" // constante
+	}
+	switch e := expr.(type) {
+	case *ast.BinaryExpr:
+		if e.Op == token.ADD {
+			return "concatenação"
+		}
+	case *ast.CallExpr:
+		if analysisutil.IsFunc(info, e, "fmt", "Sprintf") {
+			return "fmt.Sprintf"
+		}
+	case *ast.Ident:
+		obj, ok := info.Uses[e].(*types.Var)
+		if !ok || body == nil {
+			return ""
+		}
+		var how string
+		ast.Inspect(body, func(n ast.Node) bool {
+			if how != "" {
+				return false
+			}
+			switch s := n.(type) {
+			case *ast.AssignStmt:
+				for i, lhs := range s.Lhs {
+					id, ok := lhs.(*ast.Ident)
+					if !ok || info.ObjectOf(id) != obj {
+						continue
+					}
+					if s.Tok == token.ADD_ASSIGN {
+						how = "concatenação"
+					} else if len(s.Lhs) == len(s.Rhs) && ast.Unparen(s.Rhs[i]) != expr {
+						how = dynamicSQL(info, nil, s.Rhs[i])
+					}
+				}
+			case *ast.ValueSpec:
+				for i, name := range s.Names {
+					if info.Defs[name] == obj && i < len(s.Values) {
+						how = dynamicSQL(info, nil, s.Values[i])
+					}
+				}
+			}
+			return true
+		})
+		return how
+	}
+	return ""
+}
@@ -0,0 +1,12 @@
+package sqlconcat_test
+
+import (
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/fixtest"
+	"github.com/lucasrafaldini/fubango/analyzers/sqlconcat"
+)
+
+func TestAnalyzer(t *testing.T) {
+	fixtest.Run(t, sqlconcat.Analyzer, "banco", "dynamic")
+}
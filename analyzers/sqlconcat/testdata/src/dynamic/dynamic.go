@@ -0,0 +1,54 @@
+package dynamic
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+const table = "users"
+
+func Constant(db *sql.DB) {
+	db.Query("SELECT id FROM " + table)
+}
+
+func AppendFilter(ctx context.Context, db *sql.DB, name string) {
+	query := "SELECT id FROM users"
+	query += " WHERE name = '" + name + "'"
+	db.ExecContext(ctx, query) // want `consulta SQL montada por concatenação`
+}
+
+func VarSpec(tx *sql.Tx, id int) {
+	var query = fmt.Sprintf("DELETE FROM users WHERE id = %d", id)
+	tx.Exec(query) // want `consulta SQL montada por fmt.Sprintf`
+}
+
+func Placeholder(db *sql.DB, id int) {
+	db.QueryRow("SELECT name FROM users WHERE id = $1", id)
+}
+
+func Inline(ctx context.Context, db *sql.DB, name string, age int) {
+	db.QueryContext(ctx, "SELECT id FROM users WHERE name = '"+name+"' AND age > "+fmt.Sprint(age)) // want `consulta SQL montada por concatenação`
+}
+
+func Search(db *sql.DB, term string) {
+	db.Query("SELECT id FROM users WHERE name LIKE '%" + term + "%'") // want `consulta SQL montada por concatenação`
+}
+
+func Reused(db *sql.DB, id int) {
+	query := fmt.Sprintf("SELECT name FROM users WHERE id = %d", id)
+	db.Query(query) // want `consulta SQL montada por fmt.Sprintf`
+	fmt.Println(query)
+}
+
+func Sorted(db *sql.DB, table, col string) {
+	db.Query("SELECT * FROM " + table + " ORDER BY " + col) // want `consulta SQL montada por concatenação`
+}
+
+func Insert(db *sql.DB, table, name string) {
+	db.Exec("INSERT INTO " + table + " (name) VALUES ('" + name + "')") // want `consulta SQL montada por concatenação`
+}
+
+func Page(db *sql.DB, name string, limit int) {
+	db.Query(fmt.Sprintf("SELECT id FROM users WHERE name IN (%s) LIMIT %d", name, limit)) // want `consulta SQL montada por fmt.Sprintf`
+}
@@ -0,0 +1,77 @@
+// Package sqlloop define um Analyzer que detecta consultas executadas
+// dentro de loops, o formato clássico do problema N+1.
+//
+//	for _, id := range userIDs {
+//		db.Query("SELECT * FROM users WHERE id = ?", id)
+//	}
+//
+// Cada iteração paga uma ida e volta ao banco. O correto é buscar todos os
+// registros de uma vez (WHERE id = ANY($1), IN (...) ou JOIN).
+//
+// Lições: exemplos/04-casos-reais/database/analise.md, seção 6.
+package sqlloop
+
+import (
+	"go/ast"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer detecta consultas SQL dentro de loops
+var Analyzer = &analysis.Analyzer{
+	Name:     "sqlloop",
+	Doc:      "detecta consultas SQL executadas dentro de loops (N+1)",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var queryMethods = map[string]bool{
+	"Query":           true,
+	"QueryContext":    true,
+	"QueryRow":        true,
+	"QueryRowContext": true,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		if name, ok := analysisutil.SQLMethod(pass.TypesInfo, call); !ok || !queryMethods[name] {
+			return true
+		}
+		if !insideLoop(stack) {
+			return true
+		}
+		pass.Reportf(call.Pos(), "%s dentro de loop (N+1); busque todos os registros com uma única consulta",
+			analysisutil.Render(pass.Fset, call.Fun))
+		return true
+	})
+	return nil, nil
+}
+
+// insideLoop informa se o último nó de stack está no corpo de um for ou
+// range da mesma função
+func insideLoop(stack []ast.Node) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.FuncLit, *ast.FuncDecl:
+			return false
+		case *ast.ForStmt:
+			if stack[i+1] == n.Body {
+				return true
+			}
+		case *ast.RangeStmt:
+			if stack[i+1] == n.Body {
+				return true
+			}
+		}
+	}
+	return false
+}
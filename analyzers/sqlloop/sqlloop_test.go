@@ -0,0 +1,12 @@
+package sqlloop_test
+
+import (
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers/sqlloop"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), sqlloop.Analyzer, "banco")
+}
@@ -0,0 +1,21 @@
+package keys
+
+type field string
+
+type analyzer struct{ name string }
+
+type inspector struct{}
+
+// ResultOf imita pass.ResultOf: a chave é um ponteiro, e cada valor tem um
+// tipo conhecido por quem o guardou
+func ResultOf(results map[*analyzer]any, a *analyzer) *inspector {
+	return results[a].(*inspector)
+}
+
+func ByIndex(values map[int]interface{}) string {
+	return values[0].(string)
+}
+
+func NamedKey(input map[field]any) string {
+	return input["name"].(string) // want `type assertion sem verificação em input\["name"\]`
+}
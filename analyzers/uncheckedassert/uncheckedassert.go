@@ -0,0 +1,71 @@
+// Package uncheckedassert define um Analyzer que detecta type assertions
+// sem verificação sobre valores de map[string]interface{}.
+//
+// Entradas decodificadas de JSON em map[string]interface{} podem não ter o
+// campo esperado ou tê-lo com outro tipo (números viram float64). A forma de
+// um único valor entra em panic nesses casos:
+//
+//	name := input["name"].(string) // panic se ausente ou não string
+//	age := input["age"].(int)      // sempre panic: JSON produz float64
+//
+// Use a forma v, ok := x.(T) ou, melhor, decodifique para uma struct com
+// tipos definidos e valide os campos.
+//
+// Lição: exemplos/04-casos-reais/api-design/analise.md, seção 6.
+package uncheckedassert
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer detecta type assertions sem ok sobre valores de mapas genéricos
+// com chave string
+var Analyzer = &analysis.Analyzer{
+	Name:     "uncheckedassert",
+	Doc:      "detecta type assertions sem verificação sobre map[string]interface{}",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.WithStack([]ast.Node{(*ast.TypeAssertExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		assert := n.(*ast.TypeAssertExpr)
+		if assert.Type == nil || commaOk(assert, stack) {
+			return true
+		}
+		index, ok := ast.Unparen(assert.X).(*ast.IndexExpr)
+		if !ok {
+			return true
+		}
+		m, ok := pass.TypesInfo.TypeOf(index.X).Underlying().(*types.Map)
+		if !ok || !types.IsInterface(m.Elem()) || !types.Identical(m.Key().Underlying(), types.Typ[types.String]) {
+			return true
+		}
+		pass.Reportf(assert.Pos(), "type assertion sem verificação em %s pode causar panic; use v, ok := %s.(%s) e valide a entrada",
+			analysisutil.Render(pass.Fset, index), analysisutil.Render(pass.Fset, index), analysisutil.Render(pass.Fset, assert.Type))
+		return true
+	})
+	return nil, nil
+}
+
+// commaOk informa se a assertion é usada na forma v, ok := x.(T)
+func commaOk(assert *ast.TypeAssertExpr, stack []ast.Node) bool {
+	switch parent := stack[len(stack)-2].(type) {
+	case *ast.AssignStmt:
+		return len(parent.Lhs) == 2 && len(parent.Rhs) == 1
+	case *ast.ValueSpec:
+		return len(parent.Names) == 2 && len(parent.Values) == 1
+	}
+	return false
+}
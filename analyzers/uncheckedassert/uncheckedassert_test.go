@@ -0,0 +1,12 @@
+package uncheckedassert_test
+
+import (
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers/uncheckedassert"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), uncheckedassert.Analyzer, "apidesign", "keys")
+}
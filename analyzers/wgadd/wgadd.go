@@ -0,0 +1,59 @@
+// Package wgadd define um Analyzer que detecta sync.WaitGroup.Add chamado
+// dentro da própria goroutine que ele deveria contar.
+//
+// Quando Add roda dentro da goroutine, Wait pode ser executado antes dela
+// começar, encontrar o contador zerado e retornar cedo demais:
+//
+//	go func() {
+//		wg.Add(1) // tarde demais
+//		defer wg.Done()
+//	}()
+//	wg.Wait()
+//
+// Add deve ser chamado antes do comando go.
+//
+// Lições: exemplos/02-intermediario/concorrencia/analise.md, seção 7, e
+// exemplos/03-avancado/goroutines/analise.md, seção 8.
+package wgadd
+
+import (
+	"go/ast"
+
+	"github.com/lucasrafaldini/fubango/analyzers/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer detecta WaitGroup.Add dentro de goroutines
+var Analyzer = &analysis.Analyzer{
+	Name:     "wgadd",
+	Doc:      "detecta sync.WaitGroup.Add chamado dentro da goroutine iniciada",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.Preorder([]ast.Node{(*ast.GoStmt)(nil)}, func(n ast.Node) {
+		lit, ok := ast.Unparen(n.(*ast.GoStmt).Call.Fun).(*ast.FuncLit)
+		if !ok {
+			return
+		}
+		ast.Inspect(lit.Body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false // outra função, analisada separadamente
+			case *ast.CallExpr:
+				if analysisutil.IsMethod(pass.TypesInfo, n, "sync", "WaitGroup", "Add") {
+					sel := ast.Unparen(n.Fun).(*ast.SelectorExpr)
+					pass.Reportf(n.Pos(), "%s.Add chamado dentro da goroutine; chame Add antes do comando go",
+						analysisutil.Render(pass.Fset, sel.X))
+				}
+			}
+			return true
+		})
+	})
+	return nil, nil
+}
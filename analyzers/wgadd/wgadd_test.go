@@ -0,0 +1,12 @@
+package wgadd_test
+
+import (
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers/wgadd"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), wgadd.Analyzer, "concorrencia", "goroutines")
+}
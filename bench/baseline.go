@@ -0,0 +1,126 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// Baseline é um conjunto de resultados salvo para comparação futura
+type Baseline struct {
+	Date       time.Time         `json:"date"`
+	Config     map[string]string `json:"config,omitempty"`
+	Benchmarks []Summary         `json:"benchmarks"`
+}
+
+// ReadBaseline lê um baseline salvo por WriteFile
+func ReadBaseline(name string) (*Baseline, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &b, nil
+}
+
+// WriteFile salva o baseline em JSON
+func (b *Baseline) WriteFile(name string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, append(data, '\n'), 0o644)
+}
+
+// Latest junta baselines salvos em momentos diferentes. Para cada
+// benchmark fica o resultado do baseline mais recente; Date é a do mais
+// recente de todos.
+func Latest(baselines []*Baseline) *Baseline {
+	sorted := slices.Clone(baselines)
+	slices.SortStableFunc(sorted, func(a, b *Baseline) int { return a.Date.Compare(b.Date) })
+	out := &Baseline{}
+	index := make(map[string]int)
+	for _, b := range sorted {
+		out.Date, out.Config = b.Date, b.Config
+		for _, s := range b.Benchmarks {
+			if i, ok := index[s.Key()]; ok {
+				out.Benchmarks[i] = s
+				continue
+			}
+			index[s.Key()] = len(out.Benchmarks)
+			out.Benchmarks = append(out.Benchmarks, s)
+		}
+	}
+	return out
+}
+
+// Regression é uma métrica que piorou em relação ao baseline
+type Regression struct {
+	Package string  `json:"package"`
+	Name    string  `json:"name"`
+	Procs   int     `json:"procs"`
+	Metric  string  `json:"metric"` // ns/op, B/op ou allocs/op
+	Old     float64 `json:"old"`
+	New     float64 `json:"new"`
+}
+
+// Delta é a variação relativa (0.25 = 25% pior). É zero quando o valor
+// antigo era zero.
+func (r Regression) Delta() float64 {
+	if r.Old == 0 {
+		return 0
+	}
+	return r.New/r.Old - 1
+}
+
+// Compare devolve as métricas de current que pioraram mais que threshold
+// (0.10 = 10%) em relação a b. Quando os dois lados têm várias execuções, a
+// piora de ns/op também precisa ser significativa ao nível alpha (teste U de
+// Mann-Whitney). B/op e allocs/op que saem de zero sempre contam como
+// regressão. Benchmarks ausentes de um dos lados são ignorados.
+func (b *Baseline) Compare(current []Summary, threshold, alpha float64) []Regression {
+	old := make(map[string]Summary, len(b.Benchmarks))
+	for _, s := range b.Benchmarks {
+		old[s.Key()] = s
+	}
+	var regressions []Regression
+	for _, s := range current {
+		o, ok := old[s.Key()]
+		if !ok {
+			continue
+		}
+		significant := true
+		if len(o.Samples) > 1 && len(s.Samples) > 1 {
+			_, p := MannWhitney(o.Samples, s.Samples)
+			significant = p < alpha
+		}
+		metrics := []struct {
+			name     string
+			old, new float64
+			ok       bool
+		}{
+			{"ns/op", o.NsPerOp, s.NsPerOp, significant},
+			{"B/op", o.BytesPerOp, s.BytesPerOp, o.Mem && s.Mem},
+			{"allocs/op", o.AllocsPerOp, s.AllocsPerOp, o.Mem && s.Mem},
+		}
+		for _, m := range metrics {
+			if !m.ok || m.new <= m.old*(1+threshold) {
+				continue
+			}
+			regressions = append(regressions, Regression{
+				Package: s.Package,
+				Name:    s.Name,
+				Procs:   s.Procs,
+				Metric:  m.name,
+				Old:     m.old,
+				New:     m.new,
+			})
+		}
+	}
+	return regressions
+}
@@ -0,0 +1,169 @@
+// Package bench interpreta a saída de "go test -bench" e compara as
+// variantes ruins e boas dos benchmarks do FubanGo.
+//
+// Os benchmarks de cada tópico seguem convenções de nome como
+// BenchmarkBadCounter/BenchmarkGoodCounter ou
+// BenchmarkFanOut_Uncontrolled/BenchmarkFanOut_Controlled; Pairs usa essas
+// convenções para montar os pares ruim-vs-bom. Como benchmarks com
+// goroutines e timers são ruidosos, cada par é comparado com o teste U de
+// Mann-Whitney sobre as várias execuções (-count), e não por uma única razão.
+package bench
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Result é uma linha de resultado da saída de "go test -bench"
+type Result struct {
+	Package     string  `json:"package"`
+	Name        string  `json:"name"`
+	Procs       int     `json:"procs"`
+	Iterations  int     `json:"iterations"`
+	NsPerOp     float64 `json:"nsPerOp"`
+	BytesPerOp  float64 `json:"bytesPerOp"`
+	AllocsPerOp float64 `json:"allocsPerOp"`
+	Mem         bool    `json:"mem"` // B/op e allocs/op presentes (-benchmem)
+}
+
+// Output é a saída completa de uma execução: a configuração informada pelo
+// go test (goos, goarch, cpu) e os resultados na ordem em que apareceram
+type Output struct {
+	Config  map[string]string
+	Results []Result
+}
+
+// Parse lê a saída de "go test -bench". Linhas que não são resultados
+// (logs, PASS, panics) são ignoradas.
+func Parse(r io.Reader) (*Output, error) {
+	out := &Output{Config: make(map[string]string)}
+	var pkg string
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if key, value, ok := configLine(line); ok {
+			if key == "pkg" {
+				pkg = value
+			} else {
+				out.Config[key] = value
+			}
+			continue
+		}
+		if res, ok := parseResult(line); ok {
+			res.Package = pkg
+			out.Results = append(out.Results, res)
+		}
+	}
+	return out, sc.Err()
+}
+
+// configLine reconhece linhas "chave: valor" como "goos: linux"
+func configLine(line string) (key, value string, ok bool) {
+	key, value, ok = strings.Cut(line, ": ")
+	if !ok || key == "" || strings.ContainsAny(key, " \t") || strings.ToLower(key) != key {
+		return "", "", false
+	}
+	return key, strings.TrimSpace(value), true
+}
+
+// parseResult interpreta uma linha como
+//
+//	BenchmarkBadCounter-8   1000000   1050 ns/op   16 B/op   1 allocs/op
+func parseResult(line string) (Result, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+		return Result{}, false
+	}
+	n, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return Result{}, false
+	}
+	res := Result{Iterations: n, Procs: 1}
+	res.Name, res.Procs = splitProcs(fields[0])
+
+	found := false
+	for i := 2; i+1 < len(fields); i += 2 {
+		v, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return Result{}, false
+		}
+		switch fields[i+1] {
+		case "ns/op":
+			res.NsPerOp, found = v, true
+		case "B/op":
+			res.BytesPerOp, res.Mem = v, true
+		case "allocs/op":
+			res.AllocsPerOp, res.Mem = v, true
+		}
+	}
+	return res, found
+}
+
+// splitProcs separa o sufixo -GOMAXPROCS do nome do benchmark
+func splitProcs(name string) (string, int) {
+	i := strings.LastIndexByte(name, '-')
+	if i < 0 {
+		return name, 1
+	}
+	procs, err := strconv.Atoi(name[i+1:])
+	if err != nil || procs < 1 {
+		return name, 1
+	}
+	return name[:i], procs
+}
+
+// Summary é a média das execuções (-count) de um benchmark. Samples guarda
+// o ns/op de cada execução, usado nos testes de significância.
+type Summary struct {
+	Package     string    `json:"package"`
+	Name        string    `json:"name"`
+	Procs       int       `json:"procs"`
+	Runs        int       `json:"runs"`
+	NsPerOp     float64   `json:"nsPerOp"`
+	StdDev      float64   `json:"stdDev"`
+	CI95        float64   `json:"ci95"` // meia largura do intervalo de 95% de NsPerOp
+	BytesPerOp  float64   `json:"bytesPerOp"`
+	AllocsPerOp float64   `json:"allocsPerOp"`
+	Mem         bool      `json:"mem"`
+	Samples     []float64 `json:"samples,omitempty"`
+}
+
+// Key identifica o benchmark entre execuções
+func (s Summary) Key() string {
+	return fmt.Sprintf("%s.%s-%d", s.Package, s.Name, s.Procs)
+}
+
+// Summarize agrupa os resultados por pacote, nome e GOMAXPROCS, na ordem
+// da primeira ocorrência, com média, desvio padrão e intervalo de confiança
+func Summarize(results []Result) []Summary {
+	var out []Summary
+	index := make(map[string]int)
+	for _, r := range results {
+		s := Summary{Package: r.Package, Name: r.Name, Procs: r.Procs}
+		i, ok := index[s.Key()]
+		if !ok {
+			i = len(out)
+			index[s.Key()] = i
+			out = append(out, s)
+		}
+		sum := &out[i]
+		sum.Runs++
+		sum.Samples = append(sum.Samples, r.NsPerOp)
+		sum.BytesPerOp += r.BytesPerOp
+		sum.AllocsPerOp += r.AllocsPerOp
+		sum.Mem = sum.Mem || r.Mem
+	}
+	for i := range out {
+		n := float64(out[i].Runs)
+		out[i].NsPerOp = Mean(out[i].Samples)
+		out[i].StdDev = StdDev(out[i].Samples)
+		out[i].CI95 = CI95(out[i].Samples)
+		out[i].BytesPerOp /= n
+		out[i].AllocsPerOp /= n
+	}
+	return out
+}
@@ -0,0 +1,151 @@
+package bench
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func parseFile(t *testing.T, name string) *Output {
+	t.Helper()
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	out, err := Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestParse(t *testing.T) {
+	out := parseFile(t, "testdata/goroutines.txt")
+	if out.Config["goarch"] != "amd64" || out.Config["cpu"] == "" {
+		t.Errorf("Config = %v", out.Config)
+	}
+	if len(out.Results) != 8 {
+		t.Fatalf("len(Results) = %d, esperado 8", len(out.Results))
+	}
+	r := out.Results[0]
+	want := Result{
+		Package:     "github.com/lucasrafaldini/fubango/exemplos/03-avancado/goroutines",
+		Name:        "BenchmarkBadCounter",
+		Procs:       8,
+		Iterations:  100000,
+		NsPerOp:     10500,
+		BytesPerOp:  1600,
+		AllocsPerOp: 100,
+		Mem:         true,
+	}
+	if r != want {
+		t.Errorf("Results[0] = %+v, esperado %+v", r, want)
+	}
+	if out.Results[6].Mem {
+		t.Errorf("Channel_Buffered não tem -benchmem: %+v", out.Results[6])
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	s := Summarize(parseFile(t, "testdata/goroutines.txt").Results)
+	if len(s) != 7 {
+		t.Fatalf("len = %d, esperado 7", len(s))
+	}
+	if s[0].Runs != 2 || s[0].NsPerOp != 11000 {
+		t.Errorf("BadCounter = %+v, esperada média de 2 execuções", s[0])
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		stem string
+		v    Variant
+	}{
+		{"BenchmarkBadCounter", "Counter", Bad},
+		{"BenchmarkGoodHandler_Separated", "Handler", Good},
+		{"BenchmarkFanOut_Uncontrolled", "FanOut", Bad},
+		{"BenchmarkFanOut_Controlled", "FanOut", Good},
+		{"BenchmarkSend_Unsafe", "Send", Bad},
+		{"BenchmarkBatch_WithPool", "Batch", Good},
+		{"BenchmarkRange_WithoutClose", "Range", Bad},
+		{"BenchmarkSync_GoodWaitGroup", "Sync", Good},
+		{"BenchmarkConcurrentAccessUnsafe", "ConcurrentAccess", Bad},
+		{"BenchmarkBadgeRender", "BadgeRender", Unknown},
+		{"BenchmarkChannel_Buffered", "Channel_Buffered", Unknown},
+	}
+	for _, tt := range tests {
+		stem, v := Classify(tt.name)
+		if stem != tt.stem || v != tt.v {
+			t.Errorf("Classify(%q) = %q, %v; esperado %q, %v", tt.name, stem, v, tt.stem, tt.v)
+		}
+	}
+}
+
+func TestPairs(t *testing.T) {
+	pairs, unpaired := Pairs(Summarize(parseFile(t, "testdata/goroutines.txt").Results), nil)
+	var names []string
+	for _, p := range pairs {
+		names = append(names, p.Name)
+	}
+	if len(pairs) != 3 || names[0] != "Counter" || names[1] != "FanOut" || names[2] != "SleepSync/ChannelSync" {
+		t.Fatalf("pares = %v", names)
+	}
+	if got := pairs[0].Speedup(); got != 11000.0/3500 {
+		t.Errorf("Speedup = %v", got)
+	}
+	if len(unpaired) != 1 || unpaired[0].Name != "BenchmarkChannel_Buffered" {
+		t.Errorf("sem par = %+v", unpaired)
+	}
+}
+
+func TestBaselineCompare(t *testing.T) {
+	old := []Summary{
+		{Package: "p", Name: "BenchmarkA", Procs: 8, NsPerOp: 100, BytesPerOp: 0, Mem: true},
+		{Package: "p", Name: "BenchmarkB", Procs: 8, NsPerOp: 100, Mem: true},
+	}
+	name := filepath.Join(t.TempDir(), "baseline.json")
+	if err := (&Baseline{Benchmarks: old}).WriteFile(name); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ReadBaseline(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current := []Summary{
+		{Package: "p", Name: "BenchmarkA", Procs: 8, NsPerOp: 105, BytesPerOp: 16, Mem: true},
+		{Package: "p", Name: "BenchmarkB", Procs: 8, NsPerOp: 150, Mem: true},
+		{Package: "p", Name: "BenchmarkC", Procs: 8, NsPerOp: 1000, Mem: true},
+	}
+	regs := b.Compare(current, 0.10, 0.05)
+	if len(regs) != 2 {
+		t.Fatalf("regressões = %+v, esperadas 2", regs)
+	}
+	if regs[0].Name != "BenchmarkA" || regs[0].Metric != "B/op" {
+		t.Errorf("regs[0] = %+v", regs[0])
+	}
+	if regs[1].Name != "BenchmarkB" || regs[1].Metric != "ns/op" || regs[1].Delta() != 0.5 {
+		t.Errorf("regs[1] = %+v", regs[1])
+	}
+}
+
+func TestLatest(t *testing.T) {
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	newer := &Baseline{Date: day.AddDate(0, 0, 1), Benchmarks: []Summary{
+		{Package: "p", Name: "BenchmarkA", Procs: 8, NsPerOp: 50},
+	}}
+	older := &Baseline{Date: day, Benchmarks: []Summary{
+		{Package: "p", Name: "BenchmarkA", Procs: 8, NsPerOp: 100},
+		{Package: "p", Name: "BenchmarkB", Procs: 8, NsPerOp: 200},
+	}}
+	b := Latest([]*Baseline{newer, older})
+	if !b.Date.Equal(newer.Date) || len(b.Benchmarks) != 2 {
+		t.Fatalf("Latest = %+v", b)
+	}
+	if b.Benchmarks[0].NsPerOp != 50 || b.Benchmarks[1].NsPerOp != 200 {
+		t.Errorf("Benchmarks = %+v", b.Benchmarks)
+	}
+}
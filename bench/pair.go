@@ -0,0 +1,221 @@
+package bench
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Variant indica se um benchmark mede a versão ruim ou a boa
+type Variant int
+
+const (
+	Unknown Variant = iota
+	Bad
+	Good
+)
+
+func (v Variant) String() string {
+	switch v {
+	case Bad:
+		return "ruim"
+	case Good:
+		return "bom"
+	}
+	return "?"
+}
+
+// Marcadores reconhecidos no início de um sufixo (_Unsafe, _WithPool) ou
+// do nome (BadCounter). Os ruins são testados primeiro, para que Without
+// não seja lido como With.
+var (
+	badMarkers = []string{
+		"Bad", "Unsafe", "Uncontrolled", "Unlimited", "Unbounded", "Infinite",
+		"Without", "No", "Blocking", "TooSmall", "Leak", "Mixed", "Slow",
+	}
+	goodMarkers = []string{
+		"Good", "Safe", "Controlled", "Limited", "Bounded", "With",
+		"Proper", "WellSized", "WorkerPool", "Separated", "Fast",
+	}
+)
+
+// Classify devolve o nome comum de um benchmark (sem o prefixo Benchmark e
+// sem o marcador) e sua variante. Exemplos:
+//
+//	BenchmarkBadCounter             -> Counter, Bad
+//	BenchmarkBadQuery_NoPool        -> Query, Bad
+//	BenchmarkFanOut_Controlled      -> FanOut, Good
+//	BenchmarkSync_BadWaitGroup      -> Sync, Bad
+//	BenchmarkConcurrentAccessUnsafe -> ConcurrentAccess, Bad
+func Classify(name string) (string, Variant) {
+	name = strings.TrimPrefix(name, "Benchmark")
+	if v := marker(name, []string{"Bad"}, []string{"Good"}); v != Unknown {
+		stem := strings.TrimPrefix(strings.TrimPrefix(name, "Bad"), "Good")
+		if i := strings.IndexAny(stem, "_/"); i > 0 {
+			stem = stem[:i]
+		}
+		return stem, v
+	}
+	if i := strings.LastIndexAny(name, "_/"); i > 0 {
+		if v := marker(name[i+1:], badMarkers, goodMarkers); v != Unknown {
+			return name[:i], v
+		}
+		return name, Unknown
+	}
+	for _, m := range []struct {
+		suffix string
+		v      Variant
+	}{{"Unsafe", Bad}, {"Safe", Good}} {
+		if stem, ok := strings.CutSuffix(name, m.suffix); ok && stem != "" {
+			return stem, m.v
+		}
+	}
+	return name, Unknown
+}
+
+// marker classifica s pelo marcador no início
+func marker(s string, bad, good []string) Variant {
+	for _, m := range bad {
+		if hasWord(s, m) {
+			return Bad
+		}
+	}
+	for _, m := range good {
+		if hasWord(s, m) {
+			return Good
+		}
+	}
+	return Unknown
+}
+
+// hasWord informa se s começa com a palavra w em CamelCase
+func hasWord(s, w string) bool {
+	if !strings.HasPrefix(s, w) {
+		return false
+	}
+	rest := s[len(w):]
+	return rest == "" || unicode.IsUpper(rune(rest[0])) || unicode.IsDigit(rune(rest[0])) || rest[0] == '_'
+}
+
+// Pair é uma comparação entre a versão ruim e a boa de um benchmark
+type Pair struct {
+	Package string  `json:"package"`
+	Name    string  `json:"name"`
+	Bad     Summary `json:"bad"`
+	Good    Summary `json:"good"`
+}
+
+// Speedup é quantas vezes a versão boa é mais rápida que a ruim
+func (p Pair) Speedup() float64 {
+	if p.Good.NsPerOp == 0 {
+		return 0
+	}
+	return p.Bad.NsPerOp / p.Good.NsPerOp
+}
+
+// Comparison é o resultado estatístico de um par
+type Comparison struct {
+	Speedup     float64 `json:"speedup"`
+	P           float64 `json:"p"` // p-valor do teste U de Mann-Whitney
+	Significant bool    `json:"significant"`
+}
+
+// Compare testa se a diferença de ns/op entre as versões é significativa
+// ao nível alpha. Com uma execução de cada lado ela nunca é.
+func (p Pair) Compare(alpha float64) Comparison {
+	_, pv := MannWhitney(p.Bad.Samples, p.Good.Samples)
+	return Comparison{Speedup: p.Speedup(), P: pv, Significant: pv < alpha}
+}
+
+// Pairs monta os pares ruim-vs-bom, sempre no mesmo pacote e GOMAXPROCS.
+// explicit força pares que as convenções não reconhecem, do nome da versão
+// ruim para o da boa (com ou sem o prefixo Benchmark), como
+// Communication_Mutex=Communication_Atomic. Depois são pareados benchmarks
+// com o mesmo nome comum (Classify) e, por fim, uma variante ruim seguida
+// imediatamente por uma boa (a ordem de declaração em benchmark_test.go),
+// como BenchmarkBadSleepSync e BenchmarkGoodChannelSync. Os demais
+// benchmarks são devolvidos em unpaired.
+func Pairs(summaries []Summary, explicit map[string]string) (pairs []Pair, unpaired []Summary) {
+	type entry struct {
+		stem    string
+		variant Variant
+		paired  bool
+	}
+	entries := make([]entry, len(summaries))
+	groups := make(map[string][]int)
+	byKey := make(map[string]int)
+	for i, s := range summaries {
+		byKey[s.Key()] = i
+		stem, v := Classify(s.Name)
+		entries[i] = entry{stem: stem, variant: v}
+		if v != Unknown {
+			key := fmt.Sprintf("%s.%s-%d", s.Package, stem, s.Procs)
+			groups[key] = append(groups[key], i)
+		}
+	}
+
+	byBad := make(map[int]Pair)
+	for i, s := range summaries {
+		good, ok := explicit[strings.TrimPrefix(s.Name, "Benchmark")]
+		if !ok {
+			continue
+		}
+		g := Summary{Package: s.Package, Name: "Benchmark" + strings.TrimPrefix(good, "Benchmark"), Procs: s.Procs}
+		j, ok := byKey[g.Key()]
+		if !ok || entries[j].paired {
+			continue
+		}
+		entries[i].paired, entries[j].paired = true, true
+		name := strings.TrimPrefix(s.Name, "Benchmark") + "/" + strings.TrimPrefix(summaries[j].Name, "Benchmark")
+		byBad[i] = Pair{Package: s.Package, Name: name, Bad: s, Good: summaries[j]}
+	}
+	for _, idx := range groups {
+		idx = slices.DeleteFunc(idx, func(i int) bool { return entries[i].paired })
+		var bad, good []int
+		for _, i := range idx {
+			if entries[i].variant == Bad {
+				bad = append(bad, i)
+			} else {
+				good = append(good, i)
+			}
+		}
+		if len(bad) != 1 || len(good) != 1 {
+			continue
+		}
+		b, g := bad[0], good[0]
+		entries[b].paired, entries[g].paired = true, true
+		byBad[b] = Pair{Package: summaries[b].Package, Name: entries[b].stem, Bad: summaries[b], Good: summaries[g]}
+	}
+	// Com go test -cpu cada benchmark roda em todos os GOMAXPROCS antes do
+	// próximo, então a vizinhança é vista dentro de cada pacote e GOMAXPROCS.
+	var order []string
+	sequences := make(map[string][]int)
+	for i, s := range summaries {
+		key := fmt.Sprintf("%s-%d", s.Package, s.Procs)
+		if _, ok := sequences[key]; !ok {
+			order = append(order, key)
+		}
+		sequences[key] = append(sequences[key], i)
+	}
+	for _, key := range order {
+		seq := sequences[key]
+		for k := 0; k+1 < len(seq); k++ {
+			i, j := seq[k], seq[k+1]
+			if entries[i].paired || entries[j].paired || entries[i].variant != Bad || entries[j].variant != Good {
+				continue
+			}
+			entries[i].paired, entries[j].paired = true, true
+			byBad[i] = Pair{Package: summaries[i].Package, Name: entries[i].stem + "/" + entries[j].stem, Bad: summaries[i], Good: summaries[j]}
+		}
+	}
+
+	for i, s := range summaries {
+		if p, ok := byBad[i]; ok {
+			pairs = append(pairs, p)
+		} else if !entries[i].paired {
+			unpaired = append(unpaired, s)
+		}
+	}
+	return pairs, unpaired
+}
@@ -0,0 +1,224 @@
+package bench
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/pprof/profile"
+)
+
+// ProfileKind descreve um dos perfis capturados de cada lado de um par
+type ProfileKind struct {
+	Name       string // cpu, alloc, mutex ou block
+	Flag       string // flag do go test que gera o perfil
+	SampleType string // tipo de amostra comparado
+	Title      string
+	// Caller ignora os frames de runtime e sync no topo da pilha, para que
+	// alocações e contenção sejam atribuídas a quem chamou make, Lock etc.
+	Caller bool
+	GC     bool // inclui o coletor de lixo (ver Focus)
+}
+
+// ProfileKinds são os perfis comparados por DiffProfiles
+var ProfileKinds = []ProfileKind{
+	{Name: "cpu", Flag: "-cpuprofile", SampleType: "cpu", Title: "CPU", GC: true},
+	{Name: "alloc", Flag: "-memprofile", SampleType: "alloc_space", Title: "Alocações (bytes)", Caller: true},
+	{Name: "mutex", Flag: "-mutexprofile", SampleType: "delay", Title: "Contenção de mutex", Caller: true},
+	{Name: "block", Flag: "-blockprofile", SampleType: "delay", Title: "Bloqueios (channels, select, WaitGroup)", Caller: true},
+}
+
+// FuncShare é a fração do total de um perfil atribuída a uma função em
+// cada versão do par
+type FuncShare struct {
+	Name string  `json:"name"`
+	Bad  float64 `json:"bad"`
+	Good float64 `json:"good"`
+}
+
+// ProfileDiff compara o mesmo perfil das duas versões de um par. Como cada
+// lado roda um número diferente de iterações, as funções são comparadas
+// pela fração do total e não pelo valor absoluto.
+type ProfileDiff struct {
+	Kind      string      `json:"kind"`
+	Title     string      `json:"title"`
+	Unit      string      `json:"unit"`
+	BadTotal  int64       `json:"badTotal"`
+	GoodTotal int64       `json:"goodTotal"`
+	Funcs     []FuncShare `json:"funcs"`
+}
+
+// ReadProfile lê um perfil gerado pelo go test
+func ReadProfile(name string) (*profile.Profile, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return profile.Parse(f)
+}
+
+// Focus mantém apenas as amostras com alguma função do pacote pkg na pilha
+// (o benchmark, suas closures e as goroutines que ele cria), descartando o
+// custo do próprio go test e da captura dos perfis. No perfil de CPU o
+// trabalho do coletor de lixo também é mantido.
+func (k ProfileKind) Focus(p *profile.Profile, pkg string) {
+	expr := `^` + regexp.QuoteMeta(pkg) + `\.`
+	if k.GC {
+		expr += `|^runtime\.gcBgMarkWorker$`
+	}
+	p.FilterSamplesByName(regexp.MustCompile(expr), nil, nil, nil)
+}
+
+// DiffProfiles compara as funções no topo da pilha (valor flat) dos perfis
+// bad e good e devolve as n com maior diferença de fração. Perfis nil
+// contam como vazios.
+func DiffProfiles(kind ProfileKind, bad, good *profile.Profile, n int) (ProfileDiff, error) {
+	d := ProfileDiff{Kind: kind.Name, Title: kind.Title}
+	badFlat, badTotal, unit, err := flat(bad, kind)
+	if err != nil {
+		return d, err
+	}
+	goodFlat, goodTotal, goodUnit, err := flat(good, kind)
+	if err != nil {
+		return d, err
+	}
+	d.BadTotal, d.GoodTotal, d.Unit = badTotal, goodTotal, cmp.Or(unit, goodUnit)
+
+	names := make(map[string]bool)
+	for name := range badFlat {
+		names[name] = true
+	}
+	for name := range goodFlat {
+		names[name] = true
+	}
+	for name := range names {
+		d.Funcs = append(d.Funcs, FuncShare{
+			Name: name,
+			Bad:  share(badFlat[name], badTotal),
+			Good: share(goodFlat[name], goodTotal),
+		})
+	}
+	sort.Slice(d.Funcs, func(i, j int) bool {
+		a, b := d.Funcs[i], d.Funcs[j]
+		da, db := math.Abs(a.Bad-a.Good), math.Abs(b.Bad-b.Good)
+		if da != db {
+			return da > db
+		}
+		return a.Name < b.Name
+	})
+	if len(d.Funcs) > n {
+		d.Funcs = d.Funcs[:n]
+	}
+	return d, nil
+}
+
+func share(v, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(v) / float64(total)
+}
+
+// flat soma o valor de kind.SampleType por função no topo da pilha
+func flat(p *profile.Profile, kind ProfileKind) (map[string]int64, int64, string, error) {
+	out := make(map[string]int64)
+	if p == nil {
+		return out, 0, "", nil
+	}
+	idx, err := p.SampleIndexByName(kind.SampleType)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	var total int64
+	for _, s := range p.Sample {
+		v := s.Value[idx]
+		total += v
+		out[ShortName(leaf(s, kind.Caller))] += v
+	}
+	return out, total, p.SampleType[idx].Unit, nil
+}
+
+// leaf devolve a função no topo da pilha de s (a mais interna, incluindo
+// funções inlined). Com caller, os frames de runtime e sync são pulados.
+func leaf(s *profile.Sample, caller bool) string {
+	first := ""
+	for _, loc := range s.Location {
+		for _, line := range loc.Line {
+			if line.Function == nil {
+				continue
+			}
+			name := line.Function.Name
+			if first == "" {
+				first = name
+			}
+			if !caller || !(strings.HasPrefix(name, "runtime.") || strings.HasPrefix(name, "sync.")) {
+				return name
+			}
+		}
+	}
+	if first == "" {
+		return "?"
+	}
+	return first
+}
+
+// ShortName remove o caminho do pacote do nome de uma função, como faz o
+// pprof (ex: github.com/x/goroutines.(*Pool).Submit vira
+// goroutines.(*Pool).Submit)
+func ShortName(name string) string {
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// WriteMarkdown escreve as comparações como tabelas Markdown, prontas para
+// serem citadas em um analise.md
+func WriteMarkdown(w io.Writer, p Pair, diffs []ProfileDiff) error {
+	fmt.Fprintf(w, "### %s vs %s\n", p.Bad.Name, p.Good.Name)
+	for _, d := range diffs {
+		if d.BadTotal == 0 && d.GoodTotal == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n**%s** — total ruim %s, bom %s\n\n", d.Title, FormatValue(d.BadTotal, d.Unit), FormatValue(d.GoodTotal, d.Unit))
+		fmt.Fprintln(w, "| Função | ruim | bom |")
+		fmt.Fprintln(w, "|---|---:|---:|")
+		for _, f := range d.Funcs {
+			fmt.Fprintf(w, "| `%s` | %.1f%% | %.1f%% |\n", f.Name, 100*f.Bad, 100*f.Good)
+		}
+	}
+	return nil
+}
+
+// FormatValue formata o total de um perfil na sua unidade
+func FormatValue(v int64, unit string) string {
+	switch unit {
+	case "nanoseconds":
+		switch {
+		case v >= 1e9:
+			return fmt.Sprintf("%.2fs", float64(v)/1e9)
+		case v >= 1e6:
+			return fmt.Sprintf("%.1fms", float64(v)/1e6)
+		case v >= 1e3:
+			return fmt.Sprintf("%.1fµs", float64(v)/1e3)
+		}
+		return fmt.Sprintf("%dns", v)
+	case "bytes":
+		switch {
+		case v >= 1<<30:
+			return fmt.Sprintf("%.1fGB", float64(v)/(1<<30))
+		case v >= 1<<20:
+			return fmt.Sprintf("%.1fMB", float64(v)/(1<<20))
+		case v >= 1<<10:
+			return fmt.Sprintf("%.1fkB", float64(v)/(1<<10))
+		}
+		return fmt.Sprintf("%dB", v)
+	}
+	return fmt.Sprintf("%d %s", v, unit)
+}
@@ -0,0 +1,101 @@
+package bench
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/pprof/profile"
+)
+
+// testProfile monta um perfil de alocações com uma amostra por pilha (da
+// função mais interna para a mais externa)
+func testProfile(samples map[string]int64) *profile.Profile {
+	p := &profile.Profile{SampleType: []*profile.ValueType{{Type: "alloc_space", Unit: "bytes"}}}
+	funcs := make(map[string]*profile.Function)
+	for stack, v := range samples {
+		s := &profile.Sample{Value: []int64{v}}
+		for _, name := range strings.Split(stack, ";") {
+			f, ok := funcs[name]
+			if !ok {
+				f = &profile.Function{ID: uint64(len(funcs) + 1), Name: name}
+				funcs[name] = f
+				p.Function = append(p.Function, f)
+			}
+			loc := &profile.Location{ID: uint64(len(p.Location) + 1), Line: []profile.Line{{Function: f}}}
+			p.Location = append(p.Location, loc)
+			s.Location = append(s.Location, loc)
+		}
+		p.Sample = append(p.Sample, s)
+	}
+	return p
+}
+
+func TestDiffProfiles(t *testing.T) {
+	bad := testProfile(map[string]int64{
+		"runtime.mallocgc;example.com/x/goroutines.Unsafe": 300,
+		"example.com/x/goroutines.Setup":                   100,
+	})
+	good := testProfile(map[string]int64{
+		"runtime.mallocgc;sync.(*Pool).Get;example.com/x/goroutines.Safe": 100,
+		"example.com/x/goroutines.Setup":                                  100,
+	})
+	kind := ProfileKinds[1]
+	d, err := DiffProfiles(kind, bad, good, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.BadTotal != 400 || d.GoodTotal != 200 || d.Unit != "bytes" {
+		t.Errorf("totais = %d, %d %s", d.BadTotal, d.GoodTotal, d.Unit)
+	}
+	want := []FuncShare{
+		{Name: "goroutines.Unsafe", Bad: 0.75},
+		{Name: "goroutines.Safe", Good: 0.5},
+	}
+	if len(d.Funcs) != len(want) {
+		t.Fatalf("funções = %+v", d.Funcs)
+	}
+	for i := range want {
+		if d.Funcs[i] != want[i] {
+			t.Errorf("funções[%d] = %+v, esperado %+v", i, d.Funcs[i], want[i])
+		}
+	}
+
+	kind.Caller = false
+	d, err = DiffProfiles(kind, bad, nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Funcs) != 1 || d.Funcs[0].Name != "runtime.mallocgc" || d.GoodTotal != 0 {
+		t.Errorf("sem Caller = %+v", d)
+	}
+}
+
+func TestFocus(t *testing.T) {
+	p := testProfile(map[string]int64{
+		"runtime.mallocgc;example.com/x/goroutines.Unsafe":     1,
+		"compress/flate.NewWriter;runtime/pprof.profileWriter": 1,
+		"runtime.gcBgMarkWorker":                               1,
+	})
+	ProfileKinds[1].Focus(p, "example.com/x/goroutines")
+	if len(p.Sample) != 1 {
+		t.Errorf("amostras = %d, esperado 1", len(p.Sample))
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		v    int64
+		unit string
+		want string
+	}{
+		{500, "nanoseconds", "500ns"},
+		{2500000, "nanoseconds", "2.5ms"},
+		{1536, "bytes", "1.5kB"},
+		{7, "count", "7 count"},
+	}
+	for _, tt := range tests {
+		if got := FormatValue(tt.v, tt.unit); got != tt.want {
+			t.Errorf("FormatValue(%d, %q) = %q, esperado %q", tt.v, tt.unit, got, tt.want)
+		}
+	}
+}
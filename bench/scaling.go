@@ -0,0 +1,189 @@
+package bench
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Curve é a vazão (ops/s) de um par em cada GOMAXPROCS, obtida com
+// go test -cpu=1,2,4,...
+type Curve struct {
+	Package string    `json:"package"`
+	Name    string    `json:"name"`
+	Procs   []int     `json:"procs"`
+	Bad     []float64 `json:"bad"`  // ops/s da versão ruim
+	Good    []float64 `json:"good"` // ops/s da versão boa
+}
+
+// Throughput converte ns/op em operações por segundo
+func Throughput(nsPerOp float64) float64 {
+	if nsPerOp == 0 {
+		return 0
+	}
+	return 1e9 / nsPerOp
+}
+
+// Scaling agrupa os pares de mesmo nome em curvas ordenadas por
+// GOMAXPROCS. Pares medidos em um único GOMAXPROCS não formam curva.
+func Scaling(pairs []Pair) []Curve {
+	var curves []Curve
+	index := make(map[string]int)
+	for _, p := range pairs {
+		key := p.Package + "." + p.Name
+		i, ok := index[key]
+		if !ok {
+			i = len(curves)
+			index[key] = i
+			curves = append(curves, Curve{Package: p.Package, Name: p.Name})
+		}
+		c := &curves[i]
+		c.Procs = append(c.Procs, p.Bad.Procs)
+		c.Bad = append(c.Bad, Throughput(p.Bad.NsPerOp))
+		c.Good = append(c.Good, Throughput(p.Good.NsPerOp))
+	}
+
+	out := curves[:0]
+	for _, c := range curves {
+		if len(c.Procs) < 2 {
+			continue
+		}
+		sort.Sort(byProcs{&c})
+		out = append(out, c)
+	}
+	return out
+}
+
+type byProcs struct{ *Curve }
+
+func (c byProcs) Len() int           { return len(c.Procs) }
+func (c byProcs) Less(i, j int) bool { return c.Procs[i] < c.Procs[j] }
+func (c byProcs) Swap(i, j int) {
+	c.Procs[i], c.Procs[j] = c.Procs[j], c.Procs[i]
+	c.Bad[i], c.Bad[j] = c.Bad[j], c.Bad[i]
+	c.Good[i], c.Good[j] = c.Good[j], c.Good[i]
+}
+
+// Crossovers devolve os GOMAXPROCS em que a versão mais rápida muda em
+// relação ao ponto anterior da curva
+func (c Curve) Crossovers() []int {
+	var out []int
+	for i := 1; i < len(c.Procs); i++ {
+		before := c.Good[i-1] > c.Bad[i-1]
+		after := c.Good[i] > c.Bad[i]
+		if before != after {
+			out = append(out, c.Procs[i])
+		}
+	}
+	return out
+}
+
+// WriteASCII desenha a curva como barras horizontais, uma linha por
+// versão em cada GOMAXPROCS. width é a largura da maior barra.
+func (c Curve) WriteASCII(w io.Writer, width int) error {
+	peak := 0.0
+	for i := range c.Procs {
+		peak = max(peak, c.Bad[i], c.Good[i])
+	}
+	bar := func(v float64, fill string) string {
+		if peak == 0 {
+			return ""
+		}
+		n := int(v / peak * float64(width))
+		return strings.Repeat(fill, max(n, 1)) + strings.Repeat(" ", width-max(n, 1))
+	}
+	fmt.Fprintf(w, "%s (ops/s)\n", c.Name)
+	for i, procs := range c.Procs {
+		fmt.Fprintf(w, "  cpu=%-3d ruim |%s %s\n", procs, bar(c.Bad[i], "#"), FormatRate(c.Bad[i]))
+		fmt.Fprintf(w, "          bom  |%s %s\n", bar(c.Good[i], "="), FormatRate(c.Good[i]))
+	}
+	for _, procs := range c.Crossovers() {
+		fmt.Fprintf(w, "  a versão mais rápida muda em GOMAXPROCS=%d\n", procs)
+	}
+	return nil
+}
+
+// FormatRate abrevia uma vazão (ex: 1.5M)
+func FormatRate(v float64) string {
+	switch {
+	case v >= 1e9:
+		return fmt.Sprintf("%.1fG", v/1e9)
+	case v >= 1e6:
+		return fmt.Sprintf("%.1fM", v/1e6)
+	case v >= 1e3:
+		return fmt.Sprintf("%.1fk", v/1e3)
+	default:
+		return fmt.Sprintf("%.1f", v)
+	}
+}
+
+// Dimensões de cada gráfico do SVG
+const (
+	svgWidth  = 640
+	svgHeight = 240
+	svgMargin = 50
+)
+
+// WriteSVG desenha as curvas em um SVG, um gráfico de linhas por par com a
+// vazão no eixo y e os GOMAXPROCS, igualmente espaçados, no eixo x
+func WriteSVG(w io.Writer, curves []Curve) error {
+	var b strings.Builder
+	total := len(curves) * svgHeight
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="12">`+"\n", svgWidth, total)
+	for i, c := range curves {
+		fmt.Fprintf(&b, `<g transform="translate(0,%d)">`+"\n", i*svgHeight)
+		writeSVGChart(&b, c)
+		b.WriteString("</g>\n")
+	}
+	b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeSVGChart(b *strings.Builder, c Curve) {
+	left, top := svgMargin+20, 30
+	right, bottom := svgWidth-svgMargin, svgHeight-svgMargin
+	peak := 0.0
+	for i := range c.Procs {
+		peak = max(peak, c.Bad[i], c.Good[i])
+	}
+	if peak == 0 {
+		peak = 1
+	}
+	x := func(i int) float64 {
+		if len(c.Procs) == 1 {
+			return float64(left)
+		}
+		return float64(left) + float64(i)*float64(right-left)/float64(len(c.Procs)-1)
+	}
+	y := func(v float64) float64 {
+		return float64(bottom) - v/peak*float64(bottom-top)
+	}
+
+	fmt.Fprintf(b, `<text x="%d" y="18" font-weight="bold">%s</text>`+"\n", left, html.EscapeString(c.Name))
+	fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#999"/>`+"\n", left, bottom, right, bottom)
+	fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#999"/>`+"\n", left, top, left, bottom)
+	fmt.Fprintf(b, `<text x="%d" y="%d" text-anchor="end">%s</text>`+"\n", left-4, top+4, FormatRate(peak))
+	fmt.Fprintf(b, `<text x="%d" y="%d" text-anchor="end">0</text>`+"\n", left-4, bottom+4)
+	for i, procs := range c.Procs {
+		fmt.Fprintf(b, `<text x="%.1f" y="%d" text-anchor="middle">%d</text>`+"\n", x(i), bottom+16, procs)
+	}
+	fmt.Fprintf(b, `<text x="%d" y="%d" text-anchor="middle">GOMAXPROCS</text>`+"\n", (left+right)/2, bottom+32)
+
+	for _, s := range []struct {
+		label, color string
+		values       []float64
+	}{{"ruim", "#d62728", c.Bad}, {"bom", "#2ca02c", c.Good}} {
+		points := make([]string, len(s.values))
+		for i, v := range s.values {
+			points[i] = fmt.Sprintf("%.1f,%.1f", x(i), y(v))
+			fmt.Fprintf(b, `<circle cx="%.1f" cy="%.1f" r="3" fill="%s"><title>%s cpu=%d: %s ops/s</title></circle>`+"\n",
+				x(i), y(v), s.color, s.label, c.Procs[i], FormatRate(v))
+		}
+		fmt.Fprintf(b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`+"\n", strings.Join(points, " "), s.color)
+	}
+	fmt.Fprintf(b, `<text x="%d" y="18" fill="#d62728">● ruim</text>`+"\n", right-90)
+	fmt.Fprintf(b, `<text x="%d" y="18" fill="#2ca02c">● bom</text>`+"\n", right-40)
+}
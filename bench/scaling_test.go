@@ -0,0 +1,80 @@
+package bench
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func sweepCurves(t *testing.T) []Curve {
+	t.Helper()
+	summaries := Summarize(parseFile(t, "testdata/sweep.txt").Results)
+	pairs, unpaired := Pairs(summaries, map[string]string{"Communication_Mutex": "Communication_Atomic"})
+	if len(pairs) != 6 || len(unpaired) != 0 {
+		t.Fatalf("%d pares e %d sem par, esperados 6 e 0", len(pairs), len(unpaired))
+	}
+	return Scaling(pairs)
+}
+
+func TestScaling(t *testing.T) {
+	curves := sweepCurves(t)
+	if len(curves) != 2 {
+		t.Fatalf("len(curves) = %d, esperado 2", len(curves))
+	}
+	c := curves[0]
+	if c.Name != "Communication_Mutex/Communication_Atomic" {
+		t.Errorf("Name = %q", c.Name)
+	}
+	if len(c.Procs) != 3 || c.Procs[2] != 4 || c.Bad[0] != 2e7 || c.Good[2] != 4e7 {
+		t.Errorf("curva = %+v", c)
+	}
+	// o mutex vence com 1 CPU e perde a partir de 2
+	if got := c.Crossovers(); len(got) != 1 || got[0] != 2 {
+		t.Errorf("Crossovers = %v, esperado [2]", got)
+	}
+	if got := curves[1].Crossovers(); len(got) != 0 {
+		t.Errorf("SleepSync/ChannelSync não deveria cruzar: %v", got)
+	}
+}
+
+func TestWriteASCII(t *testing.T) {
+	var b strings.Builder
+	if err := sweepCurves(t)[0].WriteASCII(&b, 10); err != nil {
+		t.Fatal(err)
+	}
+	want := `Communication_Mutex/Communication_Atomic (ops/s)
+  cpu=1   ruim |#####      20.0M
+          bom  |==         10.0M
+  cpu=2   ruim |####       16.7M
+          bom  |=====      20.0M
+  cpu=4   ruim |###        12.5M
+          bom  |========== 40.0M
+  a versão mais rápida muda em GOMAXPROCS=2
+`
+	if b.String() != want {
+		t.Errorf("WriteASCII:\n%s\nesperado:\n%s", b.String(), want)
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	var b strings.Builder
+	if err := WriteSVG(&b, sweepCurves(t)); err != nil {
+		t.Fatal(err)
+	}
+	var svg struct {
+		Groups []struct {
+			Polylines []struct {
+				Points string `xml:"points,attr"`
+			} `xml:"polyline"`
+		} `xml:"g"`
+	}
+	if err := xml.Unmarshal([]byte(b.String()), &svg); err != nil {
+		t.Fatal(err)
+	}
+	if len(svg.Groups) != 2 || len(svg.Groups[0].Polylines) != 2 {
+		t.Fatalf("SVG com %d gráficos, esperados 2", len(svg.Groups))
+	}
+	if n := len(strings.Fields(svg.Groups[0].Polylines[0].Points)); n != 3 {
+		t.Errorf("polyline com %d pontos, esperados 3", n)
+	}
+}
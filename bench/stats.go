@@ -0,0 +1,158 @@
+package bench
+
+import (
+	"math"
+	"sort"
+)
+
+// Mean devolve a média de xs
+func Mean(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+// StdDev devolve o desvio padrão amostral de xs (zero com menos de duas
+// amostras)
+func StdDev(xs []float64) float64 {
+	if len(xs) < 2 {
+		return 0
+	}
+	m := Mean(xs)
+	sum := 0.0
+	for _, x := range xs {
+		sum += (x - m) * (x - m)
+	}
+	return math.Sqrt(sum / float64(len(xs)-1))
+}
+
+// CI95 devolve a meia largura do intervalo de confiança de 95% da média,
+// pela distribuição t de Student (zero com menos de duas amostras)
+func CI95(xs []float64) float64 {
+	n := len(xs)
+	if n < 2 {
+		return 0
+	}
+	return tQuantile975(n-1) * StdDev(xs) / math.Sqrt(float64(n))
+}
+
+// t975 são os quantis 0,975 da distribuição t para 1 a 30 graus de liberdade
+var t975 = []float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+func tQuantile975(df int) float64 {
+	if df <= len(t975) {
+		return t975[df-1]
+	}
+	// Aproximação com erro menor que 0,005 acima de 30 graus de liberdade
+	return 1.96 + 2.5/float64(df)
+}
+
+// maxExact é o maior tamanho de amostra para o qual MannWhitney calcula a
+// distribuição exata de U
+const maxExact = 50
+
+// MannWhitney aplica o teste U de Mann-Whitney (bilateral) às amostras a e
+// b e devolve a estatística U de a e o p-valor. Sem empates e com amostras
+// pequenas a distribuição exata é usada; nos demais casos, a aproximação
+// normal com correção para empates e continuidade.
+func MannWhitney(a, b []float64) (u, p float64) {
+	m, n := len(a), len(b)
+	if m == 0 || n == 0 {
+		return 0, 1
+	}
+
+	type sample struct {
+		x     float64
+		fromA bool
+	}
+	all := make([]sample, 0, m+n)
+	for _, x := range a {
+		all = append(all, sample{x, true})
+	}
+	for _, x := range b {
+		all = append(all, sample{x, false})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].x < all[j].x })
+
+	// postos médios para os empates
+	rankSum, ties := 0.0, 0.0
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].x == all[i].x {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if all[k].fromA {
+				rankSum += rank
+			}
+		}
+		if t := float64(j - i); t > 1 {
+			ties += t*t*t - t
+		}
+		i = j
+	}
+	u = rankSum - float64(m*(m+1))/2
+
+	if ties == 0 && m <= maxExact && n <= maxExact {
+		return u, exactP(u, m, n)
+	}
+	mu := float64(m*n) / 2
+	N := float64(m + n)
+	sigma := math.Sqrt(float64(m*n) / 12 * ((N + 1) - ties/(N*(N-1))))
+	if sigma == 0 {
+		return u, 1
+	}
+	z := (math.Abs(u-mu) - 0.5) / sigma
+	if z < 0 {
+		z = 0
+	}
+	return u, math.Min(1, math.Erfc(z/math.Sqrt2))
+}
+
+// exactP devolve o p-valor bilateral exato de U para amostras de tamanho m
+// e n sem empates. counts[k] é o número de ordenações com U = k.
+func exactP(u float64, m, n int) float64 {
+	// f(m, n)[k] = f(m-1, n)[k-n] + f(m, n-1)[k], com f(0, n) = f(m, 0) = [1]
+	prev := make([][]float64, n+1) // prev[j] = f(i-1, j)
+	for j := range prev {
+		prev[j] = []float64{1}
+	}
+	for i := 1; i <= m; i++ {
+		cur := make([][]float64, n+1)
+		cur[0] = []float64{1}
+		for j := 1; j <= n; j++ {
+			c := make([]float64, i*j+1)
+			for k, v := range prev[j] {
+				c[k+j] += v
+			}
+			for k, v := range cur[j-1] {
+				c[k] += v
+			}
+			cur[j] = c
+		}
+		prev = cur
+	}
+	counts := prev[n]
+
+	total, below, above := 0.0, 0.0, 0.0
+	for k, c := range counts {
+		total += c
+		if float64(k) <= u {
+			below += c
+		}
+		if float64(k) >= u {
+			above += c
+		}
+	}
+	return math.Min(1, 2*math.Min(below, above)/total)
+}
@@ -0,0 +1,48 @@
+package bench
+
+import (
+	"math"
+	"testing"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-3
+}
+
+func TestStats(t *testing.T) {
+	xs := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	if m := Mean(xs); m != 5 {
+		t.Errorf("Mean = %v, esperado 5", m)
+	}
+	if s := StdDev(xs); !near(s, 2.138) {
+		t.Errorf("StdDev = %v, esperado 2.138", s)
+	}
+	// t(7) = 2.365
+	if ci := CI95(xs); !near(ci, 2.365*2.138/math.Sqrt(8)) {
+		t.Errorf("CI95 = %v", ci)
+	}
+	if CI95([]float64{1}) != 0 {
+		t.Error("CI95 com uma amostra deveria ser 0")
+	}
+}
+
+func TestMannWhitney(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float64
+		u, p float64
+	}{
+		{"separadas", []float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, 0, 2.0 / 252},
+		{"intercaladas", []float64{1, 3, 5}, []float64{2, 4, 6}, 3, 0.7},
+		{"uma amostra", []float64{1}, []float64{2}, 0, 1},
+		{"iguais", []float64{5, 5, 5}, []float64{5, 5, 5}, 4.5, 1},
+		// empates: aproximação normal, z = (12.5 - 0.5) / 4.758
+		{"empates", []float64{1, 2, 2, 3, 4}, []float64{5, 6, 6, 7, 8}, 0, 0.0114},
+	}
+	for _, tt := range tests {
+		u, p := MannWhitney(tt.a, tt.b)
+		if u != tt.u || !near(p, tt.p) {
+			t.Errorf("%s: MannWhitney = %v, %v; esperado %v, %v", tt.name, u, p, tt.u, tt.p)
+		}
+	}
+}
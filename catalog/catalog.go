@@ -0,0 +1,255 @@
+// Package catalog indexa os diretórios de exemplos do FubanGo.
+//
+// Cada tópico em exemplos/<nível>/<categoria> segue o mesmo layout: ruim.go,
+// bom.go, analise.md, benchmark_test.go e manifesto.json. O catálogo lê as
+// seções numeradas de analise.md e, pelo manifesto, liga cada anti-padrão à
+// declaração em ruim.go, à contraparte em bom.go e aos benchmarks. Tópicos
+// sem manifesto têm as declarações deduzidas do código de cada seção.
+// Traduções do analise.md ficam em analise.<língua>.md (veja LoadLang).
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Nomes dos arquivos que compõem um tópico
+const (
+	ExamplesDir   = "exemplos"
+	RuimFile      = "ruim.go"
+	BomFile       = "bom.go"
+	AnalysisFile  = "analise.md"
+	BenchmarkFile = "benchmark_test.go"
+	ManifestFile  = "manifesto.json"
+)
+
+// Catalog agrupa todos os tópicos encontrados sob Root/exemplos
+type Catalog struct {
+	Root   string   `json:"-"`
+	Topics []*Topic `json:"topics"`
+}
+
+// Topic representa um diretório de exemplo (ex: 03-avancado/goroutines)
+type Topic struct {
+	Level        string         `json:"level"`
+	Category     string         `json:"category"`
+	Dir          string         `json:"dir"`
+	Title        string         `json:"title"`
+	Lang         string         `json:"lang"`            // língua de Title (veja LoadLang)
+	Langs        []string       `json:"langs,omitempty"` // traduções do analise.md
+	AntiPatterns []*AntiPattern `json:"antiPatterns"`
+	Benchmarks   []string       `json:"benchmarks,omitempty"`
+	Manifest     *Manifest      `json:"-"` // nil se o tópico não tem manifesto.json
+}
+
+// ID retorna o identificador do tópico no formato nível/categoria
+func (t *Topic) ID() string {
+	return t.Level + "/" + t.Category
+}
+
+// AntiPattern representa uma seção "## N. Título" de analise.md
+type AntiPattern struct {
+	ID           string         `json:"id,omitempty"`
+	Number       int            `json:"number"`
+	Title        string         `json:"title"`
+	Lang         string         `json:"lang"` // língua de Title; a linha é do analise.md dessa língua
+	AnalysisLine int            `json:"analysisLine"`
+	Severity     string         `json:"severity,omitempty"`
+	Tags         []string       `json:"tags,omitempty"`
+	Ruim         Symbol         `json:"ruim"`
+	RuimLine     int            `json:"ruimLine,omitempty"` // linha do trecho da seção dentro de Ruim
+	Bom          Symbol         `json:"bom"`
+	Benchmark    *BenchmarkPair `json:"benchmark,omitempty"`
+	Analyzers    []string       `json:"analyzers,omitempty"`
+}
+
+// Symbol aponta para uma declaração em um arquivo Go do tópico.
+// Name fica vazio quando nenhuma declaração foi associada.
+type Symbol struct {
+	Name string `json:"name,omitempty"`
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+}
+
+// Found informa se o símbolo foi associado a alguma declaração
+func (s Symbol) Found() bool {
+	return s.Name != ""
+}
+
+func (s Symbol) String() string {
+	if !s.Found() {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%s:%d)", s.Name, s.File, s.Line)
+}
+
+// Load percorre root/exemplos/*/* e monta o catálogo completo
+func Load(root string) (*Catalog, error) {
+	dirs, err := filepath.Glob(filepath.Join(root, ExamplesDir, "*", "*"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(dirs)
+
+	c := &Catalog{Root: root}
+	for _, dir := range dirs {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			continue
+		}
+		topic, err := LoadTopic(root, dir)
+		if err != nil {
+			return nil, fmt.Errorf("carregando %s: %w", dir, err)
+		}
+		c.Topics = append(c.Topics, topic)
+	}
+	return c, nil
+}
+
+// LoadTopic lê um único diretório de exemplo
+func LoadTopic(root, dir string) (*Topic, error) {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return nil, err
+	}
+	topic := &Topic{
+		Level:    filepath.Base(filepath.Dir(dir)),
+		Category: filepath.Base(dir),
+		Dir:      filepath.ToSlash(rel),
+	}
+
+	analysis, err := ParseAnalysisFile(filepath.Join(dir, AnalysisFile))
+	if err != nil {
+		return nil, err
+	}
+	topic.Title, topic.Lang = analysis.Title, DefaultLang
+	if topic.Langs, err = translations(dir); err != nil {
+		return nil, err
+	}
+
+	ruim, err := ParseSourceFile(filepath.Join(dir, RuimFile))
+	if err != nil {
+		return nil, err
+	}
+	bom, err := ParseSourceFile(filepath.Join(dir, BomFile))
+	if err != nil {
+		return nil, err
+	}
+	topic.Manifest, err = readTopicManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, section := range analysis.Sections {
+		ap := &AntiPattern{
+			Number:       section.Number,
+			Title:        section.Title,
+			Lang:         DefaultLang,
+			AnalysisLine: section.Line,
+		}
+		if topic.Manifest != nil {
+			if e := topic.Manifest.Entry(section.Number); e != nil {
+				ap.ID, ap.Severity, ap.Tags = e.ID, e.Severity, e.Tags
+				ap.Benchmark, ap.Analyzers = e.Benchmark, e.Analyzers
+				ap.Ruim = lookupSymbol(ruim, RuimFile, e.Ruim)
+				ap.Bom = lookupSymbol(bom, BomFile, e.Bom)
+			}
+		} else if decl := ruim.MatchSection(section); decl != nil {
+			ap.Ruim = Symbol{Name: decl.Name, File: RuimFile, Line: decl.Line}
+			if counterpart := bom.Counterpart(decl); counterpart != nil {
+				ap.Bom = Symbol{Name: counterpart.Name, File: BomFile, Line: counterpart.Line}
+			}
+		}
+		ap.RuimLine = ap.Ruim.Line
+		topic.AntiPatterns = append(topic.AntiPatterns, ap)
+	}
+	locateShared(topic, analysis, ruim)
+
+	bench, err := ParseSourceFile(filepath.Join(dir, BenchmarkFile))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		// benchmark_test.go é opcional
+	case err != nil:
+		return nil, err
+	default:
+		for _, d := range bench.Decls {
+			if d.Kind == KindFunc && strings.HasPrefix(d.Name, "Benchmark") {
+				topic.Benchmarks = append(topic.Benchmarks, d.Name)
+			}
+		}
+	}
+	return topic, nil
+}
+
+// locateShared aponta RuimLine para o trecho de cada seção quando vários
+// anti-padrões do tópico compartilham a mesma declaração de ruim.go (ex: uma
+// função com todos os exemplos). Os demais ficam no início da declaração.
+func locateShared(topic *Topic, analysis *Analysis, ruim *Source) {
+	uses := make(map[string]int)
+	for _, ap := range topic.AntiPatterns {
+		if ap.Ruim.Found() {
+			uses[ap.Ruim.Name]++
+		}
+	}
+	for i, ap := range topic.AntiPatterns {
+		if uses[ap.Ruim.Name] > 1 {
+			ap.RuimLine = ruim.Locate(ruim.Lookup(ap.Ruim.Name), analysis.Sections[i].Code)
+		}
+	}
+}
+
+// lookupSymbol associa name à sua declaração em src. Nomes vazios ou não
+// declarados ficam sem símbolo; Validate aponta os que não existem.
+func lookupSymbol(src *Source, file, name string) Symbol {
+	if name == "" {
+		return Symbol{}
+	}
+	d := src.Lookup(name)
+	if d == nil {
+		return Symbol{}
+	}
+	return Symbol{Name: d.Name, File: file, Line: d.Line}
+}
+
+// Filter restringe o catálogo a um nível e/ou categoria.
+// Valores vazios não filtram; a comparação aceita prefixos (ex: "03").
+func (c *Catalog) Filter(level, category string) *Catalog {
+	out := &Catalog{Root: c.Root}
+	for _, t := range c.Topics {
+		if level != "" && !strings.HasPrefix(t.Level, level) {
+			continue
+		}
+		if category != "" && t.Category != category {
+			continue
+		}
+		out.Topics = append(out.Topics, t)
+	}
+	return out
+}
+
+// Topic busca um tópico pelo ID (nível/categoria) ou apenas pela categoria
+func (c *Catalog) Topic(id string) *Topic {
+	for _, t := range c.Topics {
+		if t.ID() == id || t.Dir == id || t.Category == id {
+			return t
+		}
+	}
+	return nil
+}
+
+// Count retorna o número total de anti-padrões no catálogo
+func (c *Catalog) Count() int {
+	n := 0
+	for _, t := range c.Topics {
+		n += len(t.AntiPatterns)
+	}
+	return n
+}
@@ -0,0 +1,97 @@
+package catalog
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAnalysis(t *testing.T) {
+	src := "# Título\n\nIntro.\n\n## 1. Primeiro\n```go\nfunc Bad() {}\n```\ntexto\n\n## 2. Segundo\nsem código\n\n## Conclusão\nfim\n"
+	a, err := ParseAnalysis(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Title != "Título" {
+		t.Errorf("Title = %q", a.Title)
+	}
+	if len(a.Sections) != 2 {
+		t.Fatalf("len(Sections) = %d, esperado 2", len(a.Sections))
+	}
+	first := a.Sections[0]
+	if first.Number != 1 || first.Title != "Primeiro" || first.Line != 5 {
+		t.Errorf("primeira seção = %+v", first)
+	}
+	if first.Code != "func Bad() {}\n" {
+		t.Errorf("Code = %q", first.Code)
+	}
+	if a.Sections[1].Code != "" {
+		t.Errorf("segunda seção não deveria ter código: %q", a.Sections[1].Code)
+	}
+}
+
+func TestParseSourceValues(t *testing.T) {
+	src := "package p\n\n// Global\nvar result string\n\nconst (\n\tA, B = 1, 2\n\t_ = 3\n)\n\ntype T int\n"
+	s, err := ParseSource("p.go", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range s.Decls {
+		got = append(got, d.Kind+" "+d.Name)
+	}
+	want := []string{"var result", "const A", "const B", "type T"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decls = %v, esperado %v", got, want)
+	}
+	if d := s.Lookup("result"); d == nil || d.Line != 4 || d.Doc != "Global\n" {
+		t.Errorf("Lookup(result) = %+v", d)
+	}
+	if d := s.Lookup("B"); d == nil || d.Line != 7 {
+		t.Errorf("Lookup(B) = %+v", d)
+	}
+}
+
+func TestNameWords(t *testing.T) {
+	tests := map[string][]string{
+		"BadConcurrentCounter":   {"concurrent", "counter"},
+		"SafeChannel.Close":      {"channel", "close"},
+		"SQLInjectionVulnerable": {"sql", "injection", "vulnerable"},
+	}
+	for name, want := range tests {
+		if got := nameWords(name); !reflect.DeepEqual(got, want) {
+			t.Errorf("nameWords(%q) = %v, esperado %v", name, got, want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	c, err := Load("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Topics) != 12 {
+		t.Errorf("len(Topics) = %d, esperado 12", len(c.Topics))
+	}
+
+	topic := c.Topic("03-avancado/goroutines")
+	if topic == nil {
+		t.Fatal("tópico goroutines não encontrado")
+	}
+	ap := topic.AntiPatterns[1]
+	if ap.Ruim.Name != "ClosureVariableSharing" {
+		t.Errorf("Ruim = %v, esperado ClosureVariableSharing", ap.Ruim)
+	}
+	if ap.Severity != "error" || len(ap.Analyzers) != 1 || ap.Benchmark == nil || ap.Benchmark.Bom != "BenchmarkClosureSharing_Good" {
+		t.Errorf("manifesto de goroutines #2 = %+v", ap)
+	}
+
+	db := c.Topic("database")
+	if got := db.AntiPatterns[1].Ruim.Name; got != "SQLInjectionVulnerable" {
+		t.Errorf("database #2 = %q, esperado SQLInjectionVulnerable", got)
+	}
+
+	if n := len(c.Filter("03", "").Topics); n != 3 {
+		t.Errorf("Filter(03) = %d tópicos, esperado 3", n)
+	}
+}
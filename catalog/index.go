@@ -0,0 +1,603 @@
+package catalog
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// IndexHeading é o título da seção do README gerada a partir do catálogo
+const IndexHeading = "## Índice de Anti-Padrões Documentados"
+
+// Problem é uma entrada desatualizada encontrada por CheckLinks ou Validate
+type Problem struct {
+	File    string // arquivo verificado, relativo à raiz
+	Line    int
+	Target  string // link ou referência desatualizada
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Line == 0 {
+		return fmt.Sprintf("%s: %s: %s", p.File, p.Target, p.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s: %s", p.File, p.Line, p.Target, p.Message)
+}
+
+var (
+	mdLink     = regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]+)\)`)
+	ruimRef    = regexp.MustCompile("`" + regexp.QuoteMeta(RuimFile) + `:(\d+)` + "`")
+	lineAnchor = regexp.MustCompile(`^L(\d+)$`)
+	listNumber = regexp.MustCompile(`^(\d+)\.\s`)
+	mdHeading  = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*\s*$`)
+)
+
+// CheckLinks verifica os links de um arquivo Markdown (caminho relativo a
+// c.Root). Cada link relativo precisa apontar para um arquivo existente;
+// âncoras #L<n> precisam cair em um título (em arquivos .md) cujo texto
+// seja o do link, e as demais âncoras precisam corresponder a um título do
+// arquivo. Nas linhas que ligam um anti-padrão a analise.md, a referência
+// `ruim.go:<n>` precisa apontar para a declaração associada à seção (ou,
+// quando a declaração é compartilhada por várias seções, para o trecho).
+func (c *Catalog) CheckLinks(name string) ([]Problem, error) {
+	src, err := os.ReadFile(filepath.Join(c.Root, name))
+	if err != nil {
+		return nil, err
+	}
+	base := path.Dir(filepath.ToSlash(name))
+	files := make(map[string]*linkTarget)
+	target := func(p string) *linkTarget {
+		if t, ok := files[p]; ok {
+			return t
+		}
+		t := loadLinkTarget(filepath.Join(c.Root, filepath.FromSlash(p)))
+		files[p] = t
+		return t
+	}
+
+	var problems []Problem
+	inCode := false
+	for i, line := range strings.Split(string(src), "\n") {
+		lineNo := i + 1
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+		report := func(ref, format string, args ...any) {
+			problems = append(problems, Problem{File: name, Line: lineNo, Target: ref, Message: fmt.Sprintf(format, args...)})
+		}
+
+		for _, m := range mdLink.FindAllStringSubmatch(line, -1) {
+			text, link := m[1], m[2]
+			if strings.Contains(link, "://") || strings.HasPrefix(link, "mailto:") {
+				continue
+			}
+			file, anchor, _ := strings.Cut(link, "#")
+			p := path.Clean(path.Join(base, file))
+			if file == "" {
+				p = filepath.ToSlash(name)
+			}
+			t := target(p)
+			if !t.exists {
+				report(link, "arquivo %s não existe", p)
+				continue
+			}
+			if anchor == "" {
+				continue
+			}
+			if m := lineAnchor.FindStringSubmatch(anchor); m != nil {
+				n, _ := strconv.Atoi(m[1])
+				if msg := t.checkLine(n, text); msg != "" {
+					report(link, "%s", msg)
+				}
+			} else if !t.slugs[anchor] {
+				report(link, "título #%s não existe em %s", anchor, p)
+			}
+		}
+
+		if msg, ref := c.checkRuimRef(base, line); msg != "" {
+			report(ref, "%s", msg)
+		}
+	}
+	return problems, nil
+}
+
+// checkRuimRef confere a referência `ruim.go:<n>` de uma entrada do índice.
+// Devolve a mensagem de erro (ou "") e a referência encontrada.
+func (c *Catalog) checkRuimRef(base, line string) (string, string) {
+	ref := ruimRef.FindStringSubmatch(line)
+	if ref == nil {
+		return "", ""
+	}
+	topic, ap := c.entry(base, line)
+	if topic == nil || ap == nil {
+		return "", ""
+	}
+	n, _ := strconv.Atoi(ref[1])
+	refText := topic.Dir + "/" + RuimFile + ":" + ref[1]
+	if ap.Ruim.Found() {
+		switch {
+		case n == ap.RuimLine:
+		case ap.RuimLine == ap.Ruim.Line:
+			return fmt.Sprintf("a declaração de %s está em %s:%d", ap.Ruim.Name, RuimFile, ap.RuimLine), refText
+		default:
+			return fmt.Sprintf("o trecho de %s está em %s:%d", ap.Ruim.Name, RuimFile, ap.RuimLine), refText
+		}
+		return "", ""
+	}
+	lines, err := declLines(filepath.Join(c.Root, filepath.FromSlash(topic.Dir), RuimFile))
+	if err != nil {
+		return err.Error(), refText
+	}
+	if !lines[n] {
+		return fmt.Sprintf("a linha %d não é uma declaração de %s", n, RuimFile), refText
+	}
+	return "", ""
+}
+
+// entry identifica o tópico e o anti-padrão de uma linha do índice, pelo
+// link para analise.md e pelo texto do link (ou pelo número da lista)
+func (c *Catalog) entry(base, line string) (*Topic, *AntiPattern) {
+	for _, m := range mdLink.FindAllStringSubmatch(line, -1) {
+		file, _, _ := strings.Cut(m[2], "#")
+		if path.Base(file) != AnalysisFile {
+			continue
+		}
+		topic := c.Topic(path.Dir(path.Clean(path.Join(base, file))))
+		if topic == nil {
+			return nil, nil
+		}
+		for _, ap := range topic.AntiPatterns {
+			if ap.Title == m[1] {
+				return topic, ap
+			}
+		}
+		if n := listNumber.FindStringSubmatch(strings.TrimSpace(line)); n != nil {
+			num, _ := strconv.Atoi(n[1])
+			for _, ap := range topic.AntiPatterns {
+				if ap.Number == num {
+					return topic, ap
+				}
+			}
+		}
+		return topic, nil
+	}
+	return nil, nil
+}
+
+// linkTarget guarda o que CheckLinks precisa saber de um arquivo ligado
+type linkTarget struct {
+	exists   bool
+	dir      bool
+	markdown bool
+	lines    []string
+	slugs    map[string]bool
+}
+
+func loadLinkTarget(name string) *linkTarget {
+	t := &linkTarget{slugs: make(map[string]bool)}
+	info, err := os.Stat(name)
+	if err != nil {
+		return t
+	}
+	t.exists = true
+	if t.dir = info.IsDir(); t.dir {
+		return t
+	}
+	src, err := os.ReadFile(name)
+	if err != nil {
+		return t
+	}
+	t.lines = strings.Split(string(src), "\n")
+	if t.markdown = strings.HasSuffix(name, ".md"); t.markdown {
+		seen := make(map[string]int)
+		inCode := false
+		for _, line := range t.lines {
+			if strings.HasPrefix(strings.TrimSpace(line), "```") {
+				inCode = !inCode
+			}
+			if m := mdHeading.FindStringSubmatch(line); m != nil && !inCode {
+				slug := Slug(m[1])
+				if n := seen[slug]; n > 0 {
+					t.slugs[fmt.Sprintf("%s-%d", slug, n)] = true
+				} else {
+					t.slugs[slug] = true
+				}
+				seen[slug]++
+			}
+		}
+	}
+	return t
+}
+
+// checkLine confere uma âncora #L<n>. Em arquivos Markdown a linha precisa
+// ser um título; se ele for "N. Título", o título precisa ser text.
+func (t *linkTarget) checkLine(n int, text string) string {
+	if t.dir {
+		return "âncora de linha em um diretório"
+	}
+	if n < 1 || n > len(t.lines) {
+		return fmt.Sprintf("o arquivo tem apenas %d linhas", len(t.lines))
+	}
+	if !t.markdown {
+		return ""
+	}
+	want := strings.TrimSpace(text)
+	if m := mdHeading.FindStringSubmatch(t.lines[n-1]); m != nil {
+		got := m[1]
+		if s := sectionHeading.FindStringSubmatch(t.lines[n-1]); s != nil {
+			got = s[2]
+		}
+		if want == "" || got == want {
+			return ""
+		}
+	}
+	for i, line := range t.lines {
+		if s := sectionHeading.FindStringSubmatch(line); s != nil && s[2] == want {
+			return fmt.Sprintf("a seção %q está na linha %d", want, i+1)
+		}
+	}
+	return fmt.Sprintf("a linha %d não é o título %q", n, want)
+}
+
+// Slug devolve a âncora que o GitHub gera para um título Markdown
+func Slug(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+// declLines devolve as linhas em que começam as declarações de nível de
+// pacote de um arquivo Go, incluindo var e const
+func declLines(name string) (map[int]bool, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, name, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	lines := make(map[int]bool)
+	for _, d := range f.Decls {
+		lines[fset.Position(d.Pos()).Line] = true
+		if gen, ok := d.(*ast.GenDecl); ok {
+			for _, spec := range gen.Specs {
+				lines[fset.Position(spec.Pos()).Line] = true
+			}
+		}
+	}
+	return lines, nil
+}
+
+// IndexLabels guarda os nomes exibidos no índice para níveis e tópicos,
+// indexados pelo diretório (ex: 01-basicos, exemplos/01-basicos/variaveis).
+// A ordem em que os tópicos aparecem também é preservada, assim como as
+// referências `ruim.go:<n>` de cada entrada (por diretório e número), que
+// RenderIndex mantém quando o anti-padrão não tem declaração associada.
+type IndexLabels struct {
+	Levels map[string]string
+	Topics map[string]string
+	Order  []string
+	Refs   map[string]map[int]int
+}
+
+var (
+	levelHeading = regexp.MustCompile(`^###\s+(?:📁\s+)?(.+?)\s*$`)
+	topicHeading = regexp.MustCompile(`^####\s+\[(.+)\]\((.+)\)\s*$`)
+)
+
+// ParseIndexLabels lê os nomes de níveis e tópicos de um índice existente
+func ParseIndexLabels(readme []byte) IndexLabels {
+	labels := IndexLabels{
+		Levels: make(map[string]string),
+		Topics: make(map[string]string),
+		Refs:   make(map[string]map[int]int),
+	}
+	section, _, _ := findSection(readme, IndexHeading)
+	var level, topic string
+	for _, line := range strings.Split(string(section), "\n") {
+		if m := topicHeading.FindStringSubmatch(line); m != nil {
+			topic = strings.TrimSuffix(m[2], "/")
+			labels.Topics[topic] = m[1]
+			labels.Order = append(labels.Order, topic)
+			labels.Refs[topic] = make(map[int]int)
+			if level != "" {
+				labels.Levels[path.Base(path.Dir(topic))] = level
+				level = ""
+			}
+		} else if m := levelHeading.FindStringSubmatch(line); m != nil {
+			level, topic = m[1], ""
+		} else if ref := ruimRef.FindStringSubmatch(line); ref != nil && topic != "" {
+			if n := listNumber.FindStringSubmatch(line); n != nil {
+				num, _ := strconv.Atoi(n[1])
+				labels.Refs[topic][num], _ = strconv.Atoi(ref[1])
+			}
+		}
+	}
+	return labels
+}
+
+// Level devolve o nome de exibição de um nível (ex: 01-Básicos)
+func (l IndexLabels) Level(level string) string {
+	if name, ok := l.Levels[level]; ok {
+		return name
+	}
+	return dirLabel(level)
+}
+
+// Topic devolve o nome de exibição de um tópico (ex: Variáveis)
+func (l IndexLabels) Topic(t *Topic) string {
+	if name, ok := l.Topics[t.Dir]; ok {
+		return name
+	}
+	return dirLabel(t.Category)
+}
+
+// Sorted devolve os tópicos na ordem do índice: por nível e, dentro de cada
+// nível, na ordem de labels
+func (c *Catalog) Sorted(labels IndexLabels) []*Topic {
+	order := make(map[string]int)
+	for i, dir := range labels.Order {
+		order[dir] = i
+	}
+	var levels []string
+	byLevel := make(map[string][]*Topic)
+	for _, t := range c.Topics {
+		if _, ok := byLevel[t.Level]; !ok {
+			levels = append(levels, t.Level)
+		}
+		byLevel[t.Level] = append(byLevel[t.Level], t)
+	}
+	var out []*Topic
+	for _, level := range levels {
+		topics := byLevel[level]
+		sortTopics(topics, order)
+		out = append(out, topics...)
+	}
+	return out
+}
+
+// RenderIndex gera a seção do índice do README (sem o título), usando os
+// nomes de labels e, para tópicos novos, nomes derivados dos diretórios.
+// Anti-padrões sem declaração em ruim.go mantêm a referência de labels.
+func (c *Catalog) RenderIndex(labels IndexLabels) string {
+	var b strings.Builder
+	level := ""
+	for _, t := range c.Sorted(labels) {
+		if t.Level != level {
+			level = t.Level
+			fmt.Fprintf(&b, "\n### 📁 %s\n", labels.Level(level))
+		}
+		fmt.Fprintf(&b, "\n#### [%s](%s)\n", labels.Topic(t), t.Dir)
+		for _, ap := range t.AntiPatterns {
+			fmt.Fprintf(&b, "%d. [%s](%s/%s#L%d)", ap.Number, ap.Title, t.Dir, AnalysisFile, ap.AnalysisLine)
+			if ap.Ruim.Found() {
+				fmt.Fprintf(&b, " - `%s:%d`", RuimFile, ap.RuimLine)
+			} else if n := labels.Refs[t.Dir][ap.Number]; n > 0 {
+				fmt.Fprintf(&b, " - `%s:%d`", RuimFile, n)
+			}
+			b.WriteByte('\n')
+		}
+	}
+	fmt.Fprintf(&b, "\n---\n\n**Total: %d anti-padrões documentados** 🚫\n\n", c.Count())
+	return b.String()
+}
+
+// sortTopics ordena os tópicos de um nível pela ordem do índice anterior;
+// tópicos novos vão para o fim, em ordem alfabética
+func sortTopics(topics []*Topic, order map[string]int) {
+	rank := func(t *Topic) int {
+		if i, ok := order[t.Dir]; ok {
+			return i
+		}
+		return len(order)
+	}
+	sort.SliceStable(topics, func(i, j int) bool {
+		a, b := topics[i], topics[j]
+		if rank(a) != rank(b) {
+			return rank(a) < rank(b)
+		}
+		return a.Dir < b.Dir
+	})
+}
+
+// dirLabel deriva um nome de exibição de um diretório (ex: api-design vira
+// "Api Design" e 04-casos-reais vira "04-Casos Reais")
+func dirLabel(dir string) string {
+	prefix := ""
+	if i := strings.IndexByte(dir, '-'); i > 0 && strings.Trim(dir[:i], "0123456789") == "" {
+		prefix, dir = dir[:i+1], dir[i+1:]
+	}
+	words := strings.Split(dir, "-")
+	for i, w := range words {
+		r := []rune(w)
+		if len(r) > 0 {
+			r[0] = unicode.ToUpper(r[0])
+		}
+		words[i] = string(r)
+	}
+	return prefix + strings.Join(words, " ")
+}
+
+// ReplaceSection troca o conteúdo da seção iniciada pela linha heading
+// (até o próximo título de mesmo nível) por body
+func ReplaceSection(src []byte, heading, body string) ([]byte, error) {
+	_, start, end := findSection(src, heading)
+	if start < 0 {
+		return nil, fmt.Errorf("seção %q não encontrada", heading)
+	}
+	var out bytes.Buffer
+	out.Write(src[:start])
+	out.WriteString(body)
+	out.Write(src[end:])
+	return out.Bytes(), nil
+}
+
+// findSection devolve o conteúdo da seção heading e seus limites em src
+// (logo após a linha do título até o próximo título de mesmo nível).
+// start é -1 quando a seção não existe.
+func findSection(src []byte, heading string) ([]byte, int, int) {
+	level := heading[:strings.IndexByte(heading, ' ')+1]
+	start, offset := -1, 0
+	inCode := false
+	for _, line := range strings.SplitAfter(string(src), "\n") {
+		trimmed := strings.TrimRight(line, "\r\n")
+		if strings.HasPrefix(strings.TrimSpace(trimmed), "```") {
+			inCode = !inCode
+		}
+		switch {
+		case inCode:
+		case start < 0 && trimmed == heading:
+			start = offset + len(line)
+		case start >= 0 && strings.HasPrefix(trimmed, level):
+			return src[start:offset], start, offset
+		}
+		offset += len(line)
+	}
+	if start < 0 {
+		return nil, -1, -1
+	}
+	return src[start:], start, len(src)
+}
+
+// Marcadores do trecho do ROADMAP.md gerado a partir do catálogo.
+// UpdateRoadmap e AddRoadmapTopic não mexem no que está fora dele.
+const (
+	RoadmapBegin = "<!-- fubango index: início -->"
+	RoadmapEnd   = "<!-- fubango index: fim -->"
+)
+
+var (
+	roadmapTopic      = regexp.MustCompile(`^(\s*- \[[ x]\] \*\*)(.+?)(\*\* \()\d+( anti-padrões documentados\))`)
+	roadmapBenchmarks = regexp.MustCompile(`^(\s+- )\d+( benchmarks)`)
+	roadmapStats      = []struct {
+		re   *regexp.Regexp
+		stat func(*Catalog) int
+	}{
+		{regexp.MustCompile(`(\*\*)\d+( anti-padrões\*\*)`), (*Catalog).Count},
+		{regexp.MustCompile(`(com )\d+( anti-padrões)`), (*Catalog).Count},
+		{regexp.MustCompile(`(\*\*)\d+( categorias\*\*)`), func(c *Catalog) int { return len(c.Topics) }},
+		{regexp.MustCompile("(\\*\\*)\\d+( arquivos\\*\\* `" + AnalysisFile + "`)"), func(c *Catalog) int { return len(c.Topics) }},
+		{regexp.MustCompile(`(\*\*)\d+\+?( benchmarks\*\*)`), (*Catalog).Benchmarks},
+	}
+)
+
+// Benchmarks devolve o número total de benchmarks do catálogo
+func (c *Catalog) Benchmarks() int {
+	n := 0
+	for _, t := range c.Topics {
+		n += len(t.Benchmarks)
+	}
+	return n
+}
+
+// roadmapBlock devolve o intervalo [start, end) das linhas entre
+// RoadmapBegin e RoadmapEnd, ou um intervalo vazio sem os marcadores
+func roadmapBlock(lines []string) (start, end int) {
+	start = -1
+	for i, line := range lines {
+		switch strings.TrimSpace(line) {
+		case RoadmapBegin:
+			start = i + 1
+		case RoadmapEnd:
+			if start >= 0 {
+				return start, i
+			}
+		}
+	}
+	return 0, 0
+}
+
+// UpdateRoadmap atualiza as estatísticas do ROADMAP.md entre RoadmapBegin e
+// RoadmapEnd: o número de anti-padrões de cada tópico (identificado pelo
+// nome usado no índice), os benchmarks listados logo abaixo dele e os totais
+// do catálogo.
+func (c *Catalog) UpdateRoadmap(src []byte, labels IndexLabels) []byte {
+	byLabel := c.byLabel(labels)
+
+	lines := strings.SplitAfter(string(src), "\n")
+	start, end := roadmapBlock(lines)
+	var current *Topic
+	for i := start; i < end; i++ {
+		line := lines[i]
+		if m := roadmapTopic.FindStringSubmatchIndex(line); m != nil {
+			current = byLabel[line[m[4]:m[5]]]
+			if current != nil {
+				lines[i] = line[:m[7]] + strconv.Itoa(len(current.AntiPatterns)) + line[m[8]:]
+			}
+			continue
+		}
+		if current != nil && roadmapBenchmarks.MatchString(line) {
+			lines[i] = roadmapBenchmarks.ReplaceAllString(line, "${1}"+strconv.Itoa(len(current.Benchmarks))+"${2}")
+			continue
+		}
+		if !strings.HasPrefix(line, "  ") {
+			current = nil
+		}
+		for _, s := range roadmapStats {
+			lines[i] = s.re.ReplaceAllString(lines[i], "${1}"+strconv.Itoa(s.stat(c))+"${2}")
+		}
+	}
+	return []byte(strings.Join(lines, ""))
+}
+
+// byLabel indexa os tópicos pelo nome usado no índice
+func (c *Catalog) byLabel(labels IndexLabels) map[string]*Topic {
+	byLabel := make(map[string]*Topic)
+	for _, t := range c.Topics {
+		byLabel[labels.Topic(t)] = t
+	}
+	return byLabel
+}
+
+// AddRoadmapTopic acrescenta ao ROADMAP.md a entrada de um tópico novo,
+// logo depois do último tópico do mesmo nível, para que UpdateRoadmap
+// passe a contar os seus anti-padrões e benchmarks. Se o tópico já está no
+// ROADMAP, ou se nenhum tópico do nível está no trecho gerado, src volta
+// sem mudanças.
+func (c *Catalog) AddRoadmapTopic(src []byte, labels IndexLabels, t *Topic) []byte {
+	byLabel := c.byLabel(labels)
+	label := labels.Topic(t)
+	lines := strings.SplitAfter(string(src), "\n")
+	start, end := roadmapBlock(lines)
+	insert := -1
+	for i := start; i < end; i++ {
+		m := roadmapTopic.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		if m[2] == label {
+			return src
+		}
+		if other := byLabel[m[2]]; other != nil && other.Level == t.Level {
+			insert = i + 1
+			for insert < end && strings.HasPrefix(lines[insert], "  ") {
+				insert++
+			}
+		}
+	}
+	if insert < 0 {
+		return src
+	}
+	entry := fmt.Sprintf("- [ ] **%s** (%d anti-padrões documentados)\n  - %d benchmarks\n",
+		label, len(t.AntiPatterns), len(t.Benchmarks))
+	return []byte(strings.Join(lines[:insert], "") + entry + strings.Join(lines[insert:], ""))
+}
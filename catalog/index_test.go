@@ -0,0 +1,192 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSlug(t *testing.T) {
+	tests := map[string]string{
+		"Índice de Anti-Padrões Documentados": "índice-de-anti-padrões-documentados",
+		"Ferramenta `fubango`":                "ferramenta-fubango",
+		"1. Race Conditions (Condições)":      "1-race-conditions-condições",
+	}
+	for heading, want := range tests {
+		if got := Slug(heading); got != want {
+			t.Errorf("Slug(%q) = %q, esperado %q", heading, got, want)
+		}
+	}
+}
+
+func TestCheckLinks(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, ExamplesDir, "01-basicos", "funcoes")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		RuimFile:     "package funcoes\n\n// Função longa demais\nfunc LongFunction() {}\n",
+		BomFile:      "package funcoes\n\n// Função curta\nfunc ShortFunction() {}\n",
+		AnalysisFile: "# Funções\n\n## 1. Função Longa\n```go\nfunc LongFunction() {}\n```\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	readme := "# Projeto\n\n## Uso\n\n" +
+		"1. [Função Longa](exemplos/01-basicos/funcoes/analise.md#L3) - `ruim.go:4`\n" +
+		"2. [Função Longa](exemplos/01-basicos/funcoes/analise.md#L1) - `ruim.go:3`\n" +
+		"[uso](#uso) [sumiu](#sumiu) [nada](exemplos/nada.go)\n"
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte(readme), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	problems, err := c.CheckLinks("README.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, p := range problems {
+		got = append(got, p.String())
+	}
+	want := []string{
+		`README.md:6: exemplos/01-basicos/funcoes/analise.md#L1: a seção "Função Longa" está na linha 3`,
+		"README.md:6: exemplos/01-basicos/funcoes/ruim.go:3: a declaração de LongFunction está em ruim.go:4",
+		"README.md:7: #sumiu: título #sumiu não existe em README.md",
+		"README.md:7: exemplos/nada.go: arquivo exemplos/nada.go não existe",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("problemas:\n%s\nesperado:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestRenderIndexRefs(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, ExamplesDir, "01-basicos", "funcoes")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		RuimFile:     "package funcoes\n\nfunc Everything() {\n\tx := 1\n\n\ty := x\n\t_ = y\n}\n",
+		BomFile:      "package funcoes\n",
+		AnalysisFile: "# Funções\n\n## 1. Nomes Curtos\n```go\nx := 1\n```\n\n## 2. Cópias\n```go\ny := x\n```\n\n## 3. Sem Código\ntexto\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c, err := Load(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	readme := IndexHeading + "\n\n#### [Funções](exemplos/01-basicos/funcoes)\n" +
+		"3. [Sem Código](exemplos/01-basicos/funcoes/analise.md#L13) - `ruim.go:7`\n"
+	got := c.RenderIndex(ParseIndexLabels([]byte(readme)))
+	for _, want := range []string{
+		"1. [Nomes Curtos](exemplos/01-basicos/funcoes/analise.md#L3) - `ruim.go:4`\n",
+		"2. [Cópias](exemplos/01-basicos/funcoes/analise.md#L8) - `ruim.go:6`\n",
+		"3. [Sem Código](exemplos/01-basicos/funcoes/analise.md#L13) - `ruim.go:7`\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("índice sem %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestReadmeIndex(t *testing.T) {
+	c, err := Load("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	readme, err := os.ReadFile("../README.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := ReplaceSection(readme, IndexHeading, c.RenderIndex(ParseIndexLabels(readme)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != string(readme) {
+		t.Error(`índice do README desatualizado; execute "go run ./cmd/fubango index write"`)
+	}
+	problems, err := c.CheckLinks("README.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range problems {
+		t.Error(p)
+	}
+}
+
+func TestUpdateRoadmap(t *testing.T) {
+	c := &Catalog{Topics: []*Topic{
+		{Dir: "exemplos/03-avancado/context", Category: "context", AntiPatterns: make([]*AntiPattern, 5), Benchmarks: make([]string, 17)},
+		{Dir: "exemplos/04-casos-reais/api-design", Category: "api-design", AntiPatterns: make([]*AntiPattern, 7)},
+	}}
+	labels := IndexLabels{Topics: map[string]string{"exemplos/04-casos-reais/api-design": "API Design"}}
+	src := RoadmapBegin + "\n" +
+		"- [x] **Context** (3 anti-padrões documentados)\n" +
+		"  - 11 benchmarks completos\n" +
+		"- [x] **API Design** (2 anti-padrões documentados)\n" +
+		"  - 13 benchmarks com httptest\n" +
+		"\n" +
+		"- **87 anti-padrões** documentados\n" +
+		"- **10 categorias** organizadas\n" +
+		"- **75+ benchmarks** funcionais\n" +
+		RoadmapEnd + "\n" +
+		"- 100+ stars no GitHub\n" +
+		"- **87 anti-padrões** em outubro\n"
+	want := RoadmapBegin + "\n" +
+		"- [x] **Context** (5 anti-padrões documentados)\n" +
+		"  - 17 benchmarks completos\n" +
+		"- [x] **API Design** (7 anti-padrões documentados)\n" +
+		"  - 0 benchmarks com httptest\n" +
+		"\n" +
+		"- **12 anti-padrões** documentados\n" +
+		"- **2 categorias** organizadas\n" +
+		"- **17 benchmarks** funcionais\n" +
+		RoadmapEnd + "\n" +
+		"- 100+ stars no GitHub\n" +
+		"- **87 anti-padrões** em outubro\n"
+	if got := string(c.UpdateRoadmap([]byte(src), labels)); got != want {
+		t.Errorf("UpdateRoadmap:\n%s\nesperado:\n%s", got, want)
+	}
+}
+
+func TestAddRoadmapTopic(t *testing.T) {
+	c := &Catalog{Topics: []*Topic{
+		{Dir: "exemplos/03-avancado/context", Level: "03-avancado", Category: "context"},
+		{Dir: "exemplos/03-avancado/generics", Level: "03-avancado", Category: "generics", AntiPatterns: make([]*AntiPattern, 1), Benchmarks: make([]string, 2)},
+		{Dir: "exemplos/04-casos-reais/api-design", Level: "04-casos-reais", Category: "api-design"},
+	}}
+	labels := IndexLabels{Topics: map[string]string{"exemplos/04-casos-reais/api-design": "API Design"}}
+	src := RoadmapBegin + "\n" +
+		"- [x] **Context** (5 anti-padrões documentados)\n" +
+		"  - 17 benchmarks completos\n" +
+		"\n" +
+		"- [x] **API Design** (7 anti-padrões documentados)\n" +
+		RoadmapEnd + "\n"
+	want := RoadmapBegin + "\n" +
+		"- [x] **Context** (5 anti-padrões documentados)\n" +
+		"  - 17 benchmarks completos\n" +
+		"- [ ] **Generics** (1 anti-padrões documentados)\n" +
+		"  - 2 benchmarks\n" +
+		"\n" +
+		"- [x] **API Design** (7 anti-padrões documentados)\n" +
+		RoadmapEnd + "\n"
+	got := c.AddRoadmapTopic([]byte(src), labels, c.Topics[1])
+	if string(got) != want {
+		t.Errorf("AddRoadmapTopic:\n%s\nesperado:\n%s", got, want)
+	}
+	if again := c.AddRoadmapTopic(got, labels, c.Topics[1]); string(again) != want {
+		t.Errorf("tópico repetido no ROADMAP:\n%s", again)
+	}
+}
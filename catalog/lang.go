@@ -0,0 +1,105 @@
+package catalog
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultLang é a língua do analise.md. As traduções ficam ao lado, em
+// analise.<língua>.md, como analise.en.md e analise.es.md.
+const DefaultLang = "pt-BR"
+
+var langCode = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// ParseLang normaliza um código de língua (ex: "EN" vira "en"). Vazio,
+// "pt" e "pt-BR" são a língua original.
+func ParseLang(lang string) (string, error) {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	switch {
+	case lang == "" || lang == "pt" || lang == strings.ToLower(DefaultLang):
+		return DefaultLang, nil
+	case !langCode.MatchString(lang):
+		return "", fmt.Errorf("código de língua inválido %q (use, por exemplo, en ou es)", lang)
+	}
+	return lang, nil
+}
+
+// AnalysisFileFor devolve o nome do analise.md na língua lang
+func AnalysisFileFor(lang string) string {
+	if lang == "" || lang == DefaultLang {
+		return AnalysisFile
+	}
+	return "analise." + lang + ".md"
+}
+
+// translations lista as línguas com analise.<língua>.md em dir
+func translations(dir string) ([]string, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "analise.*.md"))
+	if err != nil {
+		return nil, err
+	}
+	var langs []string
+	for _, name := range names {
+		lang := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(name), "analise."), ".md")
+		if langCode.MatchString(lang) {
+			langs = append(langs, lang)
+		}
+	}
+	sort.Strings(langs)
+	return langs, nil
+}
+
+// LoadLang carrega o catálogo com os títulos na língua lang. Tópicos sem
+// essa tradução, e seções que a tradução ainda não tem, ficam em pt-BR;
+// o campo Lang de cada tópico e anti-padrão diz qual texto foi usado.
+// Validate e fubango index trabalham sempre com o original (Load).
+func LoadLang(root, lang string) (*Catalog, error) {
+	lang, err := ParseLang(lang)
+	if err != nil {
+		return nil, err
+	}
+	c, err := Load(root)
+	if err != nil || lang == DefaultLang {
+		return c, err
+	}
+	for _, t := range c.Topics {
+		if err := t.localize(root, lang); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// HasLang informa se o tópico tem o analise.md na língua lang
+func (t *Topic) HasLang(lang string) bool {
+	if lang == DefaultLang {
+		return true
+	}
+	for _, l := range t.Langs {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// localize troca os títulos pelos da tradução lang, se existir
+func (t *Topic) localize(root, lang string) error {
+	if !t.HasLang(lang) {
+		return nil
+	}
+	a, err := ParseAnalysisFile(filepath.Join(root, filepath.FromSlash(t.Dir), AnalysisFileFor(lang)))
+	if err != nil {
+		return err
+	}
+	t.Lang, t.Title = lang, a.Title
+	for _, s := range a.Sections {
+		if ap := t.antiPattern(s.Number); ap != nil {
+			ap.Lang, ap.Title, ap.AnalysisLine = lang, s.Title, s.Line
+		}
+	}
+	return nil
+}
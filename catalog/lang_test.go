@@ -0,0 +1,113 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseLang(t *testing.T) {
+	for in, want := range map[string]string{"": DefaultLang, "pt": DefaultLang, "PT-br": DefaultLang, "EN": "en", "es": "es", "zh-hant": "zh-hant"} {
+		if got, err := ParseLang(in); err != nil || got != want {
+			t.Errorf("ParseLang(%q) = %q, %v; esperado %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseLang("../en"); err == nil {
+		t.Error("ParseLang aceitou um caminho")
+	}
+	if got := AnalysisFileFor("en"); got != "analise.en.md" {
+		t.Errorf("AnalysisFileFor(en) = %q", got)
+	}
+}
+
+// writeTranslatedTopic cria um tópico com o analise.md e uma tradução em
+// inglês: a seção 1 acompanha o original, a 2 mudou o código e os
+// subtítulos, a 3 falta e a 4 não existe no original
+func writeTranslatedTopic(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, ExamplesDir, "01-basicos", "demo")
+	files := map[string]string{
+		AnalysisFile: "# Demo\n\n## 1. Primeiro\n\n```go\n// ruim\nfmt.Println(\"olá\")\n```\n\n" +
+			"## 2. Segundo\n\n### Problemas\n\n```go\nx := 1\n```\n\n## 3. Terceiro\n\ntexto\n\n## Conclusão\n",
+		AnalysisFileFor("en"): "# Demo (en)\n\ntext\n\n## 1. First\n\n```go\n// bad\nfmt.Println(\"hello\")\n```\n\n" +
+			"## 2. Second\n\n```go\nx := 2\n```\n\n## 4. Fourth\n",
+		RuimFile: "package demo\n",
+		BomFile:  "package demo\n",
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestLoadLang(t *testing.T) {
+	root := writeTranslatedTopic(t)
+	c, err := LoadLang(root, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	topic := c.Topics[0]
+	if topic.Title != "Demo (en)" || topic.Lang != "en" || !reflect.DeepEqual(topic.Langs, []string{"en"}) {
+		t.Errorf("tópico = %q em %q, traduções %v", topic.Title, topic.Lang, topic.Langs)
+	}
+	var got [][2]string
+	for _, ap := range topic.AntiPatterns {
+		got = append(got, [2]string{ap.Title, ap.Lang})
+	}
+	// a seção 3 não foi traduzida e fica em pt-BR
+	want := [][2]string{{"First", "en"}, {"Second", "en"}, {"Terceiro", DefaultLang}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("anti-padrões = %v, esperado %v", got, want)
+	}
+
+	es, err := LoadLang(root, "es")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if topic := es.Topics[0]; topic.Title != "Demo" || topic.Lang != DefaultLang {
+		t.Errorf("sem tradução em es: %q em %q", topic.Title, topic.Lang)
+	}
+}
+
+func TestTranslationStatus(t *testing.T) {
+	c, err := Load(writeTranslatedTopic(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if langs := c.Langs(); !reflect.DeepEqual(langs, []string{"en"}) {
+		t.Errorf("Langs = %v", langs)
+	}
+
+	status, err := c.TranslationStatus("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := status[0]
+	if st.File != "analise.en.md" || st.Sections != 3 || st.Translated != 2 ||
+		!reflect.DeepEqual(st.Missing, []int{3}) || !reflect.DeepEqual(st.Extra, []int{4}) || st.Complete() {
+		t.Errorf("status = %+v", st)
+	}
+	// comentários e strings traduzidos não contam como mudança
+	want := []OutdatedSection{{Number: 2, Line: 12, Reasons: []string{
+		"subtítulos ### no original, (nenhum) na tradução",
+		"bloco de código 1 difere do original",
+	}}}
+	if !reflect.DeepEqual(st.Outdated, want) {
+		t.Errorf("desatualizadas = %+v, esperado %+v", st.Outdated, want)
+	}
+
+	status, err = c.TranslationStatus("es")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st := status[0]; st.File != "" || st.Translated != 0 || len(st.Missing) != 3 {
+		t.Errorf("status sem tradução = %+v", st)
+	}
+}
@@ -0,0 +1,306 @@
+package catalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Severities são as gravidades aceitas no manifesto, da mais branda para a
+// mais grave. Os nomes seguem os níveis do SARIF, como nos analisadores.
+var Severities = []string{"note", "warning", "error"}
+
+// Manifest é o manifesto.json de um tópico: a descrição de cada anti-padrão
+// que as ferramentas usam em vez de adivinhar a partir do analise.md.
+type Manifest struct {
+	AntiPatterns []*ManifestEntry `json:"antiPatterns"`
+}
+
+// ManifestEntry descreve um anti-padrão no manifesto
+type ManifestEntry struct {
+	ID        string         `json:"id"` // categoria/slug, estável entre renumerações
+	Number    int            `json:"number"`
+	Title     string         `json:"title"`
+	Severity  string         `json:"severity"`
+	Tags      []string       `json:"tags"`
+	Ruim      string         `json:"ruim,omitempty"` // declaração em ruim.go
+	Bom       string         `json:"bom,omitempty"`  // contraparte em bom.go
+	Benchmark *BenchmarkPair `json:"benchmark,omitempty"`
+	Analyzers []string       `json:"analyzers,omitempty"`
+
+	Line int `json:"-"` // linha da entrada no manifesto.json
+}
+
+// BenchmarkPair liga os benchmarks das versões ruim e boa de um anti-padrão
+type BenchmarkPair struct {
+	Ruim string `json:"ruim"`
+	Bom  string `json:"bom"`
+}
+
+// ParseManifest lê um manifesto. Campos desconhecidos são erro, para que
+// um erro de digitação não passe despercebido.
+func ParseManifest(data []byte) (*Manifest, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+	m := &Manifest{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		if tok != "antiPatterns" {
+			return nil, fmt.Errorf("campo desconhecido %v", tok)
+		}
+		if err := expectDelim(dec, '['); err != nil {
+			return nil, err
+		}
+		for dec.More() {
+			e := &ManifestEntry{Line: lineAt(data, dec.InputOffset())}
+			if err := dec.Decode(e); err != nil {
+				return nil, err
+			}
+			m.AntiPatterns = append(m.AntiPatterns, e)
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return nil, err
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != want {
+		return fmt.Errorf("esperava %q, encontrou %v", want, tok)
+	}
+	return nil
+}
+
+// lineAt devolve a linha do primeiro caractere não branco a partir de offset
+func lineAt(data []byte, offset int64) int {
+	i := int(offset)
+	for i < len(data) && strings.ContainsRune(" \t\r\n,", rune(data[i])) {
+		i++
+	}
+	return bytes.Count(data[:i], []byte("\n")) + 1
+}
+
+// ReadManifestFile lê o manifesto em path
+func ReadManifestFile(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m, err := ParseManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// LoadManifests lê os manifestos de fsys, cuja raiz é o diretório exemplos,
+// indexados pelo ID do tópico (nível/categoria). Permite ler os manifestos
+// embutidos no binário, sem o repositório em disco.
+func LoadManifests(fsys fs.FS) (map[string]*Manifest, error) {
+	names, err := fs.Glob(fsys, path.Join("*", "*", ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	manifests := make(map[string]*Manifest, len(names))
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		m, err := ParseManifest(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		manifests[path.Dir(name)] = m
+	}
+	return manifests, nil
+}
+
+// Entry busca a entrada do anti-padrão number
+func (m *Manifest) Entry(number int) *ManifestEntry {
+	for _, e := range m.AntiPatterns {
+		if e.Number == number {
+			return e
+		}
+	}
+	return nil
+}
+
+var (
+	manifestID  = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*/[a-z0-9]+(-[a-z0-9]+)*$`)
+	manifestTag = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+// Validate confere os manifestos com os demais arquivos de cada tópico:
+// toda seção do analise.md tem uma entrada com o mesmo número e título, os
+// IDs são únicos, as declarações existem em ruim.go e bom.go e os
+// benchmarks em benchmark_test.go. Se analyzers não for vazio, os nomes de
+// analisadores também são conferidos.
+func (c *Catalog) Validate(analyzers []string) ([]Problem, error) {
+	var problems []Problem
+	ids := make(map[string]string)
+	for _, t := range c.Topics {
+		name := t.Dir + "/" + ManifestFile
+		if t.Manifest == nil {
+			problems = append(problems, Problem{File: name, Target: t.ID(), Message: "tópico sem manifesto"})
+			continue
+		}
+		dir := filepath.Join(c.Root, filepath.FromSlash(t.Dir))
+		ruim, err := ParseSourceFile(filepath.Join(dir, RuimFile))
+		if err != nil {
+			return nil, err
+		}
+		bom, err := ParseSourceFile(filepath.Join(dir, BomFile))
+		if err != nil {
+			return nil, err
+		}
+		add := func(e *ManifestEntry, target, format string, args ...any) {
+			problems = append(problems, Problem{File: name, Line: e.Line, Target: target, Message: fmt.Sprintf(format, args...)})
+		}
+
+		numbers := make(map[int]bool)
+		for _, e := range t.Manifest.AntiPatterns {
+			target := fmt.Sprintf("#%d", e.Number)
+			if numbers[e.Number] {
+				add(e, target, "número repetido no manifesto")
+			}
+			numbers[e.Number] = true
+			switch ap := t.antiPattern(e.Number); {
+			case ap == nil:
+				add(e, target, "não há seção %d em %s", e.Number, AnalysisFile)
+			case ap.Title != e.Title:
+				add(e, target, "o título em %s é %q", AnalysisFile, ap.Title)
+			}
+
+			switch other, dup := ids[e.ID]; {
+			case !manifestID.MatchString(e.ID) || !strings.HasPrefix(e.ID, t.Category+"/"):
+				add(e, e.ID, "o ID precisa ter a forma %s/<slug>, em minúsculas", t.Category)
+			case dup:
+				add(e, e.ID, "ID repetido (também em %s)", other)
+			}
+			ids[e.ID] = fmt.Sprintf("%s:%d", name, e.Line)
+
+			if !slices.Contains(Severities, e.Severity) {
+				add(e, target, "gravidade desconhecida %q (use %s)", e.Severity, strings.Join(Severities, ", "))
+			}
+			if len(e.Tags) == 0 {
+				add(e, target, "nenhuma tag")
+			}
+			for _, tag := range e.Tags {
+				if !manifestTag.MatchString(tag) {
+					add(e, tag, "tags são palavras em minúsculas separadas por hífen")
+				}
+			}
+			if e.Ruim != "" && ruim.Lookup(e.Ruim) == nil {
+				add(e, e.Ruim, "não declarado em %s", RuimFile)
+			}
+			if e.Bom != "" && bom.Lookup(e.Bom) == nil {
+				add(e, e.Bom, "não declarado em %s", BomFile)
+			}
+			if e.Benchmark != nil {
+				for _, b := range []string{e.Benchmark.Ruim, e.Benchmark.Bom} {
+					if !slices.Contains(t.Benchmarks, b) {
+						add(e, b, "não declarado em %s", BenchmarkFile)
+					}
+				}
+			}
+			for _, a := range e.Analyzers {
+				if len(analyzers) > 0 && !slices.Contains(analyzers, a) {
+					add(e, a, "analisador desconhecido")
+				}
+			}
+		}
+		for _, ap := range t.AntiPatterns {
+			if !numbers[ap.Number] {
+				problems = append(problems, Problem{File: t.Dir + "/" + AnalysisFile, Line: ap.AnalysisLine,
+					Target: fmt.Sprintf("#%d", ap.Number), Message: "seção sem entrada em " + ManifestFile})
+			}
+		}
+	}
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].File < problems[j].File })
+	return problems, nil
+}
+
+// antiPattern busca o anti-padrão number do tópico
+func (t *Topic) antiPattern(number int) *AntiPattern {
+	for _, ap := range t.AntiPatterns {
+		if ap.Number == number {
+			return ap
+		}
+	}
+	return nil
+}
+
+// readTopicManifest lê o manifesto de dir, se existir
+func readTopicManifest(dir string) (*Manifest, error) {
+	m, err := ReadManifestFile(filepath.Join(dir, ManifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return m, err
+}
+
+// Encode grava o manifesto no formato dos arquivos do repositório
+func (m *Manifest) Encode() ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(m); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// asciiFold tira os acentos das letras usadas nos títulos
+var asciiFold = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// ManifestID devolve o ID de um anti-padrão a partir do título: a
+// categoria e o título em minúsculas, sem acentos e com hífens no lugar de
+// espaços e pontuação (ex: goroutines/deadlock-com-canais)
+func ManifestID(category, title string) string {
+	var b strings.Builder
+	sep := false
+	for _, r := range asciiFold.Replace(strings.ToLower(title)) {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			sep = true
+			continue
+		}
+		if sep && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		sep = false
+		b.WriteRune(r)
+	}
+	return category + "/" + b.String()
+}
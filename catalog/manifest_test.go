@@ -0,0 +1,143 @@
+package catalog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseManifest(t *testing.T) {
+	src := `{
+  "antiPatterns": [
+    {"id": "x/um", "number": 1, "title": "Um", "severity": "note", "tags": ["a"]},
+    {
+      "id": "x/dois", "number": 2, "title": "Dois", "severity": "error", "tags": ["b"],
+      "ruim": "Bad", "benchmark": {"ruim": "BenchmarkBad", "bom": "BenchmarkGood"}
+    }
+  ]
+}
+`
+	m, err := ParseManifest([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.AntiPatterns) != 2 {
+		t.Fatalf("%d entradas, esperado 2", len(m.AntiPatterns))
+	}
+	if e := m.Entry(1); e.Line != 3 || e.ID != "x/um" {
+		t.Errorf("entrada 1 = %+v", e)
+	}
+	if e := m.Entry(2); e.Line != 4 || e.Ruim != "Bad" || e.Benchmark.Bom != "BenchmarkGood" {
+		t.Errorf("entrada 2 = %+v", e)
+	}
+
+	if _, err := ParseManifest([]byte(`{"antiPatterns": [{"id": "x/um", "severidade": "note"}]}`)); err == nil || !strings.Contains(err.Error(), "severidade") {
+		t.Errorf("campo desconhecido aceito: %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	c, err := Load("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	problems, err := c.Validate([]string{"goloopvar", "doubleclose", "lockcopy", "wgadd", "chanloop",
+		"sqlconcat", "rowsclose", "sqlloop", "handleropen", "uncheckedassert",
+		"errdiscard", "panicvalidate", "errwrap", "logreturn", "recoverall", "ctxcancel", "ctxiface"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range problems {
+		t.Error(p)
+	}
+}
+
+func TestValidateProblems(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, ExamplesDir, "01-basicos", "demo")
+	files := map[string]string{
+		AnalysisFile:  "# Demo\n\n## 1. Primeiro\n\n## 2. Segundo\n\n## 3. Terceiro\n",
+		RuimFile:      "package demo\n\nfunc Bad() {}\n",
+		BomFile:       "package demo\n\nfunc Good() {}\n",
+		BenchmarkFile: "package demo\n\nimport \"testing\"\n\nfunc BenchmarkBad(b *testing.B) {}\n",
+		ManifestFile: `{
+  "antiPatterns": [
+    {"id": "demo/primeiro", "number": 1, "title": "Primeiro", "severity": "note", "tags": ["a"], "ruim": "Bad", "bom": "Good"},
+    {"id": "demo/primeiro", "number": 2, "title": "Outro", "severity": "grave", "tags": ["B"], "ruim": "Missing",
+      "benchmark": {"ruim": "BenchmarkBad", "bom": "BenchmarkGood"}, "analyzers": ["nenhum"]}
+  ]
+}
+`,
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, err := Load(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ap := c.Topics[0].AntiPatterns[0]; ap.ID != "demo/primeiro" || ap.Ruim.Line != 3 || ap.Bom.Name != "Good" {
+		t.Errorf("anti-padrão 1 = %+v", ap)
+	}
+	problems, err := c.Validate([]string{"goloopvar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, p := range problems {
+		got = append(got, p.String())
+	}
+	want := []string{
+		`exemplos/01-basicos/demo/analise.md:7: #3: seção sem entrada em manifesto.json`,
+		`exemplos/01-basicos/demo/manifesto.json:4: #2: o título em analise.md é "Segundo"`,
+		`exemplos/01-basicos/demo/manifesto.json:4: demo/primeiro: ID repetido (também em exemplos/01-basicos/demo/manifesto.json:3)`,
+		`exemplos/01-basicos/demo/manifesto.json:4: #2: gravidade desconhecida "grave" (use note, warning, error)`,
+		`exemplos/01-basicos/demo/manifesto.json:4: B: tags são palavras em minúsculas separadas por hífen`,
+		`exemplos/01-basicos/demo/manifesto.json:4: Missing: não declarado em ruim.go`,
+		`exemplos/01-basicos/demo/manifesto.json:4: BenchmarkGood: não declarado em benchmark_test.go`,
+		`exemplos/01-basicos/demo/manifesto.json:4: nenhum: analisador desconhecido`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("problemas:\n%s\n\nesperado:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+// Os manifestos do repositório seguem o formato de Encode e os IDs de
+// ManifestID, que fubango new pattern usa para acrescentar entradas
+func TestManifestEncodeAndID(t *testing.T) {
+	names, err := filepath.Glob(filepath.Join("..", ExamplesDir, "*", "*", ManifestFile))
+	if err != nil || len(names) == 0 {
+		t.Fatal("nenhum manifesto encontrado", err)
+	}
+	for _, name := range names {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := ParseManifest(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := m.Encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out, data) {
+			t.Errorf("%s: Encode não reproduz o arquivo", name)
+		}
+		category := filepath.Base(filepath.Dir(name))
+		for _, e := range m.AntiPatterns {
+			if id := ManifestID(category, e.Title); id != e.ID {
+				t.Errorf("ManifestID(%q, %q) = %q, esperado %q", category, e.Title, id, e.ID)
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package catalog
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Analysis é o resultado da leitura de um analise.md
+type Analysis struct {
+	Title    string
+	Sections []Section
+}
+
+// Section representa uma seção numerada "## N. Título"
+type Section struct {
+	Number int
+	Title  string
+	Line   int    // linha do cabeçalho (1-based)
+	Code   string // primeiro bloco ```go da seção
+	Body   string // texto completo da seção, sem o cabeçalho
+}
+
+var sectionHeading = regexp.MustCompile(`^##\s+(\d+)\.\s+(.+?)\s*$`)
+
+// ParseAnalysisFile lê e interpreta um analise.md do disco
+func ParseAnalysisFile(path string) (*Analysis, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseAnalysis(f)
+}
+
+// ParseAnalysis extrai o título e as seções numeradas de um analise.md.
+// Seções sem número (ex: "## Conclusão") encerram a seção anterior mas não
+// são incluídas no resultado.
+func ParseAnalysis(r io.Reader) (*Analysis, error) {
+	a := &Analysis{}
+	scanner := bufio.NewScanner(r)
+
+	var (
+		current *Section
+		body    strings.Builder
+		code    strings.Builder
+		inCode  bool
+		gotCode bool
+		lineNo  int
+	)
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.Body = strings.TrimSpace(body.String())
+		current.Code = code.String()
+		a.Sections = append(a.Sections, *current)
+		current = nil
+	}
+
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+
+		if inCode {
+			if strings.HasPrefix(strings.TrimSpace(line), "```") {
+				inCode = false
+				gotCode = true
+			} else if current != nil && !gotCode {
+				code.WriteString(line)
+				code.WriteByte('\n')
+			}
+			body.WriteString(line)
+			body.WriteByte('\n')
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "# ") && a.Title == "":
+			a.Title = strings.TrimSpace(strings.TrimPrefix(line, "# "))
+			continue
+		case strings.HasPrefix(line, "## "):
+			flush()
+			if m := sectionHeading.FindStringSubmatch(line); m != nil {
+				n, _ := strconv.Atoi(m[1])
+				current = &Section{Number: n, Title: m[2], Line: lineNo}
+				body.Reset()
+				code.Reset()
+				gotCode = false
+			}
+			continue
+		case strings.HasPrefix(strings.TrimSpace(line), "```"):
+			inCode = true
+		}
+
+		if current != nil {
+			body.WriteString(line)
+			body.WriteByte('\n')
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return a, nil
+}
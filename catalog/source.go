@@ -0,0 +1,390 @@
+package catalog
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Tipos de declaração reconhecidos
+const (
+	KindFunc   = "func"
+	KindMethod = "method"
+	KindType   = "type"
+	KindVar    = "var"
+	KindConst  = "const"
+)
+
+// Decl é uma declaração de nível de pacote (função, método, tipo, variável
+// ou constante). Métodos são nomeados como Tipo.Metodo.
+type Decl struct {
+	Name    string
+	Kind    string
+	Doc     string
+	Line    int
+	EndLine int
+}
+
+// Source guarda as declarações e as linhas de um arquivo Go
+type Source struct {
+	Decls []Decl
+	Lines []string
+}
+
+// ParseSourceFile lê um arquivo Go sem verificar tipos, de modo que
+// exemplos ruins que não compilam (de propósito) ainda possam ser indexados.
+func ParseSourceFile(path string) (*Source, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSource(path, src)
+}
+
+// ParseSource interpreta o conteúdo de um arquivo Go
+func ParseSource(filename string, src []byte) (*Source, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Source{Lines: strings.Split(string(src), "\n")}
+	for _, d := range f.Decls {
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			name, kind := d.Name.Name, KindFunc
+			if recv := receiverName(d); recv != "" {
+				name, kind = recv+"."+name, KindMethod
+			}
+			s.Decls = append(s.Decls, Decl{
+				Name:    name,
+				Kind:    kind,
+				Doc:     d.Doc.Text(),
+				Line:    fset.Position(d.Pos()).Line,
+				EndLine: fset.Position(d.End()).Line,
+			})
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				pos, doc := spec.Pos(), specDoc(spec)
+				if !d.Lparen.IsValid() {
+					pos, doc = d.Pos(), d.Doc
+				}
+				decl := Decl{
+					Doc:     doc.Text(),
+					Line:    fset.Position(pos).Line,
+					EndLine: fset.Position(spec.End()).Line,
+				}
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					decl.Name, decl.Kind = spec.Name.Name, KindType
+					s.Decls = append(s.Decls, decl)
+				case *ast.ValueSpec:
+					// var (a, b int) gera uma declaração para cada nome
+					decl.Kind = KindVar
+					if d.Tok == token.CONST {
+						decl.Kind = KindConst
+					}
+					for _, name := range spec.Names {
+						if name.Name != "_" {
+							decl.Name = name.Name
+							s.Decls = append(s.Decls, decl)
+						}
+					}
+				}
+			}
+		}
+	}
+	return s, nil
+}
+
+func specDoc(spec ast.Spec) *ast.CommentGroup {
+	switch spec := spec.(type) {
+	case *ast.TypeSpec:
+		return spec.Doc
+	case *ast.ValueSpec:
+		return spec.Doc
+	}
+	return nil
+}
+
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	expr := fn.Recv.List[0].Type
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.Ident:
+			return e.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// Lookup busca uma declaração pelo nome
+func (s *Source) Lookup(name string) *Decl {
+	for i := range s.Decls {
+		if s.Decls[i].Name == name {
+			return &s.Decls[i]
+		}
+	}
+	return nil
+}
+
+// Ranges devolve as linhas (início e fim) da declaração name e, se ela for
+// um tipo, dos seus métodos, em ordem. Os métodos fazem parte da versão do
+// anti-padrão (ex: BadContainer.Store e BadContainer.Retrieve).
+func (s *Source) Ranges(name string) [][2]int {
+	var out [][2]int
+	for _, d := range s.Decls {
+		if d.Name == name || (d.Kind == KindMethod && strings.HasPrefix(d.Name, name+".")) {
+			out = append(out, [2]int{d.Line, d.EndLine})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i][0] < out[j][0] })
+	return out
+}
+
+// Enclosing retorna a declaração que contém a linha informada
+func (s *Source) Enclosing(line int) *Decl {
+	for i := range s.Decls {
+		if s.Decls[i].Line <= line && line <= s.Decls[i].EndLine {
+			return &s.Decls[i]
+		}
+	}
+	return nil
+}
+
+var (
+	funcDecl   = regexp.MustCompile(`^func\s+(?:\(\s*\w*\s*\*?(\w+)(?:\[[^\]]*\])?\s*\)\s*)?(\w+)`)
+	namedDecl  = regexp.MustCompile(`^(?:type|var|const)\s+(\w+)`)
+	whitespace = regexp.MustCompile(`\s+`)
+)
+
+// MatchSection associa uma seção de analise.md a uma declaração deste
+// arquivo. A busca segue três estratégias, em ordem:
+//
+//  1. funções, tipos, variáveis ou constantes declarados no trecho de código
+//     da seção;
+//  2. uma declaração cujo comentário comece com o número da seção
+//     (ex: "// 2. Concatenação de strings") e compartilhe palavras com o título;
+//  3. a primeira linha de código do trecho que aparece no arquivo, devolvendo
+//     a declaração que a contém.
+func (s *Source) MatchSection(sec Section) *Decl {
+	if d := s.matchNames(sec.Code); d != nil {
+		return d
+	}
+	if d := s.matchNumbered(sec.Number, sec.Title); d != nil {
+		return d
+	}
+	return s.matchLines(sec.Code)
+}
+
+func (s *Source) matchNames(code string) *Decl {
+	for _, line := range strings.Split(code, "\n") {
+		line = strings.TrimSpace(line)
+		var name string
+		if m := funcDecl.FindStringSubmatch(line); m != nil {
+			name = m[2]
+			if m[1] != "" {
+				name = m[1] + "." + name
+			}
+		} else if m := namedDecl.FindStringSubmatch(line); m != nil {
+			name = m[1]
+		}
+		if name == "" {
+			continue
+		}
+		if d := s.Lookup(name); d != nil {
+			return d
+		}
+	}
+	return nil
+}
+
+func (s *Source) matchNumbered(number int, title string) *Decl {
+	prefix := fmt.Sprintf("%d. ", number)
+	titleWords := textWords(title)
+	for i := range s.Decls {
+		d := &s.Decls[i]
+		if !strings.HasPrefix(d.Doc, prefix) {
+			continue
+		}
+		for _, w := range textWords(strings.SplitN(d.Doc, "\n", 2)[0]) {
+			for _, t := range titleWords {
+				if sameStem(w, t) {
+					return d
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Source) matchLines(code string) *Decl {
+	for _, line := range strings.Split(code, "\n") {
+		needle := normalizeCode(line)
+		if needle == "" {
+			continue
+		}
+		for i, candidate := range s.Lines {
+			if normalizeCode(candidate) == needle {
+				return s.Enclosing(i + 1)
+			}
+		}
+	}
+	return nil
+}
+
+// Locate devolve a linha de d em que aparece a primeira linha de código de
+// code (o trecho de uma seção) ou, se nenhuma aparecer, o início de d
+func (s *Source) Locate(d *Decl, code string) int {
+	for _, line := range strings.Split(code, "\n") {
+		needle := normalizeCode(line)
+		if needle == "" {
+			continue
+		}
+		for n := d.Line; n <= d.EndLine && n <= len(s.Lines); n++ {
+			if normalizeCode(s.Lines[n-1]) == needle {
+				return n
+			}
+		}
+	}
+	return d.Line
+}
+
+// textWords extrai as palavras significativas (4+ letras) de um texto livre
+func textWords(text string) []string {
+	var words []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		if len([]rune(w)) >= 4 {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// normalizeCode remove comentários e espaços para comparar linhas de código
+func normalizeCode(line string) string {
+	if i := strings.Index(line, "//"); i >= 0 {
+		line = line[:i]
+	}
+	line = whitespace.ReplaceAllString(strings.TrimSpace(line), " ")
+	line = strings.TrimSuffix(line, " {")
+	if line == "" || line == "{" || line == "}" || line == ")" || line == "..." {
+		return ""
+	}
+	return line
+}
+
+// Counterpart escolhe em bom.go a declaração que melhor corresponde a uma
+// declaração de ruim.go, comparando as palavras dos nomes em CamelCase.
+// Prefixos como Bad/Good/Safe são ignorados e pelo menos metade das palavras
+// precisa coincidir. Em caso de empate, prefere declarações do mesmo tipo
+// (função, método ou tipo). Retorna nil quando não há correspondência.
+func (s *Source) Counterpart(ruim *Decl) *Decl {
+	words := nameWords(ruim.Name)
+	var (
+		best      *Decl
+		bestScore float64
+	)
+	for i := range s.Decls {
+		d := &s.Decls[i]
+		if !ast.IsExported(lastComponent(d.Name)) {
+			continue
+		}
+		candidate := nameWords(d.Name)
+		matches := 0
+		for _, w := range words {
+			for _, c := range candidate {
+				if sameStem(w, c) {
+					matches++
+					break
+				}
+			}
+		}
+		score := float64(matches) / float64(max(len(words), len(candidate)))
+		if score < 0.5 {
+			continue
+		}
+		if score > bestScore || (score == bestScore && d.Kind == ruim.Kind && best.Kind != ruim.Kind) {
+			best, bestScore = d, score
+		}
+	}
+	return best
+}
+
+func lastComponent(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+var ignoredWords = map[string]bool{
+	"bad": true, "good": true, "safe": true, "ruim": true, "bom": true,
+	"new": true, "with": true, "in": true, "as": true, "to": true,
+}
+
+// nameWords divide um identificador CamelCase em palavras minúsculas
+func nameWords(name string) []string {
+	var (
+		words []string
+		cur   []rune
+	)
+	emit := func() {
+		if len(cur) > 0 {
+			w := strings.ToLower(string(cur))
+			if !ignoredWords[w] {
+				words = append(words, w)
+			}
+			cur = cur[:0]
+		}
+	}
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '.' || r == '_':
+			emit()
+			continue
+		case unicode.IsUpper(r) && len(cur) > 0:
+			prevLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				emit()
+			}
+		}
+		cur = append(cur, r)
+	}
+	emit()
+	return words
+}
+
+// sameStem compara palavras por prefixo comum (ex: order/ordered)
+func sameStem(a, b string) bool {
+	if a == b {
+		return true
+	}
+	const minStem = 4
+	if len(a) < minStem || len(b) < minStem {
+		return false
+	}
+	n := min(len(a), len(b))
+	return a[:n] == b[:n]
+}
@@ -0,0 +1,190 @@
+package catalog
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// TranslationStatus compara a tradução de um tópico com o analise.md
+type TranslationStatus struct {
+	Topic      *Topic
+	Lang       string
+	File       string // vazio se o tópico não tem a tradução
+	Sections   int    // seções numeradas do original
+	Translated int    // seções do original presentes na tradução
+	Missing    []int  // seções do original que faltam na tradução
+	Extra      []int  // seções da tradução que o original não tem
+	Outdated   []OutdatedSection
+}
+
+// Complete informa se a tradução existe e acompanha o original
+func (s TranslationStatus) Complete() bool {
+	return s.File != "" && len(s.Missing) == 0 && len(s.Extra) == 0 && len(s.Outdated) == 0
+}
+
+// OutdatedSection é uma seção traduzida cuja estrutura não bate com a do
+// original
+type OutdatedSection struct {
+	Number  int
+	Line    int // linha do cabeçalho na tradução
+	Reasons []string
+}
+
+// Langs lista as línguas traduzidas em algum tópico
+func (c *Catalog) Langs() []string {
+	var langs []string
+	for _, t := range c.Topics {
+		for _, l := range t.Langs {
+			if !slices.Contains(langs, l) {
+				langs = append(langs, l)
+			}
+		}
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// TranslationStatus compara, tópico a tópico, o analise.<lang>.md com o
+// analise.md. O texto muda de língua, mas os subtítulos e o código não:
+// uma seção traduzida cujos subtítulos ou blocos de código não batem com
+// os do original está desatualizada. Nos blocos de Go, comentários e o
+// texto das strings podem ser traduzidos; nos demais, só a língua do
+// bloco é comparada.
+func (c *Catalog) TranslationStatus(lang string) ([]TranslationStatus, error) {
+	var out []TranslationStatus
+	for _, t := range c.Topics {
+		dir := filepath.Join(c.Root, filepath.FromSlash(t.Dir))
+		original, err := ParseAnalysisFile(filepath.Join(dir, AnalysisFile))
+		if err != nil {
+			return nil, err
+		}
+		st := TranslationStatus{Topic: t, Lang: lang, Sections: len(original.Sections)}
+		if !t.HasLang(lang) {
+			for _, s := range original.Sections {
+				st.Missing = append(st.Missing, s.Number)
+			}
+			out = append(out, st)
+			continue
+		}
+		st.File = AnalysisFileFor(lang)
+		translated, err := ParseAnalysisFile(filepath.Join(dir, st.File))
+		if err != nil {
+			return nil, err
+		}
+		sections := make(map[int]Section)
+		for _, s := range translated.Sections {
+			sections[s.Number] = s
+		}
+		for _, s := range original.Sections {
+			tr, ok := sections[s.Number]
+			if !ok {
+				st.Missing = append(st.Missing, s.Number)
+				continue
+			}
+			st.Translated++
+			if reasons := compareShapes(shapeOf(s.Body), shapeOf(tr.Body)); len(reasons) > 0 {
+				st.Outdated = append(st.Outdated, OutdatedSection{Number: s.Number, Line: tr.Line, Reasons: reasons})
+			}
+			delete(sections, s.Number)
+		}
+		for n := range sections {
+			st.Extra = append(st.Extra, n)
+		}
+		sort.Ints(st.Extra)
+		out = append(out, st)
+	}
+	return out, nil
+}
+
+// sectionShape é o que não muda ao traduzir uma seção
+type sectionShape struct {
+	headings []string // marcadores dos subtítulos (###, ####), na ordem
+	code     []codeBlock
+}
+
+type codeBlock struct {
+	lang   string
+	tokens string // para Go, os tokens sem comentários e sem o texto das strings; vazio nas demais
+}
+
+func shapeOf(body string) sectionShape {
+	var (
+		shape  sectionShape
+		block  *codeBlock
+		source strings.Builder
+	)
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case block != nil && strings.HasPrefix(trimmed, "```"):
+			if block.lang == "go" {
+				block.tokens = goTokens(source.String())
+			}
+			shape.code = append(shape.code, *block)
+			block = nil
+		case block != nil:
+			source.WriteString(line + "\n")
+		case strings.HasPrefix(trimmed, "```"):
+			block = &codeBlock{lang: strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))}
+			source.Reset()
+		case strings.HasPrefix(line, "#"):
+			marker, _, _ := strings.Cut(line, " ")
+			if strings.Trim(marker, "#") == "" {
+				shape.headings = append(shape.headings, marker)
+			}
+		}
+	}
+	return shape
+}
+
+// goTokens reduz um trecho de Go aos tokens que uma tradução não altera
+func goTokens(src string) string {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	s.Init(file, []byte(src), nil, 0) // sem ScanComments: comentários são ignorados
+	var toks []string
+	for {
+		_, tok, lit := s.Scan()
+		switch {
+		case tok == token.EOF:
+			return strings.Join(toks, " ")
+		case tok == token.SEMICOLON && lit == "\n":
+			continue
+		case tok == token.STRING || tok == token.CHAR || lit == "":
+			toks = append(toks, tok.String())
+		default:
+			toks = append(toks, lit)
+		}
+	}
+}
+
+// compareShapes explica as diferenças entre uma seção e a sua tradução
+func compareShapes(original, translated sectionShape) []string {
+	var reasons []string
+	if !slices.Equal(original.headings, translated.headings) {
+		reasons = append(reasons, fmt.Sprintf("subtítulos %s no original, %s na tradução",
+			formatHeadings(original.headings), formatHeadings(translated.headings)))
+	}
+	if len(original.code) != len(translated.code) {
+		return append(reasons, fmt.Sprintf("%d blocos de código no original, %d na tradução", len(original.code), len(translated.code)))
+	}
+	for i := range original.code {
+		if original.code[i] != translated.code[i] {
+			reasons = append(reasons, fmt.Sprintf("bloco de código %d difere do original", i+1))
+		}
+	}
+	return reasons
+}
+
+func formatHeadings(headings []string) string {
+	if len(headings) == 0 {
+		return "(nenhum)"
+	}
+	return strings.Join(headings, " ")
+}
@@ -0,0 +1,20 @@
+// Command fubango-vet executa os analisadores do FubanGo sobre pacotes Go.
+//
+// Uso:
+//
+//	go run ./cmd/fubango-vet ./...
+//
+// Também pode ser usado como ferramenta do go vet:
+//
+//	go build -o fubango-vet ./cmd/fubango-vet
+//	go vet -vettool=$(pwd)/fubango-vet ./...
+package main
+
+import (
+	"github.com/lucasrafaldini/fubango/analyzers"
+	"golang.org/x/tools/go/analysis/multichecker"
+)
+
+func main() {
+	multichecker.Main(analyzers.All()...)
+}
@@ -0,0 +1,384 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lucasrafaldini/fubango/bench"
+	"github.com/lucasrafaldini/fubango/catalog"
+	"github.com/lucasrafaldini/fubango/runner"
+)
+
+func init() {
+	register(command{
+		name:    "bench",
+		summary: "executa os benchmarks e compara as versões ruim e boa",
+		run:     runBench,
+	})
+}
+
+// benchReport é o resultado de fubango bench no formato JSON
+type benchReport struct {
+	Pairs       []benchPair        `json:"pairs"`
+	Unpaired    []bench.Summary    `json:"unpaired"`
+	Regressions []bench.Regression `json:"regressions,omitempty"`
+	Curves      []bench.Curve      `json:"curves,omitempty"`
+	Profiles    []pairProfile      `json:"profiles,omitempty"`
+}
+
+type benchPair struct {
+	bench.Pair
+	bench.Comparison
+}
+
+func runBench(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	root := fs.String("root", ".", "raiz do repositório FubanGo")
+	pattern := fs.String("bench", ".", "expressão regular passada a go test -bench")
+	benchtime := fs.String("benchtime", "", "valor de go test -benchtime (ex: 1s ou 1000x)")
+	count := fs.Int("count", 5, "número de execuções de cada benchmark, usadas nos testes de significância")
+	timeout := fs.String("timeout", "", "valor de go test -timeout")
+	input := fs.String("input", "", "lê a saída de go test -bench deste arquivo (- para stdin) em vez de executar")
+	format := fs.String("format", "text", "formato de saída: text ou json")
+	save := fs.String("save", "", "salva os resultados como baseline JSON neste arquivo")
+	baseline := fs.String("baseline", "", "compara com o baseline JSON salvo por -save")
+	threshold := fs.Float64("threshold", 0.10, "piora relativa considerada regressão (0.10 = 10%)")
+	alpha := fs.Float64("alpha", 0.05, "nível de significância do teste U de Mann-Whitney")
+	cpu := fs.String("cpu", "", `valores de GOMAXPROCS (go test -cpu), ex: 1,2,4,8; "sweep" usa potências de 2 até o número de CPUs`)
+	profileDir := fs.String("profile", "", "captura perfis de CPU, memória, mutex e bloqueio de cada par neste diretório e os compara")
+	top := fs.Int("top", 5, "número de funções mostradas em cada comparação de perfis")
+	svg := fs.String("svg", "", "salva as curvas de escalabilidade (com -cpu) neste arquivo SVG")
+	explicit := make(map[string]string)
+	fs.Func("pair", "força um par ruim=bom que as convenções de nome não reconhecem (pode repetir), ex: Communication_Mutex=Communication_Atomic", func(v string) error {
+		bad, good, ok := strings.Cut(v, "=")
+		if !ok || bad == "" || good == "" {
+			return fmt.Errorf("esperado ruim=bom, recebido %q", v)
+		}
+		explicit[strings.TrimPrefix(bad, "Benchmark")] = good
+		return nil
+	})
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Uso: fubango bench [flags] <tópico ou pacote>...")
+		fmt.Fprintln(stderr, "Tópicos podem ser dados pelo ID (03-avancado/goroutines) ou pela categoria (goroutines).")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("formato desconhecido %q", *format)
+	}
+
+	var out *bench.Output
+	var err error
+	switch {
+	case *input != "":
+		out, err = parseBenchInput(*input)
+	case fs.NArg() == 0:
+		fs.Usage()
+		return exitError(2)
+	default:
+		out, err = goTestBench(*root, fs.Args(), []string{
+			"-bench", *pattern,
+			"-benchtime", *benchtime,
+			"-count", fmt.Sprint(*count),
+			"-timeout", *timeout,
+			"-cpu", cpuList(*cpu),
+		}, stderr)
+	}
+	if err != nil {
+		return err
+	}
+	summaries := bench.Summarize(out.Results)
+
+	pairs, unpaired := bench.Pairs(summaries, explicit)
+	report := benchReport{Unpaired: unpaired}
+	for _, p := range pairs {
+		report.Pairs = append(report.Pairs, benchPair{p, p.Compare(*alpha)})
+	}
+	report.Curves = bench.Scaling(pairs)
+	if *svg != "" {
+		if err := writeBenchSVG(*svg, report.Curves); err != nil {
+			return err
+		}
+	}
+	if *profileDir != "" {
+		if *input != "" {
+			return errors.New("-profile precisa executar os benchmarks e não pode ser usado com -input")
+		}
+		report.Profiles, err = profilePairs(*root, *profileDir, pairs, *benchtime, *top, stderr)
+		if err != nil {
+			return err
+		}
+	}
+	if *baseline != "" {
+		b, err := bench.ReadBaseline(*baseline)
+		if err != nil {
+			return err
+		}
+		report.Regressions = b.Compare(summaries, *threshold, *alpha)
+	}
+	if *save != "" {
+		b := &bench.Baseline{Date: time.Now().UTC(), Config: out.Config, Benchmarks: summaries}
+		if err := b.WriteFile(*save); err != nil {
+			return err
+		}
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(report)
+	} else {
+		err = writeBenchText(stdout, report, *alpha)
+	}
+	if err != nil {
+		return err
+	}
+	if len(report.Regressions) > 0 {
+		return exitError(1)
+	}
+	return nil
+}
+
+// cpuList expande "sweep" em 1,2,4,... até runtime.NumCPU(), que é sempre
+// incluído
+func cpuList(cpu string) string {
+	if cpu != "sweep" {
+		return cpu
+	}
+	var procs []string
+	for n := 1; n < runtime.NumCPU(); n *= 2 {
+		procs = append(procs, strconv.Itoa(n))
+	}
+	return strings.Join(append(procs, strconv.Itoa(runtime.NumCPU())), ",")
+}
+
+func writeBenchSVG(name string, curves []bench.Curve) error {
+	if len(curves) == 0 {
+		return errors.New("nenhuma curva para o SVG; use -cpu com mais de um valor")
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := bench.WriteSVG(f, curves); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func parseBenchInput(name string) (*bench.Output, error) {
+	if name == "-" {
+		return bench.Parse(os.Stdin)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return bench.Parse(f)
+}
+
+// goTestBench executa go test -bench com -benchmem nos pacotes dados.
+// Flags com valor vazio são omitidas. Se go test falhar (um benchmark que
+// entra em pânico, por exemplo), os resultados obtidos até ali são usados
+// e a falha é reportada em stderr.
+func goTestBench(root string, targets, flags []string, stderr io.Writer) (*bench.Output, error) {
+	pkgs, err := benchPackages(root, targets)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"test", "-run", "^$", "-benchmem"}
+	for i := 0; i+1 < len(flags); i += 2 {
+		if flags[i+1] != "" {
+			args = append(args, flags[i], flags[i+1])
+		}
+	}
+	args = append(args, pkgs...)
+
+	var stdout bytes.Buffer
+	cmd := exec.Command("go", args...)
+	cmd.Dir = root
+	cmd.Stdout = &stdout
+	cmd.Stderr = stderr
+	runErr := cmd.Run()
+
+	out, err := bench.Parse(bytes.NewReader(stdout.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+	var exit *exec.ExitError
+	switch {
+	case runErr == nil:
+	case errors.As(runErr, &exit) && len(out.Results) > 0:
+		fmt.Fprintf(stderr, "fubango bench: go test falhou (%v); usando os %d resultados obtidos\n%s\n",
+			runErr, len(out.Results), runner.LastLines(stdout.String(), 10))
+	default:
+		return nil, fmt.Errorf("go test: %w\n%s", runErr, runner.LastLines(stdout.String(), 20))
+	}
+	return out, nil
+}
+
+// benchPackages converte tópicos do catálogo em caminhos de pacote;
+// os demais argumentos são passados ao go test como estão
+func benchPackages(root string, targets []string) ([]string, error) {
+	var c *catalog.Catalog
+	var pkgs []string
+	for _, t := range targets {
+		if strings.Contains(t, ".") { // ./exemplos/..., github.com/...
+			pkgs = append(pkgs, t)
+			continue
+		}
+		if c == nil {
+			var err error
+			if c, err = catalog.Load(root); err != nil {
+				return nil, err
+			}
+		}
+		topic := c.Topic(t)
+		if topic == nil {
+			return nil, fmt.Errorf("tópico desconhecido %q", t)
+		}
+		pkgs = append(pkgs, "./"+path.Clean(topic.Dir))
+	}
+	return pkgs, nil
+}
+
+// writeBenchText escreve a tabela de pares. O ns/op vem com o intervalo de
+// confiança de 95% e o speedup só é mostrado quando a diferença é
+// significativa; caso contrário aparece "~", como no benchstat.
+func writeBenchText(w io.Writer, r benchReport, alpha float64) error {
+	procs := make(map[int]bool)
+	for _, p := range r.Pairs {
+		procs[p.Bad.Procs] = true
+	}
+	for _, s := range r.Unpaired {
+		procs[s.Procs] = true
+	}
+	// com -cpu, os nomes levam o sufixo -GOMAXPROCS, como no go test
+	name := func(name string, p int) string {
+		if len(procs) > 1 {
+			return fmt.Sprintf("%s-%d", name, p)
+		}
+		return name
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	pkg := ""
+	for _, p := range r.Pairs {
+		if p.Package != pkg {
+			if pkg != "" {
+				fmt.Fprintln(tw)
+			}
+			pkg = p.Package
+			fmt.Fprintf(tw, "%s\n", pkg)
+			fmt.Fprintln(tw, "PAR\tns/op ruim\tns/op bom\tB/op ruim\tB/op bom\tallocs ruim\tallocs bom\tSPEEDUP\tp\tn\t")
+		}
+		speedup := "~"
+		if p.Significant {
+			speedup = fmt.Sprintf("%.2fx", p.Comparison.Speedup)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%.3f\t%d+%d\t\n", name(pairName(p.Pair), p.Bad.Procs),
+			formatCI(p.Bad), formatCI(p.Good),
+			formatMem(p.Bad, p.Bad.BytesPerOp), formatMem(p.Good, p.Good.BytesPerOp),
+			formatMem(p.Bad, p.Bad.AllocsPerOp), formatMem(p.Good, p.Good.AllocsPerOp),
+			speedup, p.P, p.Bad.Runs, p.Good.Runs)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(r.Unpaired) > 0 {
+		fmt.Fprintf(w, "\nSem par (%d):\n", len(r.Unpaired))
+		tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for _, s := range r.Unpaired {
+			fmt.Fprintf(tw, "  %s\t%s ns/op\t%s B/op\t%s allocs/op\n", name(s.Name, s.Procs),
+				formatCI(s), formatMem(s, s.BytesPerOp), formatMem(s, s.AllocsPerOp))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(r.Profiles) > 0 {
+		writeProfilesText(w, r.Profiles)
+	}
+
+	if len(r.Curves) > 0 {
+		fmt.Fprintln(w, "\nEscalabilidade por GOMAXPROCS:")
+		for _, c := range r.Curves {
+			fmt.Fprintln(w)
+			if err := c.WriteASCII(w, 40); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(r.Regressions) > 0 {
+		fmt.Fprintf(w, "\nRegressões em relação ao baseline (%d):\n", len(r.Regressions))
+		for _, reg := range r.Regressions {
+			change := "novo"
+			if reg.Old != 0 {
+				change = fmt.Sprintf("%+.1f%%", reg.Delta()*100)
+			}
+			fmt.Fprintf(w, "  %s.%s %s: %s -> %s (%s)\n", path.Base(reg.Package), reg.Name, reg.Metric,
+				formatNs(reg.Old), formatNs(reg.New), change)
+		}
+	}
+	fmt.Fprintf(w, "\n%d pares, %d sem par\n", len(r.Pairs), len(r.Unpaired))
+	if len(r.Pairs) > 0 {
+		_, err := fmt.Fprintf(w, "~: diferença não significativa (Mann-Whitney U, p >= %g); aumente -count se n for pequeno\n", alpha)
+		return err
+	}
+	return nil
+}
+
+// pairName mostra os nomes dos dois benchmarks quando o nome comum não é
+// suficiente (pares formados pela ordem de declaração)
+func pairName(p bench.Pair) string {
+	if strings.Contains(p.Name, "/") {
+		return strings.TrimPrefix(p.Bad.Name, "Benchmark") + " / " + strings.TrimPrefix(p.Good.Name, "Benchmark")
+	}
+	return p.Name
+}
+
+func formatNs(v float64) string {
+	switch {
+	case v >= 100:
+		return fmt.Sprintf("%.0f", v)
+	case v >= 10:
+		return fmt.Sprintf("%.1f", v)
+	default:
+		return fmt.Sprintf("%.2f", v)
+	}
+}
+
+// formatCI mostra o ns/op médio com o intervalo de confiança relativo
+func formatCI(s bench.Summary) string {
+	if s.Runs < 2 || s.NsPerOp == 0 {
+		return formatNs(s.NsPerOp)
+	}
+	return fmt.Sprintf("%s ±%.0f%%", formatNs(s.NsPerOp), 100*s.CI95/s.NsPerOp)
+}
+
+func formatMem(s bench.Summary, v float64) string {
+	if !s.Mem {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f", v)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBenchInput(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"bench", "-input", "testdata/bench/old.txt", "-format", "json"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("código de saída = %d\n%s", code, stderr.String())
+	}
+	var report benchReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Pairs) != 1 || report.Pairs[0].Name != "Counter" || report.Pairs[0].Comparison.Speedup != 5 {
+		t.Errorf("pares = %+v", report.Pairs)
+	}
+}
+
+func TestBenchBaseline(t *testing.T) {
+	baseline := filepath.Join(t.TempDir(), "baseline.json")
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"bench", "-input", "testdata/bench/old.txt", "-save", baseline}, &stdout, &stderr); code != 0 {
+		t.Fatalf("código de saída = %d\n%s", code, stderr.String())
+	}
+
+	stdout.Reset()
+	code := run([]string{"bench", "-input", "testdata/bench/new.txt", "-baseline", baseline}, &stdout, &stderr)
+	if code != 1 {
+		t.Fatalf("código de saída = %d, esperado 1\n%s", code, stderr.String())
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "counter.BenchmarkGoodCounter ns/op: 2000 -> 4000 (+100.0%)") {
+		t.Errorf("regressão não reportada:\n%s", out)
+	}
+	if strings.Contains(out, "counter.BenchmarkBadCounter") {
+		t.Errorf("variação de 1%% reportada como regressão:\n%s", out)
+	}
+}
+
+func TestBenchSignificance(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"bench", "-input", "testdata/bench/noisy.txt"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("código de saída = %d\n%s", code, stderr.String())
+	}
+	lines := strings.Split(stdout.String(), "\n")
+	var counter, fanOut string
+	for _, l := range lines {
+		switch fields := strings.Fields(l); {
+		case len(fields) > 0 && fields[0] == "Counter":
+			counter = l
+		case len(fields) > 0 && fields[0] == "FanOut":
+			fanOut = l
+		}
+	}
+	// 5 execuções separadas: p = 2/252
+	if !strings.Contains(counter, "4.94x") || !strings.Contains(counter, "0.008") || !strings.Contains(counter, "5+5") {
+		t.Errorf("Counter deveria ser significativo:\n%s", counter)
+	}
+	if !strings.Contains(fanOut, " ~ ") {
+		t.Errorf("FanOut não deveria ser significativo:\n%s", fanOut)
+	}
+}
+
+func TestBenchSweep(t *testing.T) {
+	svg := filepath.Join(t.TempDir(), "curvas.svg")
+	var stdout, stderr bytes.Buffer
+	args := []string{"bench", "-input", "testdata/bench/sweep.txt", "-svg", svg,
+		"-pair", "Communication_Mutex=Communication_Atomic"}
+	if code := run(args, &stdout, &stderr); code != 0 {
+		t.Fatalf("código de saída = %d\n%s", code, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"Communication_Mutex / Communication_Atomic-4",
+		"Escalabilidade por GOMAXPROCS:",
+		"a versão mais rápida muda em GOMAXPROCS=2",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("saída sem %q:\n%s", want, out)
+		}
+	}
+	data, err := os.ReadFile(svg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "<svg") {
+		t.Errorf("SVG inválido: %.40s", data)
+	}
+}
+
+func TestCPUList(t *testing.T) {
+	if got := cpuList("1,4"); got != "1,4" {
+		t.Errorf("cpuList(1,4) = %q", got)
+	}
+	want := strconv.Itoa(runtime.NumCPU())
+	if got := cpuList("sweep"); !strings.HasPrefix(got, "1") || !strings.HasSuffix(got, want) {
+		t.Errorf("cpuList(sweep) = %q", got)
+	}
+}
+
+func TestBenchProfileInput(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"bench", "-input", "testdata/bench/old.txt", "-profile", t.TempDir()}, &stdout, &stderr)
+	if code == 0 || !strings.Contains(stderr.String(), "-profile") {
+		t.Errorf("código de saída = %d\n%s", code, stderr.String())
+	}
+}
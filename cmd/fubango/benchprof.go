@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/pprof/profile"
+	"github.com/lucasrafaldini/fubango/bench"
+	"github.com/lucasrafaldini/fubango/runner"
+)
+
+// pairProfile é a comparação de perfis de um par, com o resumo em Markdown
+// salvo no diretório de -profile
+type pairProfile struct {
+	Pair    string              `json:"pair"`
+	Procs   int                 `json:"procs"`
+	Summary string              `json:"summary"`
+	Diffs   []bench.ProfileDiff `json:"diffs"`
+}
+
+// profilePairs captura os perfis das duas versões de cada par em dir e
+// compara as top funções de cada um
+func profilePairs(root, dir string, pairs []bench.Pair, benchtime string, top int, stderr io.Writer) ([]pairProfile, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	var out []pairProfile
+	for _, p := range pairs {
+		bad, err := captureProfiles(root, dir, p.Bad, benchtime, stderr)
+		if err != nil {
+			return nil, err
+		}
+		good, err := captureProfiles(root, dir, p.Good, benchtime, stderr)
+		if err != nil {
+			return nil, err
+		}
+		pp := pairProfile{Pair: pairName(p), Procs: p.Bad.Procs}
+		for _, kind := range bench.ProfileKinds {
+			d, err := bench.DiffProfiles(kind, bad[kind.Name], good[kind.Name], top)
+			if err != nil {
+				return nil, fmt.Errorf("%s (%s): %w", pp.Pair, kind.Name, err)
+			}
+			pp.Diffs = append(pp.Diffs, d)
+		}
+
+		pp.Summary = filepath.Join(dir, profileBase(p.Bad)+"_vs_"+strings.TrimPrefix(p.Good.Name, "Benchmark")+".md")
+		f, err := os.Create(pp.Summary)
+		if err != nil {
+			return nil, err
+		}
+		if err := bench.WriteMarkdown(f, p, pp.Diffs); err != nil {
+			f.Close()
+			return nil, err
+		}
+		if err := f.Close(); err != nil {
+			return nil, err
+		}
+		out = append(out, pp)
+	}
+	return out, nil
+}
+
+// profileBase é o prefixo dos arquivos de perfil de um benchmark
+func profileBase(s bench.Summary) string {
+	name := strings.ReplaceAll(strings.TrimPrefix(s.Name, "Benchmark"), "/", "_")
+	return fmt.Sprintf("%s-%d", name, s.Procs)
+}
+
+// captureProfiles roda apenas o benchmark s com todos os perfis de
+// bench.ProfileKinds ligados. Perfis que o go test não gerou ficam nil.
+func captureProfiles(root, dir string, s bench.Summary, benchtime string, stderr io.Writer) (map[string]*profile.Profile, error) {
+	base := filepath.Join(dir, profileBase(s))
+	args := []string{"test", "-run", "^$", "-bench", benchPattern(s.Name), "-cpu", strconv.Itoa(s.Procs),
+		"-memprofilerate", "1", "-o", base + ".test"}
+	if benchtime != "" {
+		args = append(args, "-benchtime", benchtime)
+	}
+	for _, kind := range bench.ProfileKinds {
+		args = append(args, kind.Flag, base+"."+kind.Name+".pprof")
+	}
+	args = append(args, s.Package)
+
+	cmd := exec.Command("go", args...)
+	cmd.Dir = root
+	cmd.Stderr = stderr
+	if out, err := cmd.Output(); err != nil {
+		return nil, fmt.Errorf("perfis de %s: %w\n%s", s.Name, err, runner.LastLines(string(out), 20))
+	}
+
+	profiles := make(map[string]*profile.Profile)
+	for _, kind := range bench.ProfileKinds {
+		p, err := bench.ReadProfile(base + "." + kind.Name + ".pprof")
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return nil, fmt.Errorf("%s: %w", s.Name, err)
+		default:
+			kind.Focus(p, s.Package)
+			profiles[kind.Name] = p
+		}
+	}
+	return profiles, nil
+}
+
+// benchPattern devolve a expressão de -bench que seleciona só o benchmark
+// name (sub-benchmarks são separados por /)
+func benchPattern(name string) string {
+	parts := strings.Split(name, "/")
+	for i, p := range parts {
+		parts[i] = "^" + regexp.QuoteMeta(p) + "$"
+	}
+	return strings.Join(parts, "/")
+}
+
+// writeProfilesText resume as comparações de perfis abaixo da tabela
+func writeProfilesText(w io.Writer, profiles []pairProfile) {
+	fmt.Fprintln(w, "\nPerfis (fração do total em cada versão, funções com maior diferença):")
+	for _, pp := range profiles {
+		fmt.Fprintf(w, "\n%s\n", pp.Pair)
+		for _, d := range pp.Diffs {
+			if d.BadTotal == 0 && d.GoodTotal == 0 {
+				continue
+			}
+			fmt.Fprintf(w, "  %s: ruim %s, bom %s\n", d.Title,
+				bench.FormatValue(d.BadTotal, d.Unit), bench.FormatValue(d.GoodTotal, d.Unit))
+			for _, f := range d.Funcs {
+				fmt.Fprintf(w, "    %5.1f%% -> %5.1f%%  %s\n", 100*f.Bad, 100*f.Good, f.Name)
+			}
+		}
+		fmt.Fprintf(w, "  resumo: %s\n", pp.Summary)
+	}
+}
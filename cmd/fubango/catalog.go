@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/lucasrafaldini/fubango/analyzers"
+	"github.com/lucasrafaldini/fubango/catalog"
+)
+
+func init() {
+	register(command{
+		name:    "catalog",
+		summary: "lista os anti-padrões documentados em exemplos/ e valida os manifestos",
+		run:     runCatalog,
+	})
+}
+
+func runCatalog(args []string, stdout, stderr io.Writer) error {
+	if len(args) > 0 && args[0] == "check" {
+		return runCatalogCheck(args[1:], stdout, stderr)
+	}
+	fs := flag.NewFlagSet("catalog", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	root := fs.String("root", ".", "raiz do repositório FubanGo")
+	level := fs.String("level", "", "filtra por nível (ex: 01-basicos ou 03)")
+	category := fs.String("category", "", "filtra por categoria (ex: goroutines)")
+	format := fs.String("format", "table", "formato de saída: table ou json")
+	lang := langFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c, err := catalog.LoadLang(*root, *lang)
+	if err != nil {
+		return err
+	}
+	c = c.Filter(*level, *category)
+
+	switch *format {
+	case "table":
+		return writeCatalogTable(stdout, c)
+	case "json":
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(c)
+	default:
+		return fmt.Errorf("formato desconhecido %q", *format)
+	}
+}
+
+// runCatalogCheck valida os manifesto.json de todos os tópicos
+func runCatalogCheck(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("catalog check", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	root := fs.String("root", ".", "raiz do repositório FubanGo")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c, err := catalog.Load(*root)
+	if err != nil {
+		return err
+	}
+	var names []string
+	for _, a := range analyzers.All() {
+		names = append(names, a.Name)
+	}
+	problems, err := c.Validate(names)
+	if err != nil {
+		return err
+	}
+	for _, p := range problems {
+		fmt.Fprintln(stdout, p)
+	}
+	if len(problems) > 0 {
+		fmt.Fprintf(stdout, "\n%d problemas nos manifestos\n", len(problems))
+		return exitError(1)
+	}
+	fmt.Fprintf(stdout, "Manifestos consistentes: %d anti-padrões em %d tópicos\n", c.Count(), len(c.Topics))
+	return nil
+}
+
+func writeCatalogTable(w io.Writer, c *catalog.Catalog) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "TÓPICO\t#\tANTI-PADRÃO\tGRAVIDADE\tRUIM\tBOM")
+	for _, t := range c.Topics {
+		for _, ap := range t.AntiPatterns {
+			severity := ap.Severity
+			if severity == "" {
+				severity = "-"
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\n", t.ID(), ap.Number, ap.Title, severity, ap.Ruim, ap.Bom)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\nTotal: %d anti-padrões em %d tópicos\n", c.Count(), len(c.Topics))
+	return err
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lucasrafaldini/fubango/catalog"
+	"github.com/lucasrafaldini/fubango/escape"
+)
+
+func init() {
+	register(command{
+		name:    "escape",
+		summary: "compara lado a lado o escape analysis e o inlining de ruim.go e bom.go",
+		run:     runEscape,
+	})
+}
+
+// escapeReport é o resultado de fubango escape no formato JSON
+type escapeReport struct {
+	Topic  string            `json:"topic"`
+	Errors map[string]string `json:"errors,omitempty"`
+	Blocks []escapeBlock     `json:"blocks"`
+}
+
+// escapeBlock compara uma declaração de ruim.go com a contraparte em
+// bom.go. Anti-padrões que apontam para as mesmas declarações são agrupados.
+type escapeBlock struct {
+	AntiPatterns []*catalog.AntiPattern `json:"antiPatterns"`
+	Ruim         escapeSide             `json:"ruim"`
+	Bom          escapeSide             `json:"bom"`
+}
+
+// escapeSide é um lado do bloco: as linhas da declaração (e, para tipos,
+// dos seus métodos) e as decisões do compilador nelas
+type escapeSide struct {
+	File      string            `json:"file"`
+	Name      string            `json:"name,omitempty"`
+	Ranges    [][2]int          `json:"ranges,omitempty"`
+	Decisions []escape.Decision `json:"decisions"`
+}
+
+func runEscape(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("escape", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	root := fs.String("root", ".", "raiz do repositório FubanGo")
+	format := fs.String("format", "text", "formato de saída: text ou json")
+	width := fs.Int("width", 72, "largura de cada coluna da listagem")
+	inline := fs.Bool("inline", true, "inclui as decisões de inlining")
+	flow := fs.Bool("flow", false, "mostra a explicação de -m=2 para cada escape")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Uso: fubango escape [flags] <tópico> [anti-padrão...]")
+		fmt.Fprintln(stderr, "Anti-padrões são dados pelo número da seção em analise.md; sem números, todos são mostrados.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("formato desconhecido %q", *format)
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitError(2)
+	}
+	numbers := make(map[int]bool)
+	for _, a := range fs.Args()[1:] {
+		n, err := strconv.Atoi(a)
+		if err != nil {
+			return fmt.Errorf("número de anti-padrão inválido: %w", err)
+		}
+		numbers[n] = true
+	}
+
+	c, err := catalog.Load(*root)
+	if err != nil {
+		return err
+	}
+	topic := c.Topic(fs.Arg(0))
+	if topic == nil {
+		return fmt.Errorf("tópico desconhecido %q", fs.Arg(0))
+	}
+	report, err := escapeTopic(*root, topic, numbers)
+	if err != nil {
+		return err
+	}
+	if !*inline || !*flow {
+		for i := range report.Blocks {
+			b := &report.Blocks[i]
+			b.Ruim.Decisions = filterDecisions(b.Ruim.Decisions, *inline, *flow)
+			b.Bom.Decisions = filterDecisions(b.Bom.Decisions, *inline, *flow)
+		}
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	return writeEscapeText(stdout, *root, topic, report, *width)
+}
+
+// escapeTopic compila o tópico e monta um bloco por par de declarações.
+// numbers restringe os anti-padrões; vazio seleciona todos que foram
+// associados a alguma declaração.
+func escapeTopic(root string, topic *catalog.Topic, numbers map[int]bool) (*escapeReport, error) {
+	dir := filepath.Join(root, topic.Dir)
+	ruim, err := catalog.ParseSourceFile(filepath.Join(dir, catalog.RuimFile))
+	if err != nil {
+		return nil, err
+	}
+	bom, err := catalog.ParseSourceFile(filepath.Join(dir, catalog.BomFile))
+	if err != nil {
+		return nil, err
+	}
+	r, err := escape.Analyze(dir, catalog.RuimFile, catalog.BomFile)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &escapeReport{Topic: topic.ID(), Errors: r.Errors}
+	index := make(map[string]int)
+	for _, ap := range topic.AntiPatterns {
+		if len(numbers) > 0 && !numbers[ap.Number] {
+			continue
+		}
+		if len(numbers) == 0 && !ap.Ruim.Found() && !ap.Bom.Found() {
+			continue
+		}
+		key := ap.Ruim.Name + "|" + ap.Bom.Name
+		if i, ok := index[key]; ok {
+			report.Blocks[i].AntiPatterns = append(report.Blocks[i].AntiPatterns, ap)
+			continue
+		}
+		index[key] = len(report.Blocks)
+		report.Blocks = append(report.Blocks, escapeBlock{
+			AntiPatterns: []*catalog.AntiPattern{ap},
+			Ruim:         newEscapeSide(r, ruim, catalog.RuimFile, ap.Ruim.Name),
+			Bom:          newEscapeSide(r, bom, catalog.BomFile, ap.Bom.Name),
+		})
+	}
+	return report, nil
+}
+
+func newEscapeSide(r *escape.Report, src *catalog.Source, file, name string) escapeSide {
+	side := escapeSide{File: file, Name: name}
+	if name == "" {
+		return side
+	}
+	side.Ranges = src.Ranges(name)
+	for _, rg := range side.Ranges {
+		side.Decisions = append(side.Decisions, r.For(file, rg[0], rg[1])...)
+	}
+	return side
+}
+
+func filterDecisions(decisions []escape.Decision, inline, flow bool) []escape.Decision {
+	var out []escape.Decision
+	for _, d := range decisions {
+		if !inline && (d.Kind == escape.KindInline || d.Kind == escape.KindNoInline) {
+			continue
+		}
+		if !flow {
+			d.Flow = nil
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+func writeEscapeText(w io.Writer, root string, topic *catalog.Topic, r *escapeReport, width int) error {
+	fmt.Fprintf(w, "%s: %s\n", r.Topic, topic.Title)
+	for _, file := range []string{catalog.RuimFile, catalog.BomFile} {
+		if msg, ok := r.Errors[file]; ok {
+			fmt.Fprintf(w, "\n%s não compila, sem decisões do compilador:\n", file)
+			for _, l := range strings.Split(msg, "\n") {
+				fmt.Fprintf(w, "  %s\n", l)
+			}
+		}
+	}
+
+	dir := filepath.Join(root, topic.Dir)
+	for _, b := range r.Blocks {
+		fmt.Fprintln(w)
+		for _, ap := range b.AntiPatterns {
+			fmt.Fprintf(w, "%d. %s\n", ap.Number, ap.Title)
+		}
+		left, err := escapeListing(dir, b.Ruim, r.Errors)
+		if err != nil {
+			return err
+		}
+		right, err := escapeListing(dir, b.Bom, r.Errors)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s | %s\n", strings.Repeat("-", width), strings.Repeat("-", width))
+		for i := 0; i < max(len(left), len(right)); i++ {
+			var l, rt string
+			if i < len(left) {
+				l = left[i]
+			}
+			if i < len(right) {
+				rt = right[i]
+			}
+			fmt.Fprintf(w, "%s | %s\n", fitColumn(l, width), strings.TrimRight(fitColumn(rt, width), " "))
+		}
+		fmt.Fprintf(w, "%s | %s\n", strings.Repeat("-", width), strings.Repeat("-", width))
+		fmt.Fprintf(w, "heap: ruim %d, bom %d   leak: ruim %d, bom %d   stack: ruim %d, bom %d\n",
+			escape.Count(b.Ruim.Decisions, escape.KindHeap), escape.Count(b.Bom.Decisions, escape.KindHeap),
+			escape.Count(b.Ruim.Decisions, escape.KindLeak), escape.Count(b.Bom.Decisions, escape.KindLeak),
+			escape.Count(b.Ruim.Decisions, escape.KindStack), escape.Count(b.Bom.Decisions, escape.KindStack))
+	}
+	return nil
+}
+
+// escapeListing devolve as linhas de um lado do bloco: o código numerado,
+// com cada decisão do compilador logo abaixo da linha a que se refere
+func escapeListing(dir string, side escapeSide, errs map[string]string) ([]string, error) {
+	if side.Name == "" {
+		return []string{side.File + ": sem declaração correspondente"}, nil
+	}
+	header := fmt.Sprintf("%s: %s", side.File, side.Name)
+	if _, ok := errs[side.File]; ok {
+		header += " (não compila)"
+	}
+	src, err := catalog.ParseSourceFile(filepath.Join(dir, side.File))
+	if err != nil {
+		return nil, err
+	}
+	byLine := make(map[int][]escape.Decision)
+	for _, d := range side.Decisions {
+		byLine[d.Line] = append(byLine[d.Line], d)
+	}
+
+	out := []string{header}
+	for i, rg := range side.Ranges {
+		if i > 0 {
+			out = append(out, "   ...")
+		}
+		for n := rg[0]; n <= rg[1] && n <= len(src.Lines); n++ {
+			out = append(out, fmt.Sprintf("%4d  %s", n, strings.ReplaceAll(src.Lines[n-1], "\t", "    ")))
+			for _, d := range byLine[n] {
+				out = append(out, fmt.Sprintf("      %-2s %-8s %s", escapeMarker(d.Kind), d.Kind, d.Message))
+				for _, f := range d.Flow {
+					out = append(out, "                  "+f)
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+// escapeMarker destaca as alocações no heap na listagem
+func escapeMarker(kind string) string {
+	switch kind {
+	case escape.KindHeap:
+		return "!!"
+	case escape.KindLeak:
+		return "!"
+	}
+	return "^"
+}
+
+// fitColumn corta ou completa s para ocupar exatamente width caracteres
+func fitColumn(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n <= width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
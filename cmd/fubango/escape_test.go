@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/escape"
+)
+
+func TestEscape(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"escape", "-root", "../..", "-format", "json", "interfaces", "7"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("código de saída = %d\n%s", code, stderr.String())
+	}
+	var report escapeReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Blocks) != 1 {
+		t.Fatalf("blocos = %+v", report.Blocks)
+	}
+	b := report.Blocks[0]
+	if b.Ruim.Name != "BadContainer" || b.Bom.Name != "Container" || len(b.Ruim.Ranges) != 3 {
+		t.Errorf("bloco = %+v", b)
+	}
+	// o interface{} de BadContainer.Store faz o valor vazar para o heap
+	var leak bool
+	for _, d := range b.Ruim.Decisions {
+		leak = leak || d.Kind == escape.KindLeak && d.Message == "leaking param: value"
+	}
+	if !leak {
+		t.Errorf("decisões de ruim.go = %+v", b.Ruim.Decisions)
+	}
+}
+
+func TestEscapeBrokenFile(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"escape", "-root", "../..", "-inline=false", "variaveis", "6"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("código de saída = %d\n%s", code, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"ruim.go não compila",
+		"declared and not used",
+		"6. Conversões Desnecessárias",
+		"bom.go: GoodVariableExample",
+		"does not escape",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("saída sem %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "can inline") {
+		t.Errorf("-inline=false mostrou decisões de inlining:\n%s", out)
+	}
+}
@@ -0,0 +1,407 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lucasrafaldini/fubango/analyzers"
+	"github.com/lucasrafaldini/fubango/bench"
+	"github.com/lucasrafaldini/fubango/catalog"
+	"github.com/lucasrafaldini/fubango/exercise"
+	"github.com/lucasrafaldini/fubango/runner"
+)
+
+func init() {
+	register(command{
+		name:    "exercise",
+		summary: "exercícios de refatoração: copia um ruim.go (start) e corrige a solução (check)",
+		run:     runExercise,
+	})
+}
+
+func runExercise(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 || (args[0] != "start" && args[0] != "check") {
+		fmt.Fprintln(stderr, "Uso: fubango exercise start [flags] <tópico>")
+		fmt.Fprintln(stderr, "     fubango exercise check [flags]")
+		return exitError(2)
+	}
+	if args[0] == "start" {
+		return runExerciseStart(args[1:], stdout, stderr)
+	}
+	return runExerciseCheck(args[1:], stdout, stderr)
+}
+
+func runExerciseStart(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("exercise start", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	root := fs.String("root", ".", "raiz do repositório FubanGo")
+	dir := fs.String("dir", "", "diretório do workspace (padrão: fubango-<categoria>)")
+	force := fs.Bool("force", false, "recomeça o exercício se o diretório já existir")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(stderr, "Uso: fubango exercise start [flags] <tópico>")
+		fs.PrintDefaults()
+		return exitError(2)
+	}
+	c, err := catalog.Load(*root)
+	if err != nil {
+		return err
+	}
+	topic := c.Topic(fs.Arg(0))
+	if topic == nil {
+		return fmt.Errorf("tópico desconhecido %q", fs.Arg(0))
+	}
+	if *dir == "" {
+		*dir = "fubango-" + topic.Category
+	}
+	w, err := exercise.Start(*root, topic, *dir, *force)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "Exercício %s criado em %s\n", w.Topic, w.Dir)
+	fmt.Fprintf(stdout, "Leia %s, refatore %s e avalie com:\n\n", exercise.InstructionsFile, catalog.RuimFile)
+	fmt.Fprintf(stdout, "  cd %s && fubango exercise check\n", w.Dir)
+	return nil
+}
+
+// exerciseOptions controla a correção
+type exerciseOptions struct {
+	race      bool
+	bench     bool
+	timeout   time.Duration
+	tolerance float64
+	benchOpts runner.BenchOptions
+}
+
+func runExerciseCheck(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("exercise check", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	dir := fs.String("dir", ".", "diretório do workspace")
+	format := fs.String("format", "text", "formato de saída: text ou json")
+	lessonsURL := fs.String("lessons-url", "", "prefixo dos links para analise.md (padrão: a raiz local do repositório)")
+	race := fs.Bool("race", true, "executa as funções com o race detector")
+	benchmarks := fs.Bool("bench", true, "compara o desempenho com a solução de referência em bom.go")
+	benchtime := fs.String("benchtime", "50ms", "valor de -benchtime de cada medição")
+	count := fs.Int("count", 5, "número de medições de cada lado")
+	tolerance := fs.Float64("tolerance", 1.5, "quantas vezes a solução pode ser mais lenta que a referência")
+	timeout := fs.Duration("timeout", 2*time.Second, "tempo máximo de cada execução")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("formato desconhecido %q", *format)
+	}
+	w, err := exercise.Open(*dir)
+	if err != nil {
+		return err
+	}
+	c, err := catalog.Load(w.Root)
+	if err != nil {
+		return err
+	}
+	topic := c.Topic(w.Topic)
+	if topic == nil {
+		return fmt.Errorf("tópico %q não existe mais em %s", w.Topic, w.Root)
+	}
+	base := *lessonsURL
+	if base == "" {
+		base = w.Root + string(filepath.Separator)
+	}
+
+	opts := exerciseOptions{
+		race:      *race,
+		bench:     *benchmarks,
+		timeout:   *timeout,
+		tolerance: *tolerance,
+		benchOpts: runner.BenchOptions{N: 100, Benchtime: *benchtime, Count: *count},
+	}
+	report, err := gradeExercise(w, topic, base, opts)
+	if err != nil {
+		return err
+	}
+	if *format == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		writeExerciseText(stdout, w, report)
+	}
+	if passed, graded := report.Score(); passed < graded {
+		return exitError(1)
+	}
+	return nil
+}
+
+// gradeExercise avalia cada anti-padrão do tópico no workspace
+func gradeExercise(w *exercise.Workspace, topic *catalog.Topic, base string, opts exerciseOptions) (*exercise.Report, error) {
+	report := &exercise.Report{Topic: topic.ID(), Title: topic.Title}
+	for _, ap := range topic.AntiPatterns {
+		report.Results = append(report.Results, exercise.NewResult(topic, ap, base))
+	}
+
+	cmd := exec.Command("go", "build", "-o", os.DevNull, ".")
+	cmd.Dir = w.Dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		msg := strings.TrimSpace(runner.LastLines(string(out), 10))
+		for _, r := range report.Results {
+			r.Add(exercise.CheckCompile, exercise.Fail, msg)
+		}
+		return report, nil
+	}
+
+	learner, err := catalog.ParseSourceFile(w.File())
+	if err != nil {
+		return nil, err
+	}
+	topicDir := filepath.Join(w.Root, topic.Dir)
+	original, err := catalog.ParseSourceFile(filepath.Join(topicDir, catalog.RuimFile))
+	if err != nil {
+		return nil, err
+	}
+	findings, err := scan(w.Dir, []string{"."}, false, io.Discard)
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := os.MkdirTemp("", "fubango-exercise")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	g := &grader{
+		w: w, topicDir: topicDir, tmp: tmp, opts: opts,
+		behavior: make(map[string]exercise.Check),
+		bench:    make(map[string]exercise.Check),
+	}
+
+	for i, ap := range topic.AntiPatterns {
+		r := report.Results[i]
+		var ranges [][2]int
+		if ap.Ruim.Found() {
+			ranges = learner.Ranges(ap.Ruim.Name)
+			if len(ranges) == 0 {
+				r.Add(exercise.CheckDecl, exercise.Fail,
+					fmt.Sprintf("%s não está mais em %s; mantenha o nome para que a correção encontre a declaração", ap.Ruim.Name, catalog.RuimFile))
+				continue
+			}
+			if declText(learner, ranges) == declText(original, original.Ranges(ap.Ruim.Name)) {
+				r.Add(exercise.CheckDecl, exercise.Fail, fmt.Sprintf("%s não foi alterada", ap.Ruim.Name))
+				continue
+			}
+		}
+		r.Checks = append(r.Checks, analyzerCheck(topic, ap, ranges, findings))
+		if !ap.Ruim.Found() || learner.Lookup(ap.Ruim.Name).Kind != catalog.KindFunc {
+			r.Add(exercise.CheckBehavior, exercise.Skip, "")
+			r.Add(exercise.CheckBenchmark, exercise.Skip, "")
+			continue
+		}
+		behavior := g.behaviorCheck(ap.Ruim.Name)
+		r.Checks = append(r.Checks, behavior)
+		if behavior.Status == exercise.Fail || !opts.bench || !ap.Bom.Found() {
+			r.Add(exercise.CheckBenchmark, exercise.Skip, "")
+			continue
+		}
+		r.Checks = append(r.Checks, g.benchCheck(ap.Ruim.Name, ap.Bom.Name))
+	}
+	return report, nil
+}
+
+// declText é o código das linhas em ranges, sem a indentação
+func declText(src *catalog.Source, ranges [][2]int) string {
+	var b strings.Builder
+	for _, rg := range ranges {
+		for n := rg[0]; n <= rg[1] && n <= len(src.Lines); n++ {
+			b.WriteString(strings.TrimSpace(src.Lines[n-1]))
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// analyzerCheck reprova o anti-padrão se algum analisador apontou um
+// problema nas linhas da sua declaração. Achados em outras declarações do
+// arquivo ficam com os seus próprios anti-padrões; só quando o anti-padrão
+// não tem declaração (ranges vazio) contam os achados que citam a sua seção
+// do analise.md. Sem achados, ele passa se algum analisador cobre a seção.
+func analyzerCheck(topic *catalog.Topic, ap *catalog.AntiPattern, ranges [][2]int, findings []finding) exercise.Check {
+	lesson := analyzers.Lesson{Topic: strings.TrimPrefix(topic.Dir, catalog.ExamplesDir+"/"), Section: ap.Number}
+	var details []string
+	for _, f := range findings {
+		hit := false
+		for _, rg := range ranges {
+			hit = hit || f.File == catalog.RuimFile && rg[0] <= f.Line && f.Line <= rg[1]
+		}
+		if len(ranges) == 0 {
+			hit = slices.Contains(f.Lessons, lesson.Link())
+		}
+		if hit {
+			details = append(details, fmt.Sprintf("%s:%d: %s (%s)", f.File, f.Line, f.Message, f.Analyzer))
+		}
+	}
+	if len(details) > 0 {
+		return exercise.Check{Name: exercise.CheckAnalyzers, Status: exercise.Fail, Detail: strings.Join(details, "\n")}
+	}
+	for _, a := range analyzers.All() {
+		for _, l := range analyzers.Describe(a).Lessons {
+			if l == lesson {
+				return exercise.Check{Name: exercise.CheckAnalyzers, Status: exercise.Pass}
+			}
+		}
+	}
+	return exercise.Check{Name: exercise.CheckAnalyzers, Status: exercise.Skip}
+}
+
+// grader guarda as execuções já feitas: vários anti-padrões podem apontar
+// para a mesma função
+type grader struct {
+	w        *exercise.Workspace
+	topicDir string
+	tmp      string
+	opts     exerciseOptions
+	behavior map[string]exercise.Check
+	bench    map[string]exercise.Check
+}
+
+// behaviorCheck executa a função do aluno com fubango run. Ela só é
+// reprovada por problemas que a versão original também tem, ou seja, pelo
+// anti-padrão que o exercício pede para corrigir; uma função original sem
+// problemas observáveis não é avaliada por execução.
+func (g *grader) behaviorCheck(name string) exercise.Check {
+	if c, ok := g.behavior[name]; ok {
+		return c
+	}
+	c := g.runBehavior(name)
+	c.Name = exercise.CheckBehavior
+	g.behavior[name] = c
+	return c
+}
+
+func (g *grader) runBehavior(name string) exercise.Check {
+	opts := runner.Options{Timeout: g.opts.timeout, Settle: 100 * time.Millisecond, N: 100, Race: g.opts.race}
+	orig, err := g.run(g.topicDir, "original", name, catalog.RuimFile, opts)
+	if err != nil || len(orig.Problems()) == 0 {
+		return exercise.Check{Status: exercise.Skip}
+	}
+	mine, err := g.run(g.w.Dir, "aluno", name, catalog.RuimFile, opts)
+	if err != nil {
+		return exercise.Check{Status: exercise.Fail, Detail: err.Error()}
+	}
+	if problems := mine.Problems(); len(problems) > 0 {
+		return exercise.Check{Status: exercise.Fail, Detail: strings.Join(problems, "\n")}
+	}
+	return exercise.Check{Status: exercise.Pass, Detail: "no original: " + strings.Join(orig.Problems(), "; ")}
+}
+
+func (g *grader) run(dir, side, name, file string, opts runner.Options) (*runner.Outcome, error) {
+	fn, err := runner.Find(dir, name, file)
+	if err != nil {
+		return nil, err
+	}
+	tmp := filepath.Join(g.tmp, side)
+	if err := os.MkdirAll(tmp, 0o755); err != nil {
+		return nil, err
+	}
+	return runner.Run(dir, tmp, fn, opts)
+}
+
+// benchCheck mede a função do aluno e a sua contraparte em bom.go. A
+// solução é reprovada se for significativamente mais lenta que
+// opts.tolerance vezes a referência.
+func (g *grader) benchCheck(name, ref string) exercise.Check {
+	key := name + "|" + ref
+	if c, ok := g.bench[key]; ok {
+		return c
+	}
+	c := g.runBench(name, ref)
+	c.Name = exercise.CheckBenchmark
+	g.bench[key] = c
+	return c
+}
+
+func (g *grader) runBench(name, ref string) exercise.Check {
+	good, err := g.measure(g.topicDir, "referencia", ref, catalog.BomFile)
+	if err != nil {
+		return exercise.Check{Status: exercise.Skip, Detail: "referência não pôde ser medida: " + firstLine(err.Error())}
+	}
+	mine, err := g.measure(g.w.Dir, "aluno", name, catalog.RuimFile)
+	if err != nil {
+		return exercise.Check{Status: exercise.Fail, Detail: firstLine(err.Error())}
+	}
+	p := bench.Pair{Bad: mine, Good: good}
+	cmp := p.Compare(0.05)
+	detail := fmt.Sprintf("%s/op contra %s/op de %s", bench.FormatValue(int64(mine.NsPerOp), "nanoseconds"),
+		bench.FormatValue(int64(good.NsPerOp), "nanoseconds"), ref)
+	if cmp.Speedup > g.opts.tolerance && cmp.Significant {
+		return exercise.Check{Status: exercise.Fail, Detail: fmt.Sprintf("%s (%.1fx mais lenta)", detail, cmp.Speedup)}
+	}
+	return exercise.Check{Status: exercise.Pass, Detail: detail}
+}
+
+func (g *grader) measure(dir, side, name, file string) (bench.Summary, error) {
+	fn, err := runner.Find(dir, name, file)
+	if err != nil {
+		return bench.Summary{}, err
+	}
+	tmp := filepath.Join(g.tmp, side)
+	if err := os.MkdirAll(tmp, 0o755); err != nil {
+		return bench.Summary{}, err
+	}
+	return runner.Bench(dir, tmp, fn, g.opts.benchOpts)
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+func writeExerciseText(w io.Writer, ws *exercise.Workspace, r *exercise.Report) {
+	fmt.Fprintf(w, "Exercício %s: %s\n", r.Topic, r.Title)
+	for _, res := range r.Results {
+		status := "não avaliado"
+		switch {
+		case res.Passed():
+			status = "ok"
+		case res.Graded():
+			status = "FALHOU"
+		}
+		fmt.Fprintf(w, "\n%2d. %s", res.Number, res.Title)
+		if res.Symbol != "" {
+			fmt.Fprintf(w, " (%s)", res.Symbol)
+		}
+		fmt.Fprintf(w, ": %s\n", status)
+		for _, c := range res.Checks {
+			if c.Status == exercise.Skip {
+				continue
+			}
+			fmt.Fprintf(w, "    %-13s %s", c.Name, c.Status)
+			lines := strings.Split(c.Detail, "\n")
+			if c.Detail != "" && (c.Status == exercise.Fail || len(lines) == 1) {
+				fmt.Fprintf(w, ": %s", lines[0])
+				for _, l := range lines[1:] {
+					fmt.Fprintf(w, "\n    %-13s   %s", "", l)
+				}
+			}
+			fmt.Fprintln(w)
+		}
+		if res.Graded() && !res.Passed() {
+			fmt.Fprintf(w, "    leia %s\n", res.Link)
+		}
+	}
+	passed, graded := r.Score()
+	fmt.Fprintf(w, "\n%d de %d anti-padrões corrigidos", passed, graded)
+	if n := len(r.Results) - graded; n > 0 {
+		fmt.Fprintf(w, " (%d sem verificação automática)", n)
+	}
+	fmt.Fprintf(w, "; workspace %s\n", ws.Dir)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/analyzers"
+	"github.com/lucasrafaldini/fubango/catalog"
+	"github.com/lucasrafaldini/fubango/exercise"
+)
+
+func TestExercise(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "goroutines")
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"exercise", "start", "-root", "../..", "-dir", dir, "goroutines"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("start: código de saída = %d\n%s", code, stderr.String())
+	}
+
+	// corrige só o deadlock (anti-padrão 9)
+	file := filepath.Join(dir, "ruim.go")
+	src, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixed := strings.Replace(string(src), "ch1 := make(chan int)\n\tch2 := make(chan int)", "ch1 := make(chan int, 1)\n\tch2 := make(chan int, 1)", 1)
+	if fixed == string(src) {
+		t.Fatal("DeadlockWithChannels mudou em ruim.go")
+	}
+	if err := os.WriteFile(file, []byte(fixed), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout.Reset()
+	code := run([]string{"exercise", "check", "-dir", dir, "-format", "json", "-race=false", "-count", "2", "-benchtime", "100x"}, &stdout, &stderr)
+	if code != 1 {
+		t.Fatalf("check: código de saída = %d, esperava 1 (exercício incompleto)\n%s", code, stderr.String())
+	}
+	var report exercise.Report
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	byNumber := make(map[int]*exercise.Result)
+	for _, r := range report.Results {
+		byNumber[r.Number] = r
+	}
+	if r := byNumber[9]; r == nil || !r.Passed() {
+		t.Errorf("anti-padrão 9 = %+v", r)
+	}
+	if r := byNumber[1]; r == nil || r.Passed() || !strings.HasSuffix(r.Link, "goroutines/analise.md#L5") {
+		t.Errorf("anti-padrão 1 = %+v", r)
+	}
+}
+
+func TestAnalyzerCheckRanges(t *testing.T) {
+	c, err := catalog.Load("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	topic := c.Topic("database")
+	src, err := catalog.ParseSourceFile(filepath.Join("../..", topic.Dir, catalog.RuimFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	injection, prepared := topic.AntiPatterns[1], topic.AntiPatterns[5]
+	if injection.Ruim.Name != "SQLInjectionVulnerable" || prepared.Ruim.Name != "NoPreparedStatements" {
+		t.Fatalf("database #2 = %s, #6 = %s", injection.Ruim.Name, prepared.Ruim.Name)
+	}
+
+	// o sqlconcat cita as seções 2 e 6; o achado fica em NoPreparedStatements
+	line := src.Ranges(prepared.Ruim.Name)[0][0] + 1
+	lessons := []string{
+		analyzers.Lesson{Topic: "04-casos-reais/database", Section: 2}.Link(),
+		analyzers.Lesson{Topic: "04-casos-reais/database", Section: 6}.Link(),
+	}
+	findings := []finding{{Analyzer: "sqlconcat", File: catalog.RuimFile, Line: line, Lessons: lessons}}
+
+	if check := analyzerCheck(topic, injection, src.Ranges(injection.Ruim.Name), findings); check.Status != exercise.Pass {
+		t.Errorf("#2 = %+v, esperado pass: o achado é de outra declaração", check)
+	}
+	if check := analyzerCheck(topic, prepared, src.Ranges(prepared.Ruim.Name), findings); check.Status != exercise.Fail {
+		t.Errorf("#6 = %+v, esperado fail", check)
+	}
+}
+
+func TestExerciseCheckNoWorkspace(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"exercise", "check", "-dir", t.TempDir()}, &stdout, &stderr)
+	if code != 1 || !strings.Contains(stderr.String(), "não é um workspace") {
+		t.Errorf("código de saída = %d\n%s", code, stderr.String())
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/lucasrafaldini/fubango/site"
+)
+
+func init() {
+	register(command{
+		name:    "export",
+		summary: "exporta o catálogo como site estático (site), JSON (json) ou livro EPUB (epub)",
+		run:     runExport,
+	})
+}
+
+func runExport(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 || (args[0] != "site" && args[0] != "json" && args[0] != "epub") {
+		fmt.Fprintln(stderr, "Uso: fubango export site|json|epub [-root dir] [-results glob] [-lang en] destino")
+		return exitError(2)
+	}
+	format := args[0]
+	fs := flag.NewFlagSet("export "+format, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	root := fs.String("root", ".", "raiz do repositório FubanGo")
+	results := resultsFlag(fs)
+	lang := langFlag(fs)
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	dest := fs.Arg(0)
+	switch {
+	case fs.NArg() > 1:
+		return fmt.Errorf("argumentos inesperados: %v", fs.Args()[1:])
+	case dest == "" && format == "json":
+		dest = "-"
+	case dest == "":
+		fmt.Fprintf(stderr, "Uso: fubango export %s [-root dir] [-results glob] [-lang en] destino\n", format)
+		return exitError(2)
+	}
+
+	s := &site.Site{Root: *root, Results: *results, Lang: *lang}
+	switch format {
+	case "site":
+		files, err := s.Export(dest)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "%d arquivos gravados em %s; abra %s\n", len(files), dest, filepath.Join(dest, "index.html"))
+		return nil
+	case "json":
+		if dest == "-" {
+			return s.WriteJSON(stdout)
+		}
+		return writeFile(dest, s.WriteJSON)
+	default:
+		if err := writeFile(dest, s.WriteEPUB); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "Livro gravado em %s\n", dest)
+		return nil
+	}
+}
+
+// writeFile grava name com write, sem deixar um arquivo pela metade se
+// write ou Close falharem. Falhas da limpeza vão junto com o erro original.
+func writeFile(name string, write func(io.Writer) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		return errors.Join(err, f.Close(), os.Remove(name))
+	}
+	if err := f.Close(); err != nil {
+		return errors.Join(err, os.Remove(name))
+	}
+	return nil
+}
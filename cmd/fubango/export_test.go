@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/site"
+)
+
+func TestExportJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"export", "json", "-root", "../.."}, &stdout, &stderr); code != 0 {
+		t.Fatalf("código de saída = %d\n%s", code, stderr.String())
+	}
+	var dump site.Dump
+	if err := json.Unmarshal(stdout.Bytes(), &dump); err != nil {
+		t.Fatal(err)
+	}
+	topics := 0
+	for _, l := range dump.Levels {
+		topics += len(l.Topics)
+	}
+	if len(dump.Levels) != 4 || topics != 12 {
+		t.Errorf("%d níveis e %d tópicos, esperados 4 e 12", len(dump.Levels), topics)
+	}
+}
+
+func TestExportUsage(t *testing.T) {
+	for _, args := range [][]string{{"export"}, {"export", "pdf", "livro.pdf"}, {"export", "epub"}} {
+		var stdout, stderr bytes.Buffer
+		if code := run(args, &stdout, &stderr); code != 2 {
+			t.Errorf("%v: código de saída = %d, esperado 2", args, code)
+		}
+	}
+}
+
+func TestWriteFileRemovesPartial(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "livro.epub")
+	failed := errors.New("falhou")
+	err := writeFile(name, func(w io.Writer) error {
+		io.WriteString(w, "pela metade")
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("writeFile = %v, esperado %v", err, failed)
+	}
+	if _, err := os.Stat(name); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("arquivo pela metade ficou no disco: %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/lucasrafaldini/fubango/catalog"
+)
+
+func init() {
+	register(command{
+		name:    "i18n",
+		summary: "mostra as seções faltando ou desatualizadas em cada tradução do analise.md",
+		run:     runI18n,
+	})
+}
+
+// langFlag registra -lang, a língua do analise.md mostrada pelo comando
+func langFlag(fs *flag.FlagSet) *string {
+	return fs.String("lang", "", "língua do analise.md (ex: en, es, lido de analise.<língua>.md); sem tradução, fica em pt-BR")
+}
+
+func runI18n(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 || args[0] != "status" {
+		fmt.Fprintln(stderr, "Uso: fubango i18n status [-root dir] [-lang en] [-check]")
+		return exitError(2)
+	}
+	fs := flag.NewFlagSet("i18n status", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	root := fs.String("root", ".", "raiz do repositório FubanGo")
+	lang := fs.String("lang", "", "só esta tradução (padrão: todas as encontradas)")
+	check := fs.Bool("check", false, "sai com código 1 se alguma tradução estiver incompleta ou desatualizada")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	c, err := catalog.Load(*root)
+	if err != nil {
+		return err
+	}
+	langs := c.Langs()
+	if *lang != "" {
+		l, err := catalog.ParseLang(*lang)
+		if err != nil {
+			return err
+		}
+		if l == catalog.DefaultLang {
+			return fmt.Errorf("%s é a língua original do analise.md", catalog.DefaultLang)
+		}
+		langs = []string{l}
+	}
+	if len(langs) == 0 {
+		fmt.Fprintln(stdout, "Nenhuma tradução encontrada. Traduções ficam ao lado do analise.md, como analise.en.md e analise.es.md.")
+		return nil
+	}
+
+	incomplete := false
+	for i, l := range langs {
+		if i > 0 {
+			fmt.Fprintln(stdout)
+		}
+		status, err := c.TranslationStatus(l)
+		if err != nil {
+			return err
+		}
+		if err := writeTranslationStatus(stdout, l, status); err != nil {
+			return err
+		}
+		for _, st := range status {
+			incomplete = incomplete || !st.Complete()
+		}
+	}
+	if *check && incomplete {
+		return exitError(1)
+	}
+	return nil
+}
+
+func writeTranslationStatus(w io.Writer, lang string, status []catalog.TranslationStatus) error {
+	var sections, translated, topics, outdated int
+	for _, st := range status {
+		sections += st.Sections
+		translated += st.Translated
+		outdated += len(st.Outdated)
+		if st.File != "" {
+			topics++
+		}
+	}
+	fmt.Fprintf(w, "%s (%s): %d de %d seções traduzidas em %d de %d tópicos, %d desatualizadas\n\n",
+		lang, catalog.AnalysisFileFor(lang), translated, sections, topics, len(status), outdated)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, st := range status {
+		switch {
+		case st.File == "":
+			fmt.Fprintf(tw, "  %s\tsem tradução\n", st.Topic.ID())
+			continue
+		case st.Complete():
+			fmt.Fprintf(tw, "  %s\tcompleta\n", st.Topic.ID())
+			continue
+		}
+		fmt.Fprintf(tw, "  %s\t%d de %d seções\n", st.Topic.ID(), st.Translated, st.Sections)
+		if len(st.Missing) > 0 {
+			fmt.Fprintf(tw, "  \tfaltam: %s\n", joinNumbers(st.Missing))
+		}
+		if len(st.Extra) > 0 {
+			fmt.Fprintf(tw, "  \tnão existem no original: %s\n", joinNumbers(st.Extra))
+		}
+		for _, o := range st.Outdated {
+			fmt.Fprintf(tw, "  \t#%d desatualizada (%s/%s:%d): %s\n", o.Number, st.Topic.Dir, st.File, o.Line, strings.Join(o.Reasons, "; "))
+		}
+	}
+	return tw.Flush()
+}
+
+func joinNumbers(numbers []int) string {
+	s := make([]string, len(numbers))
+	for i, n := range numbers {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestI18nStatus(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"i18n", "status", "-root", "testdata/i18n", "-check"}, &stdout, &stderr)
+	if code != 1 {
+		t.Fatalf("código de saída = %d, esperado 1\n%s", code, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"en (analise.en.md): 2 de 3 seções traduzidas em 1 de 1 tópicos, 1 desatualizadas",
+		"01-basicos/demo  2 de 3 seções",
+		"faltam: 3",
+		"#2 desatualizada (exemplos/01-basicos/demo/analise.en.md:10): bloco de código 1 difere do original",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("saída sem %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "#1 desatualizada") {
+		t.Errorf("comentário traduzido contou como mudança:\n%s", out)
+	}
+}
+
+func TestCatalogLang(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"catalog", "-root", "testdata/i18n", "-lang", "en"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("código de saída = %d\n%s", code, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{"First", "Second", "Terceiro"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("tabela sem %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/lucasrafaldini/fubango/catalog"
+)
+
+func init() {
+	register(command{
+		name:    "index",
+		summary: "verifica (check) ou regenera (write) o índice do README e o ROADMAP",
+		run:     runIndex,
+	})
+}
+
+// Arquivos mantidos por fubango index, relativos à raiz
+const (
+	readmeFile  = "README.md"
+	roadmapFile = "ROADMAP.md"
+)
+
+func runIndex(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 || (args[0] != "check" && args[0] != "write") {
+		fmt.Fprintln(stderr, "Uso: fubango index check|write [-root dir]")
+		return exitError(2)
+	}
+	sub := args[0]
+	fs := flag.NewFlagSet("index "+sub, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	root := fs.String("root", ".", "raiz do repositório FubanGo")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	c, err := catalog.Load(*root)
+	if err != nil {
+		return err
+	}
+	if sub == "write" {
+		return writeIndexFiles(c, stdout)
+	}
+	files, err := renderIndexFiles(c)
+	if err != nil {
+		return err
+	}
+
+	stale := 0
+	problems, err := c.CheckLinks(readmeFile)
+	if err != nil {
+		return err
+	}
+	for _, p := range problems {
+		fmt.Fprintln(stdout, p)
+		stale++
+	}
+	for _, f := range files {
+		if !bytes.Equal(f.old, f.new) {
+			fmt.Fprintf(stdout, "%s: desatualizado em relação a exemplos/\n", f.name)
+			stale++
+		}
+	}
+	if stale > 0 {
+		fmt.Fprintf(stdout, "\n%d problemas; execute \"fubango index write\" para regenerar\n", stale)
+		return exitError(1)
+	}
+	fmt.Fprintf(stdout, "Índice consistente: %d anti-padrões em %d tópicos\n", c.Count(), len(c.Topics))
+	return nil
+}
+
+// indexFile é um arquivo mantido por fubango index, antes e depois da
+// regeneração
+type indexFile struct {
+	name     string
+	old, new []byte
+}
+
+// renderIndexFiles regenera o índice do README e as estatísticas do
+// ROADMAP. O ROADMAP é opcional.
+func renderIndexFiles(c *catalog.Catalog) ([]indexFile, error) {
+	readme, err := os.ReadFile(filepath.Join(c.Root, readmeFile))
+	if err != nil {
+		return nil, err
+	}
+	labels := catalog.ParseIndexLabels(readme)
+	out, err := catalog.ReplaceSection(readme, catalog.IndexHeading, c.RenderIndex(labels))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", readmeFile, err)
+	}
+	files := []indexFile{{readmeFile, readme, out}}
+
+	roadmap, err := os.ReadFile(filepath.Join(c.Root, roadmapFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		files = append(files, indexFile{roadmapFile, roadmap, c.UpdateRoadmap(roadmap, labels)})
+	}
+	return files, nil
+}
@@ -0,0 +1,81 @@
+// Command fubango reúne as ferramentas do repositório FubanGo.
+//
+// Uso:
+//
+//	fubango <comando> [flags]
+//
+// Execute "fubango help" para ver a lista de comandos.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// command descreve um subcomando da CLI
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) error
+}
+
+var commands = map[string]command{}
+
+func register(c command) {
+	commands[c.name] = c
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stdout)
+		return 0
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "fubango: comando desconhecido %q\n\n", args[0])
+		usage(stderr)
+		return 2
+	}
+	if err := cmd.run(args[1:], stdout, stderr); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			// -h já imprimiu o uso do comando
+			return 0
+		}
+		if code, ok := err.(exitError); ok {
+			return int(code)
+		}
+		fmt.Fprintf(stderr, "fubango %s: %v\n", cmd.name, err)
+		return 1
+	}
+	return 0
+}
+
+// exitError permite que um comando encerre com um código específico
+// sem imprimir mensagem de erro.
+type exitError int
+
+func (e exitError) Error() string {
+	return fmt.Sprintf("código de saída %d", int(e))
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Uso: fubango <comando> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Comandos:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].summary)
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunHelp(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"catalog", "-h"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("código de saída = %d, esperado 0\n%s", code, stderr.String())
+	}
+	if strings.Contains(stderr.String(), "fubango catalog:") {
+		t.Errorf("-h não deveria imprimir erro:\n%s", stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "-format") {
+		t.Errorf("-h deveria listar as flags do comando:\n%s", stderr.String())
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/lucasrafaldini/fubango/catalog"
+	"github.com/lucasrafaldini/fubango/scaffold"
+)
+
+func init() {
+	register(command{
+		name:    "new",
+		summary: "cria um tópico (new nível/categoria) ou acrescenta um anti-padrão (new pattern)",
+		run:     runNew,
+	})
+}
+
+func runNew(args []string, stdout, stderr io.Writer) error {
+	if len(args) > 0 && args[0] == "pattern" {
+		return runNewPattern(args[1:], stdout, stderr)
+	}
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	root := fs.String("root", ".", "raiz do repositório FubanGo")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(stderr, "Uso: fubango new [-root dir] <nível>/<categoria>   (ex: 03-avancado/generics)")
+		fmt.Fprintln(stderr, "     fubango new pattern [-root dir] [-ruim Func] [-bom Func] [-severity warning] [-tags a,b] <tópico> <título>")
+		return exitError(2)
+	}
+	level, category, ok := strings.Cut(strings.Trim(path.Clean(filepath.ToSlash(fs.Arg(0))), "/"), "/")
+	if !ok {
+		return fmt.Errorf("%q: use <nível>/<categoria>, como 03-avancado/generics", fs.Arg(0))
+	}
+
+	readme, err := os.ReadFile(filepath.Join(*root, readmeFile))
+	if err != nil {
+		return err
+	}
+	labels := catalog.ParseIndexLabels(readme)
+	dir := path.Join(catalog.ExamplesDir, level, category)
+	name := labels.Topic(&catalog.Topic{Dir: dir, Level: level, Category: category})
+	created, err := scaffold.NewTopic(*root, level, category, name)
+	if err != nil {
+		return err
+	}
+	for _, f := range created {
+		fmt.Fprintf(stdout, "criado %s\n", f)
+	}
+
+	c, err := catalog.Load(*root)
+	if err != nil {
+		return err
+	}
+	t := c.Topic(dir)
+	if t == nil {
+		return fmt.Errorf("%s não aparece no catálogo", dir)
+	}
+	// o ROADMAP só tem os tópicos listados; o novo entra antes de atualizar
+	// as estatísticas
+	roadmapPath := filepath.Join(*root, roadmapFile)
+	roadmap, err := os.ReadFile(roadmapPath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return err
+	default:
+		if err := os.WriteFile(roadmapPath, c.AddRoadmapTopic(roadmap, labels, t), 0o644); err != nil {
+			return err
+		}
+	}
+	if err := writeIndexFiles(c, stdout); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "\nPróximo passo: fubango new pattern %s \"Título do anti-padrão\"\n", t.ID())
+	return nil
+}
+
+func runNewPattern(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("new pattern", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	root := fs.String("root", ".", "raiz do repositório FubanGo")
+	ruim := fs.String("ruim", "", "função em ruim.go (padrão: Bad + título)")
+	bom := fs.String("bom", "", "função em bom.go (padrão: Good + título)")
+	severity := fs.String("severity", "warning", "gravidade no manifesto: "+strings.Join(catalog.Severities, ", "))
+	tags := fs.String("tags", "", "tags do manifesto separadas por vírgula (padrão: a categoria)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 {
+		fmt.Fprintln(stderr, "Uso: fubango new pattern [-root dir] [-ruim Func] [-bom Func] [-severity warning] [-tags a,b] <tópico> <título>")
+		return exitError(2)
+	}
+
+	c, err := catalog.Load(*root)
+	if err != nil {
+		return err
+	}
+	t := c.Topic(fs.Arg(0))
+	if t == nil {
+		return fmt.Errorf("tópico desconhecido %q", fs.Arg(0))
+	}
+	p := scaffold.Pattern{
+		Title:    strings.Join(fs.Args()[1:], " "),
+		Ruim:     *ruim,
+		Bom:      *bom,
+		Severity: *severity,
+	}
+	for _, tag := range strings.Split(*tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			p.Tags = append(p.Tags, tag)
+		}
+	}
+	number, changed, err := scaffold.AddPattern(*root, t, p)
+	if err != nil {
+		return err
+	}
+	for _, f := range changed {
+		fmt.Fprintf(stdout, "%s atualizado\n", f)
+	}
+
+	if c, err = catalog.Load(*root); err != nil {
+		return err
+	}
+	if err := writeIndexFiles(c, stdout); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "\nAnti-padrão %d criado; preencha os TODOs em %s\n", number, t.Dir)
+	return nil
+}
+
+// writeIndexFiles regenera o índice do README e o ROADMAP, como fubango
+// index write
+func writeIndexFiles(c *catalog.Catalog, stdout io.Writer) error {
+	files, err := renderIndexFiles(c)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if bytes.Equal(f.old, f.new) {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(c.Root, f.name), f.new, 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "%s atualizado\n", f.name)
+	}
+	return nil
+}
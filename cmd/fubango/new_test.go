@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	root := t.TempDir()
+	readme := "# FubanGo\n\n## Índice de Anti-Padrões Documentados\n\n### 📁 03-Avançado\n\n---\n\n**Total: 0 anti-padrões documentados** 🚫\n\n## Contribuindo\n"
+	roadmap := "#### Avançado\n<!-- fubango index: início -->\n- [x] **Context** (5 anti-padrões documentados)\n\n- **0 anti-padrões** documentados\n<!-- fubango index: fim -->\n"
+	for name, content := range map[string]string{readmeFile: readme, roadmapFile: roadmap} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Context já está no ROADMAP; Generics entra logo depois dele
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"new", "-root", root, "03-avancado/context"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("new: código de saída = %d\n%s", code, stderr.String())
+	}
+	stdout.Reset()
+	if code := run([]string{"new", "-root", root, "03-avancado/generics"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("new: código de saída = %d\n%s", code, stderr.String())
+	}
+	for _, want := range []string{"criado exemplos/03-avancado/generics/ruim.go", "criado exemplos/03-avancado/generics/manifesto.json", "README.md atualizado"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("saída sem %q:\n%s", want, stdout.String())
+		}
+	}
+	if code := run([]string{"new", "-root", root, "03-avancado/generics"}, &stdout, &stderr); code == 0 {
+		t.Error("new recriou um tópico existente")
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	if code := run([]string{"new", "pattern", "-root", root, "-severity", "error", "generics", "Restrições", "amplas", "demais"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("new pattern: código de saída = %d\n%s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Anti-padrão 1 criado") {
+		t.Errorf("saída de new pattern:\n%s", stdout.String())
+	}
+
+	got, err := os.ReadFile(filepath.Join(root, readmeFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"#### [Generics](exemplos/03-avancado/generics)\n1. [Restrições amplas demais](exemplos/03-avancado/generics/analise.md#L5) - `ruim.go:7`",
+		"**Total: 1 anti-padrões documentados**",
+	} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("README sem %q:\n%s", want, got)
+		}
+	}
+	got, err = os.ReadFile(filepath.Join(root, roadmapFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "- [x] **Context** (0 anti-padrões documentados)\n- [ ] **Generics** (1 anti-padrões documentados)\n  - 2 benchmarks\n"; !strings.Contains(string(got), want) {
+		t.Errorf("ROADMAP sem %q:\n%s", want, got)
+	}
+
+	stdout.Reset()
+	if code := run([]string{"catalog", "check", "-root", root}, &stdout, &stderr); code != 0 {
+		t.Errorf("catalog check: código de saída = %d\n%s%s", code, stdout.String(), stderr.String())
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lucasrafaldini/fubango/analyzers"
+)
+
+// scanWriters são os formatos de saída de fubango scan. lessonsURL é o
+// prefixo dos links para as lições, já aplicado aos achados.
+var scanWriters = map[string]func(w io.Writer, findings []finding, lessonsURL string) error{
+	"text":  writeScanText,
+	"json":  writeScanJSON,
+	"sarif": writeScanSARIF,
+}
+
+// group reúne os achados de uma categoria
+type group struct {
+	Category string    `json:"category"`
+	Topic    string    `json:"topic"`
+	Findings []finding `json:"findings"`
+}
+
+// groupFindings agrupa achados já ordenados por tópico
+func groupFindings(findings []finding) []group {
+	var groups []group
+	for _, f := range findings {
+		if n := len(groups); n > 0 && groups[n-1].Topic == f.Topic {
+			groups[n-1].Findings = append(groups[n-1].Findings, f)
+			continue
+		}
+		groups = append(groups, group{Category: f.Category, Topic: f.Topic, Findings: []finding{f}})
+	}
+	return groups
+}
+
+func writeScanText(w io.Writer, findings []finding, _ string) error {
+	if len(findings) == 0 {
+		_, err := fmt.Fprintln(w, "Nenhum anti-padrão encontrado.")
+		return err
+	}
+	count := make(map[string]int)
+	for _, g := range groupFindings(findings) {
+		name := g.Topic
+		if name == "" {
+			name = "outros"
+		}
+		fmt.Fprintf(w, "== %s (%d) ==\n\n", name, len(g.Findings))
+		for _, f := range g.Findings {
+			count[f.Severity]++
+			fmt.Fprintf(w, "%s:%d:%d: %s [%s] %s\n", f.File, f.Line, f.Column, f.Severity, f.Analyzer, f.Message)
+			for _, line := range strings.Split(f.Code, "\n") {
+				fmt.Fprintf(w, "    | %s\n", line)
+			}
+			if len(f.Lessons) > 0 {
+				fmt.Fprintf(w, "    lição: %s\n", strings.Join(f.Lessons, ", "))
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	_, err := fmt.Fprintf(w, "Total: %d achados (%d error, %d warning, %d note)\n",
+		len(findings), count["error"], count["warning"], count["note"])
+	return err
+}
+
+func writeScanJSON(w io.Writer, findings []finding, _ string) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(struct {
+		Total      int     `json:"total"`
+		Categories []group `json:"categories"`
+	}{len(findings), groupFindings(findings)})
+}
+
+// Subconjunto do SARIF 2.1.0 usado pelo relatório
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID                   string        `json:"id"`
+		ShortDescription     sarifText     `json:"shortDescription"`
+		HelpURI              string        `json:"helpUri,omitempty"`
+		DefaultConfiguration sarifLevel    `json:"defaultConfiguration"`
+		Properties           sarifRuleTags `json:"properties"`
+	}
+	sarifRuleTags struct {
+		Tags []string `json:"tags,omitempty"`
+	}
+	sarifLevel struct {
+		Level string `json:"level"`
+	}
+	sarifText struct {
+		Text string `json:"text"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		RuleIndex int             `json:"ruleIndex"`
+		Level     string          `json:"level"`
+		Message   sarifText       `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysical `json:"physicalLocation"`
+	}
+	sarifPhysical struct {
+		ArtifactLocation sarifArtifact `json:"artifactLocation"`
+		Region           sarifRegion   `json:"region"`
+	}
+	sarifArtifact struct {
+		URI string `json:"uri"`
+	}
+	sarifRegion struct {
+		StartLine   int        `json:"startLine"`
+		StartColumn int        `json:"startColumn"`
+		EndLine     int        `json:"endLine,omitempty"`
+		EndColumn   int        `json:"endColumn,omitempty"`
+		Snippet     *sarifText `json:"snippet,omitempty"`
+	}
+)
+
+// writeScanSARIF escreve um run com uma regra por analisador do FubanGo.
+// O link da lição principal vira o helpUri da regra e todas as lições
+// aparecem na mensagem de cada resultado.
+func writeScanSARIF(w io.Writer, findings []finding, lessonsURL string) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "fubango",
+			InformationURI: "https://github.com/lucasrafaldini/FubanGo",
+		}},
+		Results: []sarifResult{},
+	}
+	ruleIndex := make(map[string]int)
+	for _, a := range analyzers.All() {
+		info := analyzers.Describe(a)
+		rule := sarifRule{
+			ID:                   a.Name,
+			ShortDescription:     sarifText{Text: a.Doc},
+			DefaultConfiguration: sarifLevel{Level: info.Severity.String()},
+		}
+		if len(info.Lessons) > 0 {
+			rule.HelpURI = lessonsURL + info.Lessons[0].Link()
+			rule.Properties.Tags = []string{info.Category()}
+		}
+		ruleIndex[a.Name] = len(run.Tool.Driver.Rules)
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+	}
+
+	for _, f := range findings {
+		msg := f.Message
+		if len(f.Lessons) > 0 {
+			msg += " (lição: " + strings.Join(f.Lessons, ", ") + ")"
+		}
+		region := sarifRegion{
+			StartLine:   f.Line,
+			StartColumn: f.Column,
+			EndLine:     f.EndLine,
+			EndColumn:   f.EndColumn,
+		}
+		if f.Code != "" {
+			region.Snippet = &sarifText{Text: f.Code}
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    f.Analyzer,
+			RuleIndex: ruleIndex[f.Analyzer],
+			Level:     f.Severity,
+			Message:   sarifText{Text: msg},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysical{
+				ArtifactLocation: sarifArtifact{URI: f.File},
+				Region:           region,
+			}}},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
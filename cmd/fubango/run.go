@@ -0,0 +1,236 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lucasrafaldini/fubango/bench"
+	"github.com/lucasrafaldini/fubango/catalog"
+	"github.com/lucasrafaldini/fubango/runner"
+)
+
+func init() {
+	register(command{
+		name:    "run",
+		summary: "executa uma função de um tópico e relata goroutines vazadas, deadlocks, timeouts e data races",
+		run:     runRun,
+	})
+}
+
+// runTarget é uma função a executar; file restringe a busca a um arquivo
+type runTarget struct {
+	name string
+	file string
+}
+
+func runRun(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	root := fs.String("root", ".", "raiz do repositório FubanGo")
+	format := fs.String("format", "text", "formato de saída: text ou json")
+	bad := fs.Bool("bad", false, "procura a função em ruim.go (com um anti-padrão, executa só a versão ruim)")
+	good := fs.Bool("good", false, "procura a função em bom.go (com um anti-padrão, executa só a versão boa)")
+	timeout := fs.Duration("timeout", 2*time.Second, "tempo máximo de espera pela função")
+	settle := fs.Duration("settle", 100*time.Millisecond, "espera depois do retorno antes de contar as goroutines")
+	race := fs.Bool("race", false, "compila com o race detector")
+	n := fs.Int("n", 100, "valor dos parâmetros int e tamanho dos []int passados à função")
+	check := fs.Bool("check", false, "sai com código 1 se uma função de ruim.go rodar sem problemas ou uma de bom.go tiver algum")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Uso: fubango run [flags] <tópico> <função ou anti-padrão>... [--bad|--good]")
+		fmt.Fprintln(stderr, "Um número seleciona as funções ruim e boa do anti-padrão em analise.md.")
+		fs.PrintDefaults()
+	}
+	pos, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("formato desconhecido %q", *format)
+	}
+	if *bad && *good {
+		return fmt.Errorf("--bad e --good são exclusivas")
+	}
+	if len(pos) < 2 {
+		fs.Usage()
+		return exitError(2)
+	}
+
+	c, err := catalog.Load(*root)
+	if err != nil {
+		return err
+	}
+	topic := c.Topic(pos[0])
+	if topic == nil {
+		return fmt.Errorf("tópico desconhecido %q", pos[0])
+	}
+	targets, err := runTargets(topic, pos[1:], *bad, *good)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(*root, topic.Dir)
+	tmp, err := os.MkdirTemp("", "fubango-run")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	opts := runner.Options{Timeout: *timeout, Settle: *settle, N: *n, Race: *race}
+	var outcomes []*runner.Outcome
+	var pkgs []string
+	for _, t := range targets {
+		var files []string
+		if t.file != "" {
+			files = []string{t.file}
+		}
+		fn, err := runner.Find(dir, t.name, files...)
+		if err != nil {
+			return err
+		}
+		o, err := runner.Run(dir, tmp, fn, opts)
+		if err != nil {
+			return err
+		}
+		outcomes = append(outcomes, o)
+		pkgs = append(pkgs, fn.Package)
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(outcomes); err != nil {
+			return err
+		}
+	} else if err := writeRunText(stdout, outcomes, pkgs); err != nil {
+		return err
+	}
+	if *check {
+		for _, o := range outcomes {
+			problems := len(o.Problems()) > 0
+			if o.File == catalog.RuimFile && !problems || o.File == catalog.BomFile && problems {
+				return exitError(1)
+			}
+		}
+	}
+	return nil
+}
+
+// parseInterspersed aceita flags depois dos argumentos posicionais, como
+// em "fubango run goroutines LaunchUncontrolledGoroutines --bad"
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var pos []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return pos, nil
+		}
+		pos = append(pos, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// runTargets troca os números de anti-padrão pelas funções ruim e boa
+// associadas a ele no catálogo. bad e good restringem a um dos lados.
+func runTargets(topic *catalog.Topic, args []string, bad, good bool) ([]runTarget, error) {
+	file := ""
+	switch {
+	case bad:
+		file = catalog.RuimFile
+	case good:
+		file = catalog.BomFile
+	}
+	var targets []runTarget
+	for _, a := range args {
+		num, err := strconv.Atoi(a)
+		if err != nil {
+			targets = append(targets, runTarget{name: a, file: file})
+			continue
+		}
+		var ap *catalog.AntiPattern
+		for _, p := range topic.AntiPatterns {
+			if p.Number == num {
+				ap = p
+			}
+		}
+		if ap == nil {
+			return nil, fmt.Errorf("%s não tem o anti-padrão %d", topic.ID(), num)
+		}
+		if ap.Ruim.Found() && !good {
+			targets = append(targets, runTarget{name: ap.Ruim.Name, file: catalog.RuimFile})
+		}
+		if ap.Bom.Found() && !bad {
+			targets = append(targets, runTarget{name: ap.Bom.Name, file: catalog.BomFile})
+		}
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("nenhuma função associada a %s no catálogo", strings.Join(args, ", "))
+	}
+	return targets, nil
+}
+
+func writeRunText(w io.Writer, outcomes []*runner.Outcome, pkgs []string) error {
+	for i, o := range outcomes {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		header := fmt.Sprintf("%s (%s", o.Func, o.File)
+		if o.Race {
+			header += ", -race"
+		}
+		fmt.Fprintln(w, header+")")
+		switch {
+		case o.Crash != "" || o.Killed:
+		case o.Returned:
+			fmt.Fprintf(w, "  retornou em %s; goroutines: %d antes, %d depois\n",
+				formatDuration(o.Elapsed), o.GoroutinesBefore, o.GoroutinesAfter)
+		default:
+			fmt.Fprintf(w, "  não retornou em %s; goroutines: %d antes, %d depois\n",
+				formatDuration(o.Elapsed), o.GoroutinesBefore, o.GoroutinesAfter)
+		}
+
+		problems := o.Problems()
+		if len(problems) == 0 {
+			fmt.Fprintln(w, "  nenhum problema observado")
+		}
+		for _, p := range problems {
+			fmt.Fprintf(w, "  - %s\n", p)
+			if !strings.HasPrefix(p, "vazou ") {
+				continue
+			}
+			tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+			for _, l := range o.Leaks {
+				fmt.Fprintf(tw, "      %d\t%s\t%s\t%s\n", l.Count, l.State, l.At, runFuncName(pkgs[i], l.At.Func))
+			}
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+		}
+
+		if out := strings.TrimRight(o.Stdout, "\n"); out != "" {
+			lines := strings.Split(out, "\n")
+			fmt.Fprintf(w, "  stdout (%d linhas):\n", len(lines))
+			for _, l := range strings.Split(runner.LastLines(out, 10), "\n") {
+				fmt.Fprintf(w, "    %s\n", l)
+			}
+		}
+	}
+	return nil
+}
+
+// runFuncName abrevia o nome de uma função do tópico; compilado só com o
+// arquivo da função, o pacote aparece como command-line-arguments
+func runFuncName(pkg, fn string) string {
+	if rest, ok := strings.CutPrefix(fn, "command-line-arguments."); ok {
+		return pkg + "." + rest
+	}
+	return bench.ShortName(fn)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/runner"
+)
+
+func TestRun(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	// flags depois dos argumentos, como no README
+	code := run([]string{"run", "-root", "../..", "-format", "json", "-settle", "10ms", "goroutines", "DeadlockWithChannels", "--bad"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("código de saída = %d\n%s", code, stderr.String())
+	}
+	var outcomes []*runner.Outcome
+	if err := json.Unmarshal(stdout.Bytes(), &outcomes); err != nil {
+		t.Fatal(err)
+	}
+	if len(outcomes) != 1 || outcomes[0].File != "ruim.go" {
+		t.Fatalf("resultados = %+v", outcomes)
+	}
+	o := outcomes[0]
+	if !o.Returned || o.Leaked() != 2 {
+		t.Errorf("retornou = %v, vazadas = %d", o.Returned, o.Leaked())
+	}
+	for _, l := range o.Leaks {
+		if l.State != "chan send" || !strings.HasPrefix(l.At.String(), "ruim.go:") {
+			t.Errorf("vazamento = %+v", l)
+		}
+	}
+}
+
+func TestRunCheck(t *testing.T) {
+	// anti-padrão 9: DeadlockWithChannels vaza, AvoidDeadlock não
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"run", "-root", "../..", "-settle", "10ms", "-check", "goroutines", "9"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("código de saída = %d\n%s%s", code, stdout.String(), stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "vazou 2 goroutines") || !strings.Contains(stdout.String(), "nenhum problema observado") {
+		t.Errorf("saída:\n%s", stdout.String())
+	}
+
+	// --good procura só em bom.go
+	stdout.Reset()
+	code = run([]string{"run", "-root", "../..", "-settle", "10ms", "-check", "goroutines", "UnpredictableOrder", "--good"}, &stdout, &stderr)
+	if code != 1 || !strings.Contains(stderr.String(), "não encontrada em bom.go") {
+		t.Errorf("código de saída = %d\n%s", code, stderr.String())
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/lucasrafaldini/fubango/analyzers"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+func init() {
+	register(command{
+		name:    "scan",
+		summary: "procura os anti-padrões do FubanGo em pacotes Go",
+		run:     runScan,
+	})
+}
+
+// finding é um diagnóstico de um analisador, já ligado às lições
+type finding struct {
+	Analyzer  string   `json:"analyzer"`
+	Category  string   `json:"category"`
+	Severity  string   `json:"severity"`
+	File      string   `json:"file"`
+	Line      int      `json:"line"`
+	Column    int      `json:"column"`
+	EndLine   int      `json:"endLine,omitempty"` // zero se o diagnóstico não tem fim
+	EndColumn int      `json:"endColumn,omitempty"`
+	Message   string   `json:"message"`
+	Code      string   `json:"code"`
+	Lessons   []string `json:"lessons"`
+
+	Topic string `json:"-"`
+
+	severity analyzers.Severity
+}
+
+// maxQuoteLines limita o trecho de código citado em cada achado
+const maxQuoteLines = 5
+
+func runScan(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("scan", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	dir := fs.String("dir", ".", "diretório a partir do qual os pacotes são resolvidos")
+	format := fs.String("format", "text", "formato de saída: text, json ou sarif")
+	tests := fs.Bool("tests", false, "analisa também os arquivos _test.go")
+	lessonsURL := fs.String("lessons-url", "", "prefixo dos links para as lições (ex: https://github.com/lucasrafaldini/FubanGo/blob/main/)")
+	minSeverity := fs.String("severity", "note", "gravidade mínima reportada: note, warning ou error")
+	noteCode := fs.Int("exit-note", 0, "código de saída quando há achados note")
+	warningCode := fs.Int("exit-warning", 1, "código de saída quando há achados warning")
+	errorCode := fs.Int("exit-error", 1, "código de saída quando há achados error")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	threshold, err := analyzers.ParseSeverity(*minSeverity)
+	if err != nil {
+		return err
+	}
+	write, ok := scanWriters[*format]
+	if !ok {
+		return fmt.Errorf("formato desconhecido %q", *format)
+	}
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	findings, err := scan(*dir, patterns, *tests, stderr)
+	if err != nil {
+		return err
+	}
+	findings = slices.DeleteFunc(findings, func(f finding) bool { return f.severity < threshold })
+	for i := range findings {
+		for j, l := range findings[i].Lessons {
+			findings[i].Lessons[j] = *lessonsURL + l
+		}
+	}
+	if err := write(stdout, findings, *lessonsURL); err != nil {
+		return err
+	}
+
+	codes := map[analyzers.Severity]int{
+		analyzers.Note:    *noteCode,
+		analyzers.Warning: *warningCode,
+		analyzers.Error:   *errorCode,
+	}
+	code := 0
+	for _, f := range findings {
+		code = max(code, codes[f.severity])
+	}
+	if code != 0 {
+		return exitError(code)
+	}
+	return nil
+}
+
+// scan carrega os pacotes de patterns, executa todos os analisadores e
+// devolve os achados ordenados por categoria e posição. Pacotes com erros
+// de compilação são reportados em stderr e ignorados.
+func scan(dir string, patterns []string, tests bool, stderr io.Writer) ([]finding, error) {
+	// As dependências também são verificadas a partir do código-fonte, o que
+	// evita depender do formato de export data do toolchain instalado.
+	cfg := &packages.Config{
+		Mode:  packages.LoadAllSyntax,
+		Dir:   dir,
+		Tests: tests,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	var ok []*packages.Package
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			fmt.Fprintf(stderr, "fubango scan: pacote %s ignorado por erros:\n", pkg.PkgPath)
+			for _, e := range pkg.Errors {
+				fmt.Fprintf(stderr, "\t%v\n", e)
+			}
+			continue
+		}
+		ok = append(ok, pkg)
+	}
+	if len(ok) == 0 {
+		return nil, errors.New("nenhum pacote pôde ser analisado")
+	}
+
+	graph, err := checker.Analyze(analyzers.All(), ok, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		findings []finding
+		errs     []error
+	)
+	seen := make(map[string]bool) // com -tests um arquivo pode aparecer em dois pacotes
+	lines := make(map[string][]string)
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", act, act.Err))
+			continue
+		}
+		for _, d := range act.Diagnostics {
+			f := newFinding(act.Analyzer, act.Package, d, base, lines)
+			key := fmt.Sprintf("%s:%d:%d:%s:%s", f.File, f.Line, f.Column, f.Analyzer, f.Message)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			findings = append(findings, f)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	slices.SortFunc(findings, func(a, b finding) int {
+		return cmp.Or(
+			cmp.Compare(a.Topic, b.Topic),
+			cmp.Compare(a.File, b.File),
+			cmp.Compare(a.Line, b.Line),
+			cmp.Compare(a.Column, b.Column),
+			cmp.Compare(a.Analyzer, b.Analyzer),
+		)
+	})
+	return findings, nil
+}
+
+// newFinding converte d em um achado, com o caminho relativo a base e o
+// trecho de código citado. lines guarda os arquivos já lidos.
+func newFinding(a *analysis.Analyzer, pkg *packages.Package, d analysis.Diagnostic, base string, lines map[string][]string) finding {
+	info := analyzers.Describe(a)
+	start := pkg.Fset.Position(d.Pos)
+	end := start
+	if d.End.IsValid() {
+		end = pkg.Fset.Position(d.End)
+	}
+
+	f := finding{
+		Analyzer: a.Name,
+		Category: info.Category(),
+		Severity: info.Severity.String(),
+		File:     start.Filename,
+		Line:     start.Line,
+		Column:   start.Column,
+		Message:  d.Message,
+		severity: info.Severity,
+	}
+	if d.End.IsValid() && d.End > d.Pos {
+		f.EndLine, f.EndColumn = end.Line, end.Column
+	}
+	if len(info.Lessons) > 0 {
+		f.Topic = info.Lessons[0].Topic
+	}
+	for _, l := range info.Lessons {
+		f.Lessons = append(f.Lessons, l.Link())
+	}
+	if rel, err := filepath.Rel(base, start.Filename); err == nil && !strings.HasPrefix(rel, "..") {
+		f.File = filepath.ToSlash(rel)
+	}
+
+	src, ok := lines[start.Filename]
+	if !ok {
+		if data, err := os.ReadFile(start.Filename); err == nil {
+			src = strings.Split(string(data), "\n")
+		}
+		lines[start.Filename] = src
+	}
+	if start.Line >= 1 && start.Line <= len(src) {
+		last := min(max(end.Line, start.Line), start.Line+maxQuoteLines-1, len(src))
+		f.Code = strings.Join(src[start.Line-1:last], "\n")
+	}
+	return f
+}
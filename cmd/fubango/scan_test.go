@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestScanJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"scan", "-dir", "testdata/scan", "-format", "json", "-exit-error", "3"}, &stdout, &stderr)
+	if code != 3 {
+		t.Fatalf("código de saída = %d, esperado 3\n%s", code, stderr.String())
+	}
+
+	var report struct {
+		Total      int
+		Categories []struct {
+			Category string
+			Findings []finding
+		}
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Total != 2 || len(report.Categories) != 2 {
+		t.Fatalf("relatório = %+v, esperados 2 achados em 2 categorias", report)
+	}
+	db := report.Categories[1]
+	if db.Category != "database" {
+		t.Fatalf("segunda categoria = %q, esperado database", db.Category)
+	}
+	f := db.Findings[0]
+	if f.Analyzer != "sqlconcat" || f.File != "store.go" || f.Line != 10 || f.Severity != "error" {
+		t.Errorf("achado = %+v", f)
+	}
+	if !strings.Contains(f.Code, "db.Query(query)") {
+		t.Errorf("Code = %q", f.Code)
+	}
+	if f.Lessons[0] != "exemplos/04-casos-reais/database/analise.md#2" {
+		t.Errorf("Lessons = %v", f.Lessons)
+	}
+}
+
+func TestScanSeverity(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"scan", "-dir", "testdata/scan", "-severity", "warning", "-exit-error", "0"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("código de saída = %d, esperado 0\n%s", code, stderr.String())
+	}
+	out := stdout.String()
+	if strings.Contains(out, "[errwrap]") || !strings.Contains(out, "Total: 1 achados") {
+		t.Errorf("saída inesperada:\n%s", out)
+	}
+}
+
+func TestScanSARIF(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	run([]string{"scan", "-dir", "testdata/scan", "-format", "sarif", "-lessons-url", "https://example.com/"}, &stdout, &stderr)
+
+	var log sarifLog
+	if err := json.Unmarshal(stdout.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("log = %+v", log)
+	}
+	r := log.Runs[0]
+	if len(r.Results) != 2 {
+		t.Fatalf("len(Results) = %d, esperado 2", len(r.Results))
+	}
+	for _, res := range r.Results {
+		rule := r.Tool.Driver.Rules[res.RuleIndex]
+		if rule.ID != res.RuleID {
+			t.Errorf("ruleIndex %d aponta para %s, esperado %s", res.RuleIndex, rule.ID, res.RuleID)
+		}
+		if !strings.HasPrefix(rule.HelpURI, "https://example.com/exemplos/") {
+			t.Errorf("helpUri = %q", rule.HelpURI)
+		}
+		loc := res.Locations[0].PhysicalLocation.Region
+		if loc.EndLine != 0 && loc.EndLine == loc.StartLine && loc.EndColumn <= loc.StartColumn {
+			t.Errorf("região vazia: %+v", loc)
+		}
+	}
+}
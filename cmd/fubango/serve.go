@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/lucasrafaldini/fubango/catalog"
+	"github.com/lucasrafaldini/fubango/site"
+)
+
+func init() {
+	register(command{
+		name:    "serve",
+		summary: "serve as páginas dos tópicos com ruim.go e bom.go lado a lado",
+		run:     runServe,
+	})
+}
+
+func runServe(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	root := fs.String("root", ".", "raiz do repositório FubanGo")
+	addr := fs.String("addr", "localhost:8080", "endereço HTTP")
+	results := resultsFlag(fs)
+	lang := langFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("argumentos inesperados: %v", fs.Args())
+	}
+
+	s := &site.Site{Root: *root, Results: *results, Lang: *lang}
+	// valida o repositório, a língua e os baselines antes de abrir a porta
+	if _, err := catalog.ParseLang(*lang); err != nil {
+		return err
+	}
+	if _, err := site.ReadResults(*results); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(*root, "exemplos")); err != nil {
+		return fmt.Errorf("%s não parece a raiz do FubanGo: %w", *root, err)
+	}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	// Serve retorna assim que Shutdown começa; o erro de Shutdown chega
+	// depois, quando as conexões abertas terminam ou o prazo acaba
+	shutdownErr := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdownErr <- srv.Shutdown(shutdown)
+	}()
+
+	fmt.Fprintf(stdout, "Servindo os exemplos em http://%s/ (Ctrl+C para sair)\n", ln.Addr())
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	if err := <-shutdownErr; err != nil {
+		return fmt.Errorf("encerrando o servidor: %w", err)
+	}
+	return nil
+}
+
+// resultsFlag registra -results, os baselines de fubango bench -save
+// mostrados nas páginas
+func resultsFlag(fs *flag.FlagSet) *[]string {
+	var results []string
+	fs.Func("results", "baseline salvo por fubango bench -save (pode repetir e aceita glob, ex: 'resultados-*.json'); vale o resultado mais recente de cada benchmark", func(v string) error {
+		if _, err := filepath.Match(v, ""); err != nil {
+			return fmt.Errorf("padrão inválido %q: %w", v, err)
+		}
+		results = append(results, v)
+		return nil
+	})
+	return &results
+}
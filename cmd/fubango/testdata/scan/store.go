@@ -0,0 +1,16 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func FindUser(db *sql.DB, name string) error {
+	query := "SELECT id FROM users WHERE name = '" + name + "'"
+	rows, err := db.Query(query)
+	if err != nil {
+		return fmt.Errorf("buscando %s: %v", name, err)
+	}
+	defer rows.Close()
+	return nil
+}
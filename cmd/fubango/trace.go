@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lucasrafaldini/fubango/bench"
+	"github.com/lucasrafaldini/fubango/catalog"
+	"github.com/lucasrafaldini/fubango/runner"
+	"github.com/lucasrafaldini/fubango/sched"
+)
+
+func init() {
+	register(command{
+		name:    "trace",
+		summary: "executa funções de um tópico sob runtime/trace e resume o estado das goroutines",
+		run:     runTrace,
+	})
+}
+
+// traceResult é o resumo do trace de uma função
+type traceResult struct {
+	Func    string         `json:"func"`
+	File    string         `json:"file"`
+	Trace   string         `json:"trace,omitempty"`
+	Summary *sched.Summary `json:"summary"`
+}
+
+// traceHarness é o teste inserido no pacote por runner.Build que chama a função e desiste dela depois do timeout.
+// Goroutines que a função deixar para trás continuam no trace até o fim.
+const traceHarness = `package %s
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFubangoTrace(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(%d))
+	defer cancel()
+	done := make(chan struct{})
+	go fubangoTraceRun(ctx, done)
+	select {
+	case <-done:
+		time.Sleep(time.Duration(%d)) // deixa as goroutines que sobraram aparecerem no trace
+	case <-ctx.Done():
+		t.Log("tempo esgotado; a função continua rodando")
+	}
+}
+
+func fubangoTraceRun(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	%s(%s)
+}
+`
+
+func runTrace(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("trace", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	root := fs.String("root", ".", "raiz do repositório FubanGo")
+	format := fs.String("format", "text", "formato de saída: text ou json")
+	timeout := fs.Duration("timeout", 2*time.Second, "tempo máximo de espera pela função (as que nunca retornam são abandonadas)")
+	linger := fs.Duration("linger", 100*time.Millisecond, "tempo que o trace continua depois que a função retorna, para as goroutines que ela deixou rodando")
+	n := fs.Int("n", 100, "valor dos parâmetros int e tamanho dos []int passados à função")
+	benchtime := fs.String("benchtime", "100x", "valor de go test -benchtime para funções Benchmark")
+	out := fs.String("out", "", "guarda os traces neste diretório, para abrir com go tool trace")
+	all := fs.Bool("all", false, "inclui as goroutines do runtime e do go test")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Uso: fubango trace [flags] <tópico> <função ou anti-padrão>...")
+		fmt.Fprintln(stderr, "Um número seleciona as funções ruim e boa do anti-padrão em analise.md.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("formato desconhecido %q", *format)
+	}
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return exitError(2)
+	}
+
+	c, err := catalog.Load(*root)
+	if err != nil {
+		return err
+	}
+	topic := c.Topic(fs.Arg(0))
+	if topic == nil {
+		return fmt.Errorf("tópico desconhecido %q", fs.Arg(0))
+	}
+	names, err := traceTargets(topic, fs.Args()[1:])
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(*root, topic.Dir)
+	tmp, err := os.MkdirTemp("", "fubango-trace")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	traceDir := tmp
+	if *out != "" {
+		traceDir = *out
+		if err := os.MkdirAll(traceDir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	var results []traceResult
+	for _, name := range names {
+		fn, err := runner.Find(dir, name)
+		if err != nil {
+			return err
+		}
+		file := filepath.Join(traceDir, name+".trace")
+		importPath, err := captureTrace(dir, tmp, file, fn, *timeout, *linger, *n, *benchtime, stderr)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		s, err := sched.Summarize(f, traceFocus(importPath, *all))
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		// compilado só com o arquivo da função, o pacote não tem import path
+		for i, g := range s.Groups {
+			if rest, ok := strings.CutPrefix(g.Func, "command-line-arguments."); ok {
+				s.Groups[i].Func = fn.Package + "." + rest
+			}
+		}
+		res := traceResult{Func: name, File: fn.File, Summary: s}
+		if *out != "" {
+			res.Trace = file
+		}
+		results = append(results, res)
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	}
+	return writeTraceText(stdout, results)
+}
+
+// traceTargets troca os números de anti-padrão pelas funções ruim e boa
+// associadas a ele no catálogo
+func traceTargets(topic *catalog.Topic, args []string) ([]string, error) {
+	var names []string
+	for _, a := range args {
+		num, err := strconv.Atoi(a)
+		if err != nil {
+			names = append(names, a)
+			continue
+		}
+		var ap *catalog.AntiPattern
+		for _, p := range topic.AntiPatterns {
+			if p.Number == num {
+				ap = p
+			}
+		}
+		if ap == nil {
+			return nil, fmt.Errorf("%s não tem o anti-padrão %d", topic.ID(), num)
+		}
+		for _, s := range []catalog.Symbol{ap.Ruim, ap.Bom} {
+			if s.Found() {
+				names = append(names, s.Name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// captureTrace compila o pacote com o harness, executa fn com
+// -test.trace e grava o trace em file. Devolve o import path do pacote
+// compilado, que prefixa as funções no trace.
+func captureTrace(dir, tmp, file string, fn *runner.Func, timeout, linger time.Duration, n int, benchtime string, stderr io.Writer) (string, error) {
+	harness := ""
+	args := []string{"-test.count", "1", "-test.trace", file, "-test.timeout", (timeout + time.Minute).String()}
+	if fn.IsBenchmark() {
+		args = append(args, "-test.run", "^$", "-test.bench", "^"+fn.Name+"$", "-test.benchtime", benchtime)
+	} else {
+		callArgs, err := fn.Args(n)
+		if err != nil {
+			return "", err
+		}
+		harness = fmt.Sprintf(traceHarness, fn.Package, timeout, linger, fn.Name, callArgs) + runner.IntsHelper
+		args = append(args, "-test.run", "^TestFubangoTrace$")
+	}
+	bin, err := runner.Build(dir, tmp, fn, harness, false)
+	if err != nil {
+		return "", err
+	}
+	cmd := bin.Command(context.Background(), args...)
+	cmd.Stderr = stderr
+	if out, err := cmd.Output(); err != nil {
+		return "", fmt.Errorf("executando %s: %w\n%s", fn.Name, err, runner.LastLines(string(out), 20))
+	}
+	return bin.ImportPath, nil
+}
+
+// traceFocus aceita as goroutines iniciadas no pacote do tópico (e nos
+// seus testes, de onde partem os benchmarks). Com all, aceita todas.
+func traceFocus(importPath string, all bool) func(string) bool {
+	if all {
+		return nil
+	}
+	return func(fn string) bool {
+		return strings.HasPrefix(fn, importPath+".") || strings.HasPrefix(fn, importPath+"_test.") ||
+			strings.HasPrefix(fn, "testing.(*B)")
+	}
+}
+
+func writeTraceText(w io.Writer, results []traceResult) error {
+	for i, r := range results {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		s := r.Summary
+		fmt.Fprintf(w, "%s (%s)\n", r.Func, r.File)
+		fmt.Fprintf(w, "  %s de trace, %d goroutines criadas, pico de %d vivas, %d vivas ao final\n",
+			formatDuration(s.Duration), s.Created, s.PeakLive, s.LiveAtEnd)
+		if r.Trace != "" {
+			fmt.Fprintf(w, "  trace: %s\n", r.Trace)
+		}
+
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  FUNÇÃO INICIAL\tG\tEXECUTANDO\tEXECUTÁVEL\tCANAL\tMUTEX\tSYSCALL\tSLEEP\tOUTROS")
+		var total sched.Times
+		count := 0
+		for _, g := range s.Groups {
+			name := bench.ShortName(g.Func)
+			if strings.HasSuffix(name, ".fubangoTraceRun") {
+				name = strings.TrimSuffix(name, "fubangoTraceRun") + r.Func + " (chamada)"
+			}
+			fmt.Fprintf(tw, "  %s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", name, g.Goroutines,
+				formatDuration(g.Running), formatDuration(g.Runnable), formatDuration(g.Chan),
+				formatDuration(g.Mutex), formatDuration(g.Syscall), formatDuration(g.Sleep), formatDuration(g.Other))
+			total.Add(g.Times)
+			count += g.Goroutines
+		}
+		if len(s.Groups) > 1 {
+			fmt.Fprintf(tw, "  total\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", count,
+				formatDuration(total.Running), formatDuration(total.Runnable), formatDuration(total.Chan),
+				formatDuration(total.Mutex), formatDuration(total.Syscall), formatDuration(total.Sleep), formatDuration(total.Other))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatDuration abrevia um tempo do trace; zero vira "-"
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return "-"
+	}
+	return bench.FormatValue(int64(d), "nanoseconds")
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"trace", "-root", "../..", "-format", "json", "-linger", "10ms", "channels", "DirectedChannels"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("código de saída = %d\n%s", code, stderr.String())
+	}
+	var results []traceResult
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].File != "bom.go" {
+		t.Fatalf("resultados = %+v", results)
+	}
+	s := results[0].Summary
+	// a chamada e a goroutine de sendOnly
+	if s.Created != 2 || s.PeakLive != 2 || s.LiveAtEnd != 0 {
+		t.Errorf("criadas = %d, pico = %d, vivas ao final = %d", s.Created, s.PeakLive, s.LiveAtEnd)
+	}
+	var send bool
+	for _, g := range s.Groups {
+		send = send || strings.HasSuffix(g.Func, "channels.sendOnly")
+	}
+	if !send {
+		t.Errorf("grupos sem sendOnly: %+v", s.Groups)
+	}
+}
+
+func TestTraceBrokenPackage(t *testing.T) {
+	// bom.go de concorrencia não compila; ruim.go é compilado sozinho
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"trace", "-root", "../..", "-linger", "10ms", "concorrencia", "BadGoroutineLeak"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("código de saída = %d\n%s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "concorrencia.BadGoroutineLeak.func1") {
+		t.Errorf("saída sem a goroutine vazada:\n%s", stdout.String())
+	}
+}
+
+func TestTraceExcludedFile(t *testing.T) {
+	// ruim.go de variaveis é excluído por build tags e não compila sozinho
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"trace", "-root", "../..", "variaveis", "6"}, &stdout, &stderr)
+	if code != 1 || !strings.Contains(stderr.String(), "declared and not used") {
+		t.Errorf("código de saída = %d\n%s", code, stderr.String())
+	}
+}
@@ -0,0 +1,215 @@
+// Package escape compila os tópicos do FubanGo com -gcflags=-m=2 e associa
+// as decisões de escape analysis e inlining do compilador às linhas de
+// ruim.go e bom.go.
+//
+// Muitos anti-padrões (conversões desnecessárias, interface{} no lugar de
+// genéricos, ponteiros devolvidos por funções) custam alocações no heap que
+// não aparecem no código. A saída de -m mostra quais variáveis escapam em
+// cada versão.
+package escape
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"go/build"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Tipos de decisão do compilador
+const (
+	KindHeap     = "heap"     // escapa ou é movida para o heap
+	KindStack    = "stack"    // não escapa
+	KindLeak     = "leak"     // parâmetro vaza para o resultado ou para o heap
+	KindInline   = "inline"   // função inlinável ou chamada inlined
+	KindNoInline = "noinline" // função que não pode ser inlined
+)
+
+// Decision é uma mensagem de -m associada a uma posição do código
+type Decision struct {
+	File    string   `json:"file"`
+	Line    int      `json:"line"`
+	Col     int      `json:"col"`
+	Kind    string   `json:"kind"`
+	Message string   `json:"message"`
+	Flow    []string `json:"flow,omitempty"` // explicação de -m=2
+}
+
+var (
+	position = regexp.MustCompile(`^(.+?\.go):(\d+):(\d+): (.*)$`)
+	// "can inline F with cost 12 as: func() {...}" perde o corpo
+	inlineBody = regexp.MustCompile(`^(can inline .+ with cost \d+) as: .*$`)
+)
+
+// Parse lê a saída do compilador com -m ou -m=2. As linhas de explicação
+// de -m=2 ("flow:", "from ...") são anexadas à decisão de escape ou de
+// vazamento seguinte na mesma posição. Mensagens desconhecidas e erros de
+// compilação são ignorados.
+func Parse(r io.Reader) ([]Decision, error) {
+	var out []Decision
+	seen := make(map[string]bool)
+	flows := make(map[string][]string)
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		m := position.FindStringSubmatch(sc.Text())
+		if m == nil {
+			continue
+		}
+		pos := m[1] + ":" + m[2] + ":" + m[3]
+		msg := m[4]
+		if strings.HasPrefix(msg, " ") {
+			flows[pos] = append(flows[pos], strings.TrimSpace(msg))
+			continue
+		}
+		if strings.HasSuffix(msg, ":") { // cabeçalho da explicação de -m=2
+			continue
+		}
+		kind := classify(msg)
+		if kind == "" || seen[pos+" "+msg] {
+			continue
+		}
+		seen[pos+" "+msg] = true
+
+		line, _ := strconv.Atoi(m[2])
+		col, _ := strconv.Atoi(m[3])
+		if sub := inlineBody.FindStringSubmatch(msg); sub != nil {
+			msg = sub[1]
+		}
+		d := Decision{File: filepath.Base(m[1]), Line: line, Col: col, Kind: kind, Message: msg}
+		if kind == KindHeap || kind == KindLeak {
+			d.Flow = flows[pos]
+			delete(flows, pos)
+		}
+		out = append(out, d)
+	}
+	return out, sc.Err()
+}
+
+func classify(msg string) string {
+	switch {
+	case strings.HasSuffix(msg, "escapes to heap"), strings.HasPrefix(msg, "moved to heap:"):
+		return KindHeap
+	case strings.HasSuffix(msg, "does not escape"):
+		return KindStack
+	case strings.HasPrefix(msg, "leaking param"):
+		return KindLeak
+	case strings.HasPrefix(msg, "can inline"), strings.HasPrefix(msg, "inlining call to"):
+		return KindInline
+	case strings.HasPrefix(msg, "cannot inline"):
+		return KindNoInline
+	}
+	return ""
+}
+
+// Report são as decisões de cada arquivo analisado. Arquivos que não
+// compilam (alguns ruim.go são inválidos de propósito) aparecem em Errors
+// com a saída do compilador.
+type Report struct {
+	Decisions map[string][]Decision `json:"decisions"`
+	Errors    map[string]string     `json:"errors,omitempty"`
+}
+
+// For devolve as decisões de file entre as linhas start e end (inclusive)
+func (r *Report) For(file string, start, end int) []Decision {
+	var out []Decision
+	for _, d := range r.Decisions[file] {
+		if d.Line >= start && d.Line <= end {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// Analyze compila o pacote em dir com -gcflags=-m=2 e separa as decisões
+// de cada um dos files. Um arquivo excluído do pacote por build tags (como
+// um ruim.go com //go:build ignore), ou qualquer arquivo quando o pacote não
+// compila, é compilado sozinho.
+func Analyze(dir string, files ...string) (*Report, error) {
+	r := &Report{Decisions: make(map[string][]Decision), Errors: make(map[string]string)}
+	out, pkgErr := compile(dir, ".")
+	if failed := new(exec.ExitError); pkgErr != nil && !errors.As(pkgErr, &failed) {
+		return nil, pkgErr
+	}
+	all, err := Parse(bytes.NewReader(out))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		match, err := build.Default.MatchFile(dir, file)
+		if err != nil {
+			return nil, err
+		}
+		if pkgErr == nil && match {
+			for _, d := range all {
+				if d.File == file {
+					r.Decisions[file] = append(r.Decisions[file], d)
+				}
+			}
+			continue
+		}
+
+		out, err := compile(dir, file)
+		if err != nil {
+			r.Errors[file] = compileErrors(out, err)
+			continue
+		}
+		if r.Decisions[file], err = Parse(bytes.NewReader(out)); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// compile roda go build -gcflags=-m=2 sobre target (um pacote ou um
+// arquivo) e devolve a saída do compilador
+func compile(dir, target string) ([]byte, error) {
+	cmd := exec.Command("go", "build", "-gcflags=-m=2", "-o", os.DevNull, target)
+	cmd.Dir = dir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	if _, ok := err.(*exec.ExitError); err != nil && !ok {
+		return nil, fmt.Errorf("executando go build: %w", err)
+	}
+	return out.Bytes(), err
+}
+
+// compileErrors extrai da saída de um build que falhou apenas as linhas
+// de erro, sem as decisões de -m
+func compileErrors(out []byte, err error) string {
+	var lines []string
+	for _, l := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(l, "#") || l == "" {
+			continue
+		}
+		m := position.FindStringSubmatch(l)
+		if m != nil && (classify(m[4]) != "" || strings.HasPrefix(m[4], " ") || strings.HasSuffix(m[4], ":")) {
+			continue
+		}
+		lines = append(lines, l)
+	}
+	if len(lines) == 0 {
+		return err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Count conta as decisões de um tipo
+func Count(decisions []Decision, kind string) int {
+	n := 0
+	for _, d := range decisions {
+		if d.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,55 @@
+package escape
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "interfaces.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	decisions, err := Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byPos := make(map[string]Decision)
+	for _, d := range decisions {
+		byPos[fmt.Sprintf("%s:%d:%d %s", d.File, d.Line, d.Col, d.Kind)] = d
+	}
+	tests := []struct {
+		pos, kind, message string
+		flow               int
+	}{
+		{"bom.go:27:16", KindHeap, `([]byte)("dados") escapes to heap`, 3},
+		{"bom.go:30:29", KindStack, "data does not escape", 0},
+		{"bom.go:34:31", KindLeak, "leaking param: data to result ~r0 level=0", 2},
+		{"bom.go:30:6", KindInline, "can inline (*FileHandler).Write with cost 2", 0},
+		{"bom.go:146:6", KindNoInline, "cannot inline (*EnhancedReader).Read: function too complex: cost 81 exceeds budget 80", 0},
+		{"ruim.go:112:30", KindLeak, "leaking param: value", 2},
+	}
+	for _, tt := range tests {
+		d, ok := byPos[tt.pos+" "+tt.kind]
+		if !ok {
+			t.Errorf("%s %s não encontrada", tt.pos, tt.kind)
+			continue
+		}
+		if d.Message != tt.message || len(d.Flow) != tt.flow {
+			t.Errorf("%s = %q com %d linhas de flow, esperado %q com %d", tt.pos, d.Message, len(d.Flow), tt.message, tt.flow)
+		}
+	}
+
+	// a explicação de e.Message não pode ir para e.Code na mesma linha
+	if d := byPos["bom.go:96:61 "+KindHeap]; len(d.Flow) != 6 || !strings.Contains(d.Flow[1], "e.Code") {
+		t.Errorf("flow de e.Code = %q", d.Flow)
+	}
+	if n := Count(decisions, KindHeap); n != 3 {
+		t.Errorf("Count(heap) = %d, esperado 3", n)
+	}
+}
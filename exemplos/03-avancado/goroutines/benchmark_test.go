@@ -175,7 +175,11 @@ func BenchmarkBatch_NoPool(b *testing.B) {
 func BenchmarkBatch_WithPool(b *testing.B) {
 	items := make([]int, 1000)
 	processor := NewBatchProcessor(100, 10)
-	defer processor.Stop()
+	defer func() {
+		if err := processor.Stop(); err != nil {
+			b.Error(err)
+		}
+	}()
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -237,3 +241,39 @@ func BenchmarkSync_GoodWaitGroup(b *testing.B) {
 		wg.Wait()
 	}
 }
+
+// Benchmark de carga em rajadas: pool fixo pequeno demais vs pool com
+// autoscaling, que cresce até o tamanho da rajada
+func runBurst(b *testing.B, pool *WorkerPool) {
+	defer pool.Stop()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var wg sync.WaitGroup
+		for j := 0; j < 64; j++ {
+			wg.Add(1)
+			err := pool.Submit(func() {
+				defer wg.Done()
+				time.Sleep(200 * time.Microsecond) // simula I/O
+			})
+			if err != nil {
+				wg.Done() // a tarefa recusada nunca vai rodar
+				b.Fatal(err)
+			}
+		}
+		wg.Wait()
+	}
+}
+
+func BenchmarkBurst_TooSmallPool(b *testing.B) {
+	runBurst(b, NewWorkerPool(4))
+}
+
+func BenchmarkBurst_WellSizedPool(b *testing.B) {
+	runBurst(b, mustPool(b, PoolConfig{
+		MinWorkers:   4,
+		MaxWorkers:   64,
+		ScaleUpQueue: 8,
+		ScaleUpWait:  100 * time.Microsecond,
+		IdleTimeout:  10 * time.Millisecond,
+	}))
+}
@@ -2,8 +2,12 @@ package goroutines
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"runtime"
+	"runtime/debug"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -11,65 +15,656 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-// WorkerPool implementa um pool de workers controlado
+// WorkerPool implementa um pool de workers controlado. O número de workers
+// varia entre PoolConfig.MinWorkers e MaxWorkers conforme a fila, e as
+// tarefas esperam em uma fila por classe de prioridade.
 type WorkerPool struct {
-	workers  int
-	tasks    chan func()
-	ctx      context.Context
-	cancel   context.CancelFunc
-	wg       sync.WaitGroup
-	stopping atomic.Bool
+	cfg    PoolConfig
+	queue  *taskQueue
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// slots limita cada classe a QueueSize tarefas, para uma classe cheia
+	// não bloquear o Submit das outras. ready recebe uma ficha por tarefa
+	// na fila; os workers a consomem antes de tirar a tarefa.
+	slots []chan struct{}
+	ready chan struct{}
+
+	// mu protege o envio em ready: Submit envia com RLock e o canal só é
+	// fechado com Lock, depois de closing, para Submit nunca enviar em um
+	// canal fechado
+	mu        sync.RWMutex
+	closing   chan struct{}
+	closeOnce sync.Once
+	done      chan struct{} // fechado quando todos os workers terminam
+	waitOnce  sync.Once
+
+	sizeMu  sync.Mutex
+	size    int           // workers vivos
+	excess  int           // workers que devem sair depois de um Resize
+	resized chan struct{} // fechado e recriado por Resize para acordar os workers
+
+	panicMu sync.Mutex
+	panics  []time.Time // panics dentro de PanicWindow
+	failure error       // motivo da falha, depois de panics demais
 }
 
+// Scheduling é a política de escolha entre as classes de prioridade
+type Scheduling int
+
+const (
+	// Strict sempre atende a classe mais prioritária com tarefas
+	Strict Scheduling = iota
+	// WeightedFair atende as classes na proporção de PoolConfig.Weights
+	WeightedFair
+)
+
+// PoolConfig configura um WorkerPool. Sem os limiares, o pool fica com
+// MinWorkers workers até um Resize.
+type PoolConfig struct {
+	MinWorkers int
+	MaxWorkers int // padrão: MinWorkers
+	QueueSize  int // tarefas por classe; padrão: MaxWorkers*2
+
+	// Um worker é criado quando a fila chega a ScaleUpQueue tarefas ou
+	// quando uma tarefa espera mais que ScaleUpWait para começar (zero
+	// desliga o critério)
+	ScaleUpQueue int
+	ScaleUpWait  time.Duration
+
+	// Workers acima de MinWorkers saem depois de IdleTimeout sem tarefas
+	IdleTimeout time.Duration
+
+	// Priorities é o número de classes de prioridade, de 0 (a mais alta)
+	// a Priorities-1; padrão: 1
+	Priorities int
+	Scheduling Scheduling
+	// Weights é o peso de cada classe em WeightedFair; padrão: o dobro da
+	// classe seguinte (4, 2, 1 com três classes)
+	Weights []int
+	// A cada AgingInterval na fila, uma tarefa passa a contar como da
+	// classe acima, para não esperar para sempre (zero desliga)
+	AgingInterval time.Duration
+
+	// PanicHandler recebe o panic de cada tarefa, com a pilha; padrão:
+	// registrar com log.Printf. O worker da tarefa é substituído por outro.
+	PanicHandler func(*PanicError)
+	// Com mais de MaxPanics panics em PanicWindow (zero: desde o início),
+	// o pool falha: para como StopNow e Submit devolve ErrPoolFailed. Zero
+	// tolera qualquer número de panics.
+	MaxPanics   int
+	PanicWindow time.Duration
+}
+
+// job é uma tarefa na fila, com o momento em que entrou nela
+type job struct {
+	fn func()
+	at time.Time
+}
+
+// NewWorkerPool cria um pool com um número fixo de workers
 func NewWorkerPool(workers int) *WorkerPool {
+	return newPool(PoolConfig{MinWorkers: workers, MaxWorkers: workers})
+}
+
+// NewPool cria um pool com cfg e inicia MinWorkers workers. Weights com
+// tamanho diferente de Priorities ou com pesos não positivos são um erro.
+func NewPool(cfg PoolConfig) (*WorkerPool, error) {
+	if cfg.Weights != nil && len(cfg.Weights) != max(cfg.Priorities, 1) {
+		return nil, fmt.Errorf("PoolConfig: %d pesos para %d classes", len(cfg.Weights), max(cfg.Priorities, 1))
+	}
+	for c, w := range cfg.Weights {
+		if w <= 0 {
+			return nil, fmt.Errorf("PoolConfig: peso %d da classe %d não é positivo", w, c)
+		}
+	}
+	return newPool(cfg), nil
+}
+
+// newPool preenche os padrões de cfg, já validado, e inicia o pool
+func newPool(cfg PoolConfig) *WorkerPool {
+	cfg.MinWorkers = max(cfg.MinWorkers, 0)
+	cfg.MaxWorkers = max(cfg.MaxWorkers, cfg.MinWorkers, 1)
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = cfg.MaxWorkers * 2 // buffer para evitar bloqueio
+	}
+	cfg.Priorities = max(cfg.Priorities, 1)
+	if cfg.Weights == nil {
+		cfg.Weights = make([]int, cfg.Priorities)
+		for c := range cfg.Weights {
+			cfg.Weights[c] = 1 << (cfg.Priorities - 1 - c)
+		}
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	pool := &WorkerPool{
-		workers: workers,
-		tasks:   make(chan func(), workers*2), // buffer para evitar bloqueio
+		cfg:     cfg,
+		queue:   newTaskQueue(cfg),
 		ctx:     ctx,
 		cancel:  cancel,
+		slots:   make([]chan struct{}, cfg.Priorities),
+		ready:   make(chan struct{}, cfg.QueueSize*cfg.Priorities),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+		resized: make(chan struct{}),
+	}
+	for c := range pool.slots {
+		pool.slots[c] = make(chan struct{}, cfg.QueueSize)
 	}
 	pool.Start()
 	return pool
 }
 
+// Start inicia os workers que faltam para chegar a MinWorkers
 func (p *WorkerPool) Start() {
-	for i := 0; i < p.workers; i++ {
-		p.wg.Add(1)
-		go func() {
-			defer p.wg.Done()
-			for {
-				select {
-				case task, ok := <-p.tasks:
-					if !ok {
-						return
-					}
-					task()
-				case <-p.ctx.Done():
+	p.sizeMu.Lock()
+	defer p.sizeMu.Unlock()
+	for p.size < p.cfg.MinWorkers {
+		p.spawnLocked()
+	}
+}
+
+// Workers devolve o número de workers vivos
+func (p *WorkerPool) Workers() int {
+	p.sizeMu.Lock()
+	defer p.sizeMu.Unlock()
+	return p.size
+}
+
+// QueueLen devolve o número de tarefas esperando na fila
+func (p *WorkerPool) QueueLen() int {
+	return p.queue.len()
+}
+
+// QueueDepths devolve o número de tarefas esperando em cada classe de
+// prioridade
+func (p *WorkerPool) QueueDepths() []int {
+	return p.queue.depths()
+}
+
+// Resize muda o número de workers para n. Se n estiver fora de
+// [MinWorkers, MaxWorkers], o limite passa a ser n; o autoscaling continua
+// dentro dos limites. Workers ocupados saem ao terminar a tarefa.
+func (p *WorkerPool) Resize(n int) error {
+	if n < 0 {
+		return fmt.Errorf("número de workers inválido: %d", n)
+	}
+	// com RLock, o pool não fecha enquanto workers são criados
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	select {
+	case <-p.closing:
+		return ErrPoolStopped
+	default:
+	}
+
+	p.sizeMu.Lock()
+	defer p.sizeMu.Unlock()
+	p.cfg.MinWorkers = min(p.cfg.MinWorkers, n)
+	p.cfg.MaxWorkers = max(p.cfg.MaxWorkers, n)
+	p.excess = max(p.size-n, 0)
+	for p.size < n {
+		p.spawnLocked()
+	}
+	if p.excess > 0 {
+		close(p.resized)
+		p.resized = make(chan struct{})
+	}
+	return nil
+}
+
+// spawnLocked cria um worker; chamado com sizeMu
+func (p *WorkerPool) spawnLocked() {
+	p.size++
+	p.wg.Add(1)
+	go p.worker()
+}
+
+// scaleUp cria um worker se o pool ainda não chegou a MaxWorkers e a fila
+// passou de ScaleUpQueue, ou se não há nenhum worker (MinWorkers zero)
+func (p *WorkerPool) scaleUp(force bool) {
+	p.sizeMu.Lock()
+	defer p.sizeMu.Unlock()
+	queued := p.cfg.ScaleUpQueue > 0 && p.queue.len() >= p.cfg.ScaleUpQueue
+	if p.size < p.cfg.MaxWorkers && (force || queued || p.size == 0) {
+		p.excess = 0
+		p.spawnLocked()
+	}
+}
+
+// scaleUpWaiting cria um worker se a tarefa mais antiga da fila espera há
+// ScaleUpWait. Roda em um timer armado por Submit, e não depois de um worker
+// tirar uma tarefa, para crescer mesmo com todos os workers ocupados.
+// Sem workers vivos o pool já está parando, e nada é criado.
+func (p *WorkerPool) scaleUpWaiting() {
+	p.sizeMu.Lock()
+	defer p.sizeMu.Unlock()
+	at, ok := p.queue.oldest()
+	if !ok || time.Since(at) < p.cfg.ScaleUpWait || p.ctx.Err() != nil {
+		return
+	}
+	if p.size > 0 && p.size < p.cfg.MaxWorkers {
+		p.excess = 0
+		p.spawnLocked()
+	}
+}
+
+// exit registra a saída de um worker
+func (p *WorkerPool) exit() {
+	p.sizeMu.Lock()
+	defer p.sizeMu.Unlock()
+	p.excess = max(p.excess-1, 0)
+	p.size--
+}
+
+// retireIdle tira um worker ocioso se o pool está acima de MinWorkers e a
+// fila está vazia. A fila é conferida com sizeMu para Submit não ficar sem
+// worker.
+func (p *WorkerPool) retireIdle() bool {
+	p.sizeMu.Lock()
+	defer p.sizeMu.Unlock()
+	if p.size <= p.cfg.MinWorkers || p.queue.len() > 0 {
+		return false
+	}
+	p.excess = max(p.excess-1, 0)
+	p.size--
+	return true
+}
+
+func (p *WorkerPool) worker() {
+	defer p.wg.Done()
+	var idle *time.Timer
+	if p.cfg.IdleTimeout > 0 {
+		idle = time.NewTimer(p.cfg.IdleTimeout)
+		defer idle.Stop()
+	}
+	for {
+		var timeout <-chan time.Time
+		if idle != nil {
+			idle.Reset(p.cfg.IdleTimeout)
+			timeout = idle.C
+		}
+		// sai se sobram workers depois de um Resize, sem deixar tarefas na
+		// fila sem worker; a conferência e a leitura de resized acontecem
+		// juntas para não perder o aviso
+		p.sizeMu.Lock()
+		if p.excess > 0 && (p.size > 1 || p.queue.len() == 0) {
+			p.excess--
+			p.size--
+			p.sizeMu.Unlock()
+			return
+		}
+		resized := p.resized
+		p.sizeMu.Unlock()
+
+		select {
+		case _, ok := <-p.ready:
+			if !ok || p.ctx.Err() != nil {
+				// fila fechada e vazia, ou StopNow: as tarefas que
+				// sobraram ficam na fila para StopNow devolver
+				p.exit()
+				return
+			}
+			j, class := p.queue.pop()
+			<-p.slots[class]
+			if pe := runTask(j.fn); pe != nil {
+				// a goroutine que entrou em panic dá lugar a outra, e o pool
+				// mantém a capacidade
+				p.panicked(pe)
+				if p.ctx.Err() == nil {
+					p.wg.Add(1)
+					go p.worker()
 					return
 				}
 			}
-		}()
+		case <-p.ctx.Done():
+			p.exit()
+			return
+		case <-resized:
+		case <-timeout:
+			if p.retireIdle() {
+				return
+			}
+		}
 	}
 }
 
+var (
+	// ErrPoolStopped é devolvido por Submit depois de Shutdown ou StopNow e
+	// pelas futures que o pool não chegou a resolver
+	ErrPoolStopped = errors.New("pool está parando")
+	// ErrPoolFailed é devolvido por Submit, Shutdown e pelas futures
+	// pendentes depois que os panics passam de PoolConfig.MaxPanics
+	ErrPoolFailed = errors.New("pool falhou")
+)
+
+// Err devolve o erro que fez o pool falhar, que satisfaz
+// errors.Is(err, ErrPoolFailed) e traz o último *PanicError, ou nil
+func (p *WorkerPool) Err() error {
+	p.panicMu.Lock()
+	defer p.panicMu.Unlock()
+	return p.failure
+}
+
+// stopErr é o erro de uma operação no pool parado
+func (p *WorkerPool) stopErr() error {
+	if err := p.Err(); err != nil {
+		return err
+	}
+	return ErrPoolStopped
+}
+
+// runTask executa fn, transformando um panic em *PanicError, como
+// SafeGoroutine
+func runTask(fn func()) (pe *PanicError) {
+	defer func() {
+		if r := recover(); r != nil {
+			pe = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	fn()
+	return nil
+}
+
+// panicked informa pe ao PanicHandler e aplica a política de panics
+func (p *WorkerPool) panicked(pe *PanicError) {
+	if p.cfg.PanicHandler != nil {
+		p.cfg.PanicHandler(pe)
+	} else {
+		log.Printf("worker pool: %v\n%s", pe, pe.Stack)
+	}
+	if p.cfg.MaxPanics <= 0 {
+		return
+	}
+
+	p.panicMu.Lock()
+	now := time.Now()
+	p.panics = append(p.panics, now)
+	if p.cfg.PanicWindow > 0 {
+		p.panics = slices.DeleteFunc(p.panics, func(t time.Time) bool { return now.Sub(t) > p.cfg.PanicWindow })
+	}
+	failed := p.failure == nil && len(p.panics) > p.cfg.MaxPanics
+	if failed {
+		p.failure = fmt.Errorf("%w: %d panics, o último: %w", ErrPoolFailed, len(p.panics), pe)
+	}
+	p.panicMu.Unlock()
+	if failed {
+		p.cancel()
+		p.close()
+	}
+}
+
+// Submit enfileira task na classe de prioridade mais baixa, esperando se a
+// fila estiver cheia. Depois de Shutdown ou StopNow, devolve
+// ErrPoolStopped.
 func (p *WorkerPool) Submit(task func()) error {
-	if p.stopping.Load() {
-		return fmt.Errorf("pool está parando")
+	return p.SubmitWithPriority(p.cfg.Priorities-1, task)
+}
+
+// SubmitWithPriority enfileira task na classe priority, de 0 (a mais alta)
+// a PoolConfig.Priorities-1, esperando se a fila da classe estiver cheia
+func (p *WorkerPool) SubmitWithPriority(priority int, task func()) error {
+	if priority < 0 || priority >= p.cfg.Priorities {
+		return fmt.Errorf("prioridade %d fora de [0, %d]", priority, p.cfg.Priorities-1)
 	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
 	select {
-	case p.tasks <- task:
-		return nil
-	case <-p.ctx.Done():
-		return p.ctx.Err()
+	case <-p.closing:
+		return p.stopErr()
+	default:
+	}
+	select {
+	case p.slots[priority] <- struct{}{}:
+	case <-p.closing:
+		return p.stopErr()
+	}
+	p.queue.push(priority, job{fn: task, at: time.Now()})
+	p.ready <- struct{}{} // nunca bloqueia: ready comporta todos os slots
+	p.scaleUp(false)
+	if p.cfg.ScaleUpWait > 0 {
+		time.AfterFunc(p.cfg.ScaleUpWait, p.scaleUpWaiting)
 	}
+	return nil
 }
 
-func (p *WorkerPool) Stop() {
-	p.stopping.Store(true)
+// Shutdown recusa tarefas novas, executa as que já estão na fila e retorna
+// quando todas terminam ou quando ctx termina, com o erro de ctx. Nesse
+// caso os workers continuam; StopNow os interrompe. Se o pool falhou,
+// devolve Err.
+func (p *WorkerPool) Shutdown(ctx context.Context) error {
+	p.close()
+	select {
+	case <-p.wait():
+		p.cancel()
+		return p.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// StopNow cancela o pool sem esperar a fila, aguarda as tarefas em
+// execução e devolve as que não chegaram a rodar, da mais prioritária para
+// a menos
+func (p *WorkerPool) StopNow() []func() {
 	p.cancel()
-	close(p.tasks)
-	p.wg.Wait()
+	p.close()
+	<-p.wait()
+	var pending []func()
+	for _, j := range p.queue.drain() {
+		pending = append(pending, j.fn)
+	}
+	return pending
+}
+
+// Stop é StopNow descartando as tarefas que não rodaram
+func (p *WorkerPool) Stop() {
+	p.StopNow()
+}
+
+// close recusa tarefas novas e fecha ready. Submits bloqueados na fila
+// cheia são liberados por closing antes de close pegar o Lock.
+func (p *WorkerPool) close() {
+	p.closeOnce.Do(func() {
+		close(p.closing)
+		p.mu.Lock()
+		close(p.ready)
+		p.mu.Unlock()
+	})
+}
+
+// wait devolve um canal fechado quando todos os workers terminam
+func (p *WorkerPool) wait() <-chan struct{} {
+	p.waitOnce.Do(func() {
+		go func() {
+			p.wg.Wait()
+			close(p.done)
+		}()
+	})
+	return p.done
+}
+
+// taskQueue guarda as tarefas de cada classe em ordem de chegada e escolhe
+// a próxima segundo a política do pool
+type taskQueue struct {
+	mu      sync.Mutex
+	classes [][]job
+	n       int
+
+	scheduling Scheduling
+	weights    []int
+	aging      time.Duration
+	credit     []int // créditos do round-robin ponderado suave
+}
+
+func newTaskQueue(cfg PoolConfig) *taskQueue {
+	return &taskQueue{
+		classes:    make([][]job, cfg.Priorities),
+		scheduling: cfg.Scheduling,
+		weights:    cfg.Weights,
+		aging:      cfg.AgingInterval,
+		credit:     make([]int, cfg.Priorities),
+	}
+}
+
+func (q *taskQueue) push(class int, j job) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.classes[class] = append(q.classes[class], j)
+	q.n++
+}
+
+// pop tira a próxima tarefa; só é chamado com a fila não vazia
+func (q *taskQueue) pop() (job, int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	class := q.next(time.Now())
+	j := q.classes[class][0]
+	q.classes[class][0] = job{}
+	q.classes[class] = q.classes[class][1:]
+	q.n--
+	return j, class
+}
+
+// next escolhe a classe atendida. Cada classe concorre pela prioridade da
+// tarefa mais antiga, já envelhecida: Strict escolhe a mais alta (no
+// empate, a tarefa mais antiga), e WeightedFair distribui a vez pelos pesos
+// com round-robin ponderado suave.
+func (q *taskQueue) next(now time.Time) int {
+	best, bestClass, total := -1, 0, 0
+	var bestAt time.Time
+	for c, jobs := range q.classes {
+		if len(jobs) == 0 {
+			continue
+		}
+		effective := c
+		if q.aging > 0 {
+			effective = max(c-int(now.Sub(jobs[0].at)/q.aging), 0)
+		}
+		if q.scheduling == WeightedFair {
+			q.credit[c] += q.weights[effective]
+			total += q.weights[effective]
+			if best < 0 || q.credit[c] > q.credit[best] {
+				best = c
+			}
+		} else if best < 0 || effective < bestClass || effective == bestClass && jobs[0].at.Before(bestAt) {
+			best, bestClass, bestAt = c, effective, jobs[0].at
+		}
+	}
+	if q.scheduling == WeightedFair {
+		q.credit[best] -= total
+	}
+	return best
+}
+
+// oldest devolve a chegada da tarefa mais antiga da fila
+func (q *taskQueue) oldest() (time.Time, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var at time.Time
+	for _, jobs := range q.classes {
+		if len(jobs) > 0 && (at.IsZero() || jobs[0].at.Before(at)) {
+			at = jobs[0].at
+		}
+	}
+	return at, !at.IsZero()
+}
+
+func (q *taskQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.n
+}
+
+func (q *taskQueue) depths() []int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	depths := make([]int, len(q.classes))
+	for c, jobs := range q.classes {
+		depths[c] = len(jobs)
+	}
+	return depths
+}
+
+// drain esvazia a fila, da classe mais prioritária para a menos
+func (q *taskQueue) drain() []job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var jobs []job
+	for c := range q.classes {
+		jobs = append(jobs, q.classes[c]...)
+		q.classes[c] = nil
+	}
+	q.n = 0
+	return jobs
+}
+
+// Future é o resultado de uma tarefa enviada com SubmitFunc
+type Future[T any] struct {
+	done  chan struct{}
+	once  sync.Once
+	value T
+	err   error
+}
+
+// Wait espera o resultado da tarefa. Se ctx terminar antes, devolve o erro
+// de ctx; a tarefa continua no pool e Wait pode ser chamado de novo.
+func (f *Future[T]) Wait(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// resolve guarda o primeiro resultado; os seguintes são ignorados
+func (f *Future[T]) resolve(value T, err error) {
+	f.once.Do(func() {
+		f.value, f.err = value, err
+		close(f.done)
+	})
+}
+
+// PanicError é o erro de uma tarefa que entrou em panic
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic recuperado: %v", e.Value)
+}
+
+// SubmitFunc envia ao pool uma tarefa que devolve um valor, em vez de cada
+// chamador montar os próprios canais. A tarefa recebe o contexto do pool.
+// Um panic vira um *PanicError. Shutdown espera as futures da fila; StopNow
+// resolve com ErrPoolStopped as das tarefas que ainda não terminaram, e a
+// falha do pool, com Err.
+func SubmitFunc[T any](p *WorkerPool, task func(ctx context.Context) (T, error)) *Future[T] {
+	f := &Future[T]{done: make(chan struct{})}
+	var zero T
+	// resolve a future se o pool parar antes da tarefa terminar, inclusive
+	// se ela ainda estiver na fila
+	stop := context.AfterFunc(p.ctx, func() { f.resolve(zero, p.stopErr()) })
+
+	err := p.Submit(func() {
+		defer stop()
+		if p.ctx.Err() != nil {
+			return // a future foi resolvida quando o pool parou
+		}
+		// o panic vai para a future, não para o PanicHandler do pool
+		if pe := runTask(func() { f.resolve(task(p.ctx)) }); pe != nil {
+			f.resolve(zero, pe)
+		}
+	})
+	if err != nil {
+		stop()
+		f.resolve(zero, err)
+	}
+	return f
 }
 
 // SafeCounter implementa contador thread-safe
@@ -197,8 +792,10 @@ func (b *BatchProcessor) Process(items []int) error {
 	return nil
 }
 
-func (b *BatchProcessor) Stop() {
-	b.pool.Stop()
+// Stop processa os lotes já enviados, para o pool e devolve o erro de
+// Shutdown (veja WorkerPool.Err)
+func (b *BatchProcessor) Stop() error {
+	return b.pool.Shutdown(context.Background())
 }
 
 // AvoidDeadlock demonstra como evitar deadlock usando canais com buffer
@@ -0,0 +1,516 @@
+package goroutines
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSubmitFunc(t *testing.T) {
+	pool := NewWorkerPool(2)
+	defer pool.Stop()
+	ctx := context.Background()
+
+	sum := SubmitFunc(pool, func(context.Context) (int, error) { return 1 + 2, nil })
+	if v, err := sum.Wait(ctx); v != 3 || err != nil {
+		t.Errorf("Wait = %d, %v; esperado 3, nil", v, err)
+	}
+	// o resultado continua disponível
+	if v, err := sum.Wait(ctx); v != 3 || err != nil {
+		t.Errorf("segundo Wait = %d, %v", v, err)
+	}
+
+	errTask := errors.New("falhou")
+	failed := SubmitFunc(pool, func(context.Context) (string, error) { return "", errTask })
+	if _, err := failed.Wait(ctx); !errors.Is(err, errTask) {
+		t.Errorf("erro da tarefa = %v", err)
+	}
+
+	panicked := SubmitFunc(pool, func(context.Context) (int, error) { panic("boom") })
+	_, err := panicked.Wait(ctx)
+	var pe *PanicError
+	if !errors.As(err, &pe) || pe.Value != "boom" || !strings.Contains(string(pe.Stack), "workerpool_test.go") {
+		t.Errorf("panic = %v", err)
+	}
+
+	// o pool segue funcionando depois do panic
+	if v, err := SubmitFunc(pool, func(context.Context) (int, error) { return 42, nil }).Wait(ctx); v != 42 || err != nil {
+		t.Errorf("depois do panic: %d, %v", v, err)
+	}
+}
+
+func TestFutureWaitTimeout(t *testing.T) {
+	pool := NewWorkerPool(1)
+	defer pool.Stop()
+	release := make(chan struct{})
+	f := SubmitFunc(pool, func(context.Context) (int, error) {
+		<-release
+		return 7, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := f.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait com prazo esgotado = %v", err)
+	}
+	close(release)
+	if v, err := f.Wait(context.Background()); v != 7 || err != nil {
+		t.Errorf("Wait depois de liberar = %d, %v", v, err)
+	}
+}
+
+func TestSubmitFuncStop(t *testing.T) {
+	pool := NewWorkerPool(1)
+	started, release := make(chan struct{}), make(chan struct{})
+	running := SubmitFunc(pool, func(context.Context) (int, error) {
+		close(started)
+		<-release // ignora o cancelamento de propósito
+		return 1, nil
+	})
+	<-started
+	queued := SubmitFunc(pool, func(context.Context) (int, error) {
+		t.Error("tarefa na fila executada depois de Stop")
+		return 2, nil
+	})
+
+	stopped := make(chan []func())
+	go func() { stopped <- pool.StopNow() }()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for name, f := range map[string]*Future[int]{"em execução": running, "na fila": queued} {
+		if _, err := f.Wait(ctx); !errors.Is(err, ErrPoolStopped) {
+			t.Errorf("future %s = %v, esperado ErrPoolStopped", name, err)
+		}
+	}
+	close(release)
+	// a tarefa da fila volta de StopNow, mas executá-la não muda a future
+	for _, task := range <-stopped {
+		task()
+	}
+	if _, err := queued.Wait(ctx); !errors.Is(err, ErrPoolStopped) {
+		t.Errorf("future da tarefa devolvida = %v", err)
+	}
+
+	late := SubmitFunc(pool, func(context.Context) (int, error) { return 3, nil })
+	if _, err := late.Wait(ctx); !errors.Is(err, ErrPoolStopped) {
+		t.Errorf("future depois de Stop = %v", err)
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	pool := NewWorkerPool(2)
+	// com zero workers, a fila ainda é executada
+	if err := pool.Resize(0); err != nil {
+		t.Fatal(err)
+	}
+	var ran atomic.Int64
+	for i := 0; i < 20; i++ {
+		if err := pool.Submit(func() {
+			time.Sleep(time.Millisecond)
+			ran.Add(1)
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := ran.Load(); n != 20 {
+		t.Errorf("%d tarefas executadas, esperado 20", n)
+	}
+	if err := pool.Submit(func() {}); !errors.Is(err, ErrPoolStopped) {
+		t.Errorf("Submit depois de Shutdown = %v", err)
+	}
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Errorf("segundo Shutdown = %v", err)
+	}
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	pool := NewWorkerPool(1)
+	release := make(chan struct{})
+	pool.Submit(func() { <-release })
+	pool.Submit(func() {})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := pool.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown com prazo esgotado = %v", err)
+	}
+	close(release)
+	if pending := pool.StopNow(); len(pending) > 1 {
+		t.Errorf("StopNow devolveu %d tarefas, esperado no máximo 1", len(pending))
+	}
+}
+
+func TestStopNow(t *testing.T) {
+	pool := NewWorkerPool(1)
+	started, release := make(chan struct{}), make(chan struct{})
+	pool.Submit(func() {
+		close(started)
+		<-release
+	})
+	<-started
+	var ran atomic.Int64
+	for i := 0; i < 2; i++ {
+		pool.Submit(func() { ran.Add(1) })
+	}
+
+	stopped := make(chan []func())
+	go func() { stopped <- pool.StopNow() }()
+	// com a fila cheia, Submit espera até StopNow fechar o pool
+	if err := pool.Submit(func() { ran.Add(1) }); !errors.Is(err, ErrPoolStopped) {
+		t.Errorf("Submit durante StopNow = %v", err)
+	}
+	// a tarefa em execução termina; as da fila voltam sem rodar
+	close(release)
+	if pending := <-stopped; len(pending) != 2 {
+		t.Errorf("StopNow devolveu %d tarefas, esperado 2", len(pending))
+	}
+	if n := ran.Load(); n != 0 {
+		t.Errorf("%d tarefas da fila executadas depois de StopNow", n)
+	}
+}
+
+// Submit concorrente com o fechamento nunca entra em panic: ou a tarefa é
+// aceita, ou Submit devolve ErrPoolStopped. Com Shutdown, toda tarefa
+// aceita é executada.
+func TestSubmitDuringShutdown(t *testing.T) {
+	for _, graceful := range []bool{true, false} {
+		pool := NewWorkerPool(2)
+		var wg sync.WaitGroup
+		var accepted, ran atomic.Int64
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					if err := pool.Submit(func() { ran.Add(1) }); err == nil {
+						accepted.Add(1)
+					} else if !errors.Is(err, ErrPoolStopped) {
+						t.Error(err)
+					}
+				}
+			}()
+		}
+		var pending int64
+		if graceful {
+			if err := pool.Shutdown(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+		} else {
+			pending = int64(len(pool.StopNow()))
+		}
+		wg.Wait()
+		if ran.Load()+pending != accepted.Load() {
+			t.Errorf("graceful=%v: %d executadas e %d devolvidas, mas %d aceitas", graceful, ran.Load(), pending, accepted.Load())
+		}
+	}
+}
+
+// mustPool cria um pool com NewPool, encerrando o teste se cfg for inválida
+func mustPool(tb testing.TB, cfg PoolConfig) *WorkerPool {
+	tb.Helper()
+	pool, err := NewPool(cfg)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return pool
+}
+
+// eventually espera cond ficar verdadeira por até um segundo
+func eventually(t *testing.T, cond func() bool, format string, args ...any) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); !cond(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf(format, args...)
+		}
+	}
+}
+
+func TestAutoscaling(t *testing.T) {
+	pool := mustPool(t, PoolConfig{MinWorkers: 1, MaxWorkers: 4, ScaleUpQueue: 1, IdleTimeout: 20 * time.Millisecond})
+	defer pool.Stop()
+	if n := pool.Workers(); n != 1 {
+		t.Fatalf("%d workers no início, esperado 1", n)
+	}
+
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		if err := pool.Submit(func() {
+			defer wg.Done()
+			<-release
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := pool.Workers(); n != 4 {
+		t.Errorf("%d workers com a fila cheia, esperado 4", n)
+	}
+	eventually(t, func() bool { return pool.QueueLen() == 4 }, "%d tarefas na fila, esperado 4", pool.QueueLen())
+	close(release)
+	wg.Wait()
+	eventually(t, func() bool { return pool.Workers() == 1 }, "workers ociosos não saíram: %d", pool.Workers())
+}
+
+func TestScaleUpWait(t *testing.T) {
+	pool := mustPool(t, PoolConfig{MinWorkers: 1, MaxWorkers: 3, QueueSize: 10, ScaleUpWait: time.Millisecond})
+	defer pool.Stop()
+	for i := 0; i < 6; i++ {
+		pool.Submit(func() { time.Sleep(5 * time.Millisecond) })
+	}
+	eventually(t, func() bool { return pool.Workers() == 3 }, "espera na fila não criou workers: %d", pool.Workers())
+}
+
+// com o único worker bloqueado, nenhuma tarefa sai da fila; o pool cresce
+// mesmo assim quando a tarefa da fila passa de ScaleUpWait
+func TestScaleUpWaitBlocked(t *testing.T) {
+	pool := mustPool(t, PoolConfig{MinWorkers: 1, MaxWorkers: 2, QueueSize: 10, ScaleUpWait: 10 * time.Millisecond})
+	defer pool.Stop()
+	block, started, ran := make(chan struct{}), make(chan struct{}), make(chan struct{})
+	defer close(block)
+	pool.Submit(func() { close(started); <-block })
+	<-started
+	pool.Submit(func() { close(ran) })
+	eventually(t, func() bool { return pool.Workers() == 2 }, "tarefa esperando não criou worker: %d", pool.Workers())
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("tarefa da fila não rodou no worker novo")
+	}
+}
+
+func TestScaleFromZero(t *testing.T) {
+	pool := mustPool(t, PoolConfig{MaxWorkers: 2, IdleTimeout: 5 * time.Millisecond})
+	defer pool.Stop()
+	if n := pool.Workers(); n != 0 {
+		t.Fatalf("%d workers no início, esperado 0", n)
+	}
+	for i := 0; i < 3; i++ {
+		v, err := SubmitFunc(pool, func(context.Context) (int, error) { return i, nil }).Wait(context.Background())
+		if v != i || err != nil {
+			t.Fatalf("Wait = %d, %v", v, err)
+		}
+		eventually(t, func() bool { return pool.Workers() == 0 }, "worker ocioso não saiu")
+	}
+}
+
+func TestResize(t *testing.T) {
+	pool := NewWorkerPool(4)
+	if err := pool.Resize(1); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, func() bool { return pool.Workers() == 1 }, "Resize(1) deixou %d workers", pool.Workers())
+	if err := pool.Resize(6); err != nil {
+		t.Fatal(err)
+	}
+	if n := pool.Workers(); n != 6 {
+		t.Errorf("Resize(6) deixou %d workers", n)
+	}
+
+	// com zero workers, a fila ainda é executada
+	if err := pool.Resize(0); err != nil {
+		t.Fatal(err)
+	}
+	var ran atomic.Int64
+	for i := 0; i < 20; i++ {
+		pool.Submit(func() { ran.Add(1) })
+	}
+	if err := pool.Resize(-1); err == nil {
+		t.Error("Resize(-1) aceito")
+	}
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := ran.Load(); n != 20 {
+		t.Errorf("%d tarefas executadas, esperado 20", n)
+	}
+	if n := pool.Workers(); n != 0 {
+		t.Errorf("%d workers depois de Shutdown", n)
+	}
+	if err := pool.Resize(2); !errors.Is(err, ErrPoolStopped) {
+		t.Errorf("Resize depois de Shutdown = %v", err)
+	}
+}
+
+// runInOrder bloqueia o único worker do pool, enfileira uma tarefa por
+// classe em classes e devolve a ordem em que as classes foram atendidas
+func runInOrder(t *testing.T, pool *WorkerPool, classes []int, beforeRelease func()) []int {
+	t.Helper()
+	started, release := make(chan struct{}), make(chan struct{})
+	pool.SubmitWithPriority(0, func() {
+		close(started)
+		<-release
+	})
+	<-started
+
+	var mu sync.Mutex
+	var order []int
+	for _, c := range classes {
+		if err := pool.SubmitWithPriority(c, func() {
+			mu.Lock()
+			order = append(order, c)
+			mu.Unlock()
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if beforeRelease != nil {
+		beforeRelease()
+	}
+	close(release)
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return order
+}
+
+func TestStrictPriority(t *testing.T) {
+	pool := mustPool(t, PoolConfig{MinWorkers: 1, Priorities: 3, QueueSize: 4})
+	order := runInOrder(t, pool, []int{2, 2, 0, 1, 2, 0}, func() {
+		if got := pool.QueueDepths(); !slices.Equal(got, []int{2, 1, 3}) {
+			t.Errorf("QueueDepths = %v, esperado [2 1 3]", got)
+		}
+	})
+	if want := []int{0, 0, 1, 2, 2, 2}; !slices.Equal(order, want) {
+		t.Errorf("ordem = %v, esperado %v", order, want)
+	}
+	if err := mustPool(t, PoolConfig{Priorities: 2}).SubmitWithPriority(2, func() {}); err == nil {
+		t.Error("prioridade fora do intervalo aceita")
+	}
+}
+
+func TestPoolConfigInvalid(t *testing.T) {
+	for _, cfg := range []PoolConfig{
+		{Priorities: 3, Weights: []int{2, 1}},
+		{Weights: []int{1, 1}},
+		{Priorities: 2, Weights: []int{1, 0}},
+	} {
+		if pool, err := NewPool(cfg); err == nil {
+			pool.Stop()
+			t.Errorf("NewPool(%+v) aceitou pesos inválidos", cfg)
+		}
+	}
+}
+
+func TestWeightedFair(t *testing.T) {
+	pool := mustPool(t, PoolConfig{MinWorkers: 1, Priorities: 2, QueueSize: 8, Scheduling: WeightedFair, Weights: []int{3, 1}})
+	var classes []int
+	for i := 0; i < 8; i++ {
+		classes = append(classes, 1, 0)
+	}
+	order := runInOrder(t, pool, classes, nil)
+	// a cada 4 tarefas, 3 da classe 0 e 1 da classe 1; a classe 1 não
+	// espera a 0 esvaziar
+	for i := 0; i+4 <= 8; i += 4 {
+		if n := slices.Index(order[i:i+4], 1); n < 0 || slices.Contains(order[i+n+1:i+4], 1) {
+			t.Errorf("ordem = %v, esperado uma tarefa da classe 1 a cada 4", order)
+			break
+		}
+	}
+}
+
+func TestAging(t *testing.T) {
+	pool := mustPool(t, PoolConfig{MinWorkers: 1, Priorities: 3, QueueSize: 4, AgingInterval: 10 * time.Millisecond})
+	started, release := make(chan struct{}), make(chan struct{})
+	pool.Submit(func() {
+		close(started)
+		<-release
+	})
+	<-started
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+		}
+	}
+	pool.SubmitWithPriority(2, record("antiga"))
+	// duas classes acima depois de 20ms, a tarefa antiga empata com a nova
+	// de classe 0 e vence por ser mais antiga
+	time.Sleep(25 * time.Millisecond)
+	pool.SubmitWithPriority(0, record("nova"))
+	close(release)
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"antiga", "nova"}; !slices.Equal(order, want) {
+		t.Errorf("ordem = %v, esperado %v", order, want)
+	}
+}
+
+func TestPanicIsolation(t *testing.T) {
+	panics := make(chan *PanicError, 1)
+	pool := mustPool(t, PoolConfig{MinWorkers: 2, PanicHandler: func(pe *PanicError) { panics <- pe }})
+	defer pool.Stop()
+
+	if err := pool.Submit(func() { panic("boom") }); err != nil {
+		t.Fatal(err)
+	}
+	pe := <-panics
+	if pe.Value != "boom" || !strings.Contains(string(pe.Stack), "workerpool_test.go") {
+		t.Errorf("PanicHandler recebeu %v\n%s", pe, pe.Stack)
+	}
+	// o worker que entrou em panic foi substituído
+	if n := pool.Workers(); n != 2 {
+		t.Errorf("%d workers depois do panic, esperado 2", n)
+	}
+	if v, err := SubmitFunc(pool, func(context.Context) (int, error) { return 1, nil }).Wait(context.Background()); v != 1 || err != nil {
+		t.Errorf("depois do panic: %d, %v", v, err)
+	}
+	if err := pool.Err(); err != nil {
+		t.Errorf("Err = %v sem política de panics", err)
+	}
+}
+
+func TestPanicPolicy(t *testing.T) {
+	pool := mustPool(t, PoolConfig{MinWorkers: 1, MaxPanics: 2, PanicWindow: time.Minute, PanicHandler: func(*PanicError) {}})
+	for i := 0; i < 3; i++ {
+		if err := pool.Submit(func() { panic(i) }); err != nil {
+			t.Fatal(err)
+		}
+	}
+	eventually(t, func() bool { return pool.Err() != nil }, "pool não falhou depois de 3 panics")
+
+	err := pool.Err()
+	var pe *PanicError
+	if !errors.Is(err, ErrPoolFailed) || !errors.As(err, &pe) || pe.Value != 2 {
+		t.Errorf("Err = %v", err)
+	}
+	if err := pool.Submit(func() {}); !errors.Is(err, ErrPoolFailed) {
+		t.Errorf("Submit depois da falha = %v", err)
+	}
+	if _, err := SubmitFunc(pool, func(context.Context) (int, error) { return 1, nil }).Wait(context.Background()); !errors.Is(err, ErrPoolFailed) {
+		t.Errorf("future depois da falha = %v", err)
+	}
+	if err := pool.Shutdown(context.Background()); !errors.Is(err, ErrPoolFailed) {
+		t.Errorf("Shutdown depois da falha = %v", err)
+	}
+}
+
+func TestPanicWindow(t *testing.T) {
+	pool := mustPool(t, PoolConfig{MinWorkers: 1, MaxPanics: 1, PanicWindow: 10 * time.Millisecond, PanicHandler: func(*PanicError) {}})
+	defer pool.Stop()
+	for i := 0; i < 3; i++ {
+		done := make(chan struct{})
+		pool.Submit(func() {
+			defer close(done)
+			panic(i)
+		})
+		<-done
+		time.Sleep(20 * time.Millisecond) // o panic anterior sai da janela
+	}
+	if err := pool.Err(); err != nil {
+		t.Errorf("panics espaçados fizeram o pool falhar: %v", err)
+	}
+}
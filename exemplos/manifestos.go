@@ -0,0 +1,11 @@
+// Package exemplos embute os manifestos dos tópicos, para que ferramentas
+// usadas fora do repositório (como os analisadores) liguem seus achados às
+// mesmas lições do catálogo.
+package exemplos
+
+import "embed"
+
+// Manifests contém <nível>/<categoria>/manifesto.json de cada tópico
+//
+//go:embed */*/manifesto.json
+var Manifests embed.FS
@@ -0,0 +1,177 @@
+// Package exercise transforma um tópico do FubanGo em um exercício de
+// refatoração.
+//
+// Start copia o ruim.go do tópico para um workspace separado, sem o bom.go,
+// com o go.mod necessário para compilá-lo sozinho. O aluno reescreve o
+// arquivo mantendo os nomes das declarações, e a correção (fubango exercise
+// check) avalia cada anti-padrão do analise.md separadamente, com um Result
+// por seção.
+package exercise
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lucasrafaldini/fubango/catalog"
+	"golang.org/x/mod/modfile"
+)
+
+// Arquivos do workspace
+const (
+	StateFile        = ".fubango-exercicio.json"
+	InstructionsFile = "EXERCICIO.md"
+)
+
+// Workspace é um exercício em andamento
+type Workspace struct {
+	Dir     string    `json:"-"`
+	Topic   string    `json:"topic"` // ID do tópico, ex: 03-avancado/goroutines
+	Root    string    `json:"root"`  // raiz absoluta do repositório FubanGo
+	Started time.Time `json:"started"`
+}
+
+// File é o caminho do arquivo do aluno
+func (w *Workspace) File() string {
+	return filepath.Join(w.Dir, catalog.RuimFile)
+}
+
+// Start cria o workspace do tópico em dir. O diretório não pode existir,
+// a menos que force seja verdadeiro; nesse caso o ruim.go é sobrescrito.
+func Start(root string, topic *catalog.Topic, dir string, force bool) (*Workspace, error) {
+	if _, err := os.Stat(dir); err == nil && !force {
+		return nil, fmt.Errorf("%s já existe; use -force para recomeçar o exercício", dir)
+	} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	src, err := os.ReadFile(filepath.Join(root, topic.Dir, catalog.RuimFile))
+	if err != nil {
+		return nil, err
+	}
+	// um ruim.go excluído do build (//go:build ignore) precisa compilar no
+	// exercício, ou nada poderia ser avaliado
+	src = dropBuildConstraint(src)
+	if err := os.WriteFile(filepath.Join(dir, catalog.RuimFile), src, 0o644); err != nil {
+		return nil, err
+	}
+	if err := writeModule(root, dir, topic); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, InstructionsFile), []byte(Instructions(topic)), 0o644); err != nil {
+		return nil, err
+	}
+
+	w := &Workspace{Dir: dir, Topic: topic.ID(), Root: absRoot, Started: time.Now().UTC().Truncate(time.Second)}
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return w, os.WriteFile(filepath.Join(dir, StateFile), append(data, '\n'), 0o644)
+}
+
+// Open lê o workspace em dir
+func Open(dir string) (*Workspace, error) {
+	data, err := os.ReadFile(filepath.Join(dir, StateFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%s não é um workspace de exercício (falta %s); comece com \"fubango exercise start\"", dir, StateFile)
+	}
+	if err != nil {
+		return nil, err
+	}
+	w := &Workspace{Dir: dir}
+	if err := json.Unmarshal(data, w); err != nil {
+		return nil, fmt.Errorf("%s: %w", StateFile, err)
+	}
+	return w, nil
+}
+
+// writeModule cria em dir um módulo próprio com as mesmas dependências do
+// repositório, para que o workspace compile fora dele
+func writeModule(root, dir string, topic *catalog.Topic) error {
+	data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return err
+	}
+	mod, err := modfile.Parse("go.mod", data, nil)
+	if err != nil {
+		return err
+	}
+	out := new(modfile.File)
+	if err := out.AddModuleStmt("fubango-exercicio/" + topic.Category); err != nil {
+		return err
+	}
+	if mod.Go != nil {
+		if err := out.AddGoStmt(mod.Go.Version); err != nil {
+			return err
+		}
+	}
+	out.SetRequire(mod.Require)
+	out.Cleanup()
+	formatted, err := out.Format()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), formatted, 0o644); err != nil {
+		return err
+	}
+	sum, err := os.ReadFile(filepath.Join(root, "go.sum"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "go.sum"), sum, 0o644)
+}
+
+// dropBuildConstraint remove as linhas //go:build e // +build do cabeçalho
+func dropBuildConstraint(src []byte) []byte {
+	lines := strings.SplitAfter(string(src), "\n")
+	var out []string
+	header := true
+	for _, l := range lines {
+		trimmed := strings.TrimSpace(l)
+		if strings.HasPrefix(trimmed, "package ") {
+			header = false
+		}
+		if header && (strings.HasPrefix(trimmed, "//go:build") || strings.HasPrefix(trimmed, "// +build")) {
+			continue
+		}
+		out = append(out, l)
+	}
+	return []byte(strings.TrimLeft(strings.Join(out, ""), "\n"))
+}
+
+// Instructions é o EXERCICIO.md do workspace: o que refatorar, sem as
+// respostas do analise.md
+func Instructions(topic *catalog.Topic) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Exercício: %s\n\n", topic.Title)
+	fmt.Fprintf(&b, "Este `%s` é uma cópia do exemplo ruim de `%s`. Refatore-o corrigindo os anti-padrões abaixo. ", catalog.RuimFile, topic.Dir)
+	b.WriteString("Mantenha os nomes das declarações: é por eles que a correção encontra cada trecho. ")
+	b.WriteString("Parâmetros `context.Context`, `int`, `[]int` e canais podem ser acrescentados às funções.\n\n")
+	b.WriteString("Para avaliar, execute neste diretório:\n\n")
+	b.WriteString("```bash\nfubango exercise check\n```\n\n")
+	b.WriteString("## Anti-padrões\n\n")
+	for _, ap := range topic.AntiPatterns {
+		fmt.Fprintf(&b, "%d. %s", ap.Number, ap.Title)
+		if ap.Ruim.Found() {
+			fmt.Fprintf(&b, " - `%s`", ap.Ruim.Name)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,75 @@
+package exercise
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/catalog"
+)
+
+func TestStart(t *testing.T) {
+	root := filepath.Join("..")
+	c, err := catalog.Load(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	topic := c.Topic("variaveis")
+	dir := filepath.Join(t.TempDir(), "ex")
+	w, err := Start(root, topic, dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := os.ReadFile(w.File())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// o ruim.go de variaveis é excluído do build no repositório
+	if strings.Contains(string(src), "go:build") || !strings.HasPrefix(string(src), "package variaveis") {
+		t.Errorf("ruim.go começa com:\n%.120s", src)
+	}
+	if _, err := os.Stat(filepath.Join(dir, catalog.BomFile)); err == nil {
+		t.Error("bom.go foi copiado para o workspace")
+	}
+	mod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil || !strings.Contains(string(mod), "module fubango-exercicio/variaveis") {
+		t.Errorf("go.mod = %s, %v", mod, err)
+	}
+
+	opened, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opened.Topic != topic.ID() || !filepath.IsAbs(opened.Root) {
+		t.Errorf("Open = %+v", opened)
+	}
+	if _, err := Start(root, topic, dir, false); err == nil {
+		t.Error("Start sobrescreveu um workspace sem -force")
+	}
+	if _, err := Open(t.TempDir()); err == nil {
+		t.Error("Open aceitou um diretório sem workspace")
+	}
+}
+
+func TestResult(t *testing.T) {
+	topic := &catalog.Topic{Dir: "exemplos/03-avancado/goroutines"}
+	r := NewResult(topic, &catalog.AntiPattern{Number: 9, Title: "Deadlock com Canais", AnalysisLine: 213}, "")
+	if r.Link != "exemplos/03-avancado/goroutines/analise.md#L213" {
+		t.Errorf("Link = %q", r.Link)
+	}
+	if r.Graded() || r.Passed() {
+		t.Error("resultado sem verificações foi avaliado")
+	}
+	r.Add(CheckAnalyzers, Skip, "")
+	r.Add(CheckBehavior, Pass, "")
+	if !r.Graded() || !r.Passed() {
+		t.Errorf("resultado = %+v", r)
+	}
+	r.Add(CheckBenchmark, Fail, "2.0x mais lenta")
+
+	report := &Report{Results: []*Result{r, NewResult(topic, &catalog.AntiPattern{Number: 10}, "")}}
+	if passed, graded := report.Score(); passed != 0 || graded != 1 {
+		t.Errorf("Score = %d, %d", passed, graded)
+	}
+}
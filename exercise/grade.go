@@ -0,0 +1,97 @@
+package exercise
+
+import (
+	"fmt"
+
+	"github.com/lucasrafaldini/fubango/catalog"
+)
+
+// Status é o resultado de uma verificação
+type Status string
+
+const (
+	Pass Status = "ok"
+	Fail Status = "falhou"
+	Skip Status = "-" // a verificação não se aplica ao anti-padrão
+)
+
+// Verificações feitas pela correção
+const (
+	CheckCompile   = "compilação"
+	CheckDecl      = "declaração" // a declaração de ruim.go existe e foi alterada
+	CheckAnalyzers = "analisadores"
+	CheckBehavior  = "comportamento"
+	CheckBenchmark = "benchmark"
+)
+
+// Check é uma verificação de um anti-padrão
+type Check struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Result é a avaliação de um anti-padrão
+type Result struct {
+	Number int     `json:"number"`
+	Title  string  `json:"title"`
+	Symbol string  `json:"symbol,omitempty"` // declaração em ruim.go
+	Link   string  `json:"link"`             // seção em analise.md
+	Checks []Check `json:"checks"`
+}
+
+// NewResult começa a avaliação de ap. O link aponta para o título da seção
+// em analise.md, como no índice do README, com o prefixo base.
+func NewResult(topic *catalog.Topic, ap *catalog.AntiPattern, base string) *Result {
+	return &Result{
+		Number: ap.Number,
+		Title:  ap.Title,
+		Symbol: ap.Ruim.Name,
+		Link:   fmt.Sprintf("%s%s/%s#L%d", base, topic.Dir, catalog.AnalysisFile, ap.AnalysisLine),
+	}
+}
+
+// Add registra uma verificação
+func (r *Result) Add(name string, status Status, detail string) {
+	r.Checks = append(r.Checks, Check{Name: name, Status: status, Detail: detail})
+}
+
+// Graded informa se alguma verificação se aplicou ao anti-padrão
+func (r *Result) Graded() bool {
+	for _, c := range r.Checks {
+		if c.Status != Skip {
+			return true
+		}
+	}
+	return false
+}
+
+// Passed informa se o anti-padrão foi avaliado e nenhuma verificação falhou
+func (r *Result) Passed() bool {
+	for _, c := range r.Checks {
+		if c.Status == Fail {
+			return false
+		}
+	}
+	return r.Graded()
+}
+
+// Report é a correção de um workspace
+type Report struct {
+	Topic   string    `json:"topic"`
+	Title   string    `json:"title"`
+	Results []*Result `json:"results"`
+}
+
+// Score conta os anti-padrões corrigidos e os avaliados
+func (r *Report) Score() (passed, graded int) {
+	for _, res := range r.Results {
+		if res.Graded() {
+			graded++
+		}
+		if res.Passed() {
+			passed++
+		}
+	}
+	return passed, graded
+}
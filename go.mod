@@ -5,6 +5,10 @@ go 1.24.0
 toolchain go1.24.5
 
 require (
+	github.com/google/pprof v0.0.0-20250403155104-27863c87afa6
 	github.com/lib/pq v1.10.9
+	golang.org/x/exp v0.0.0-20251023183803-a4bb9ffd2546
+	golang.org/x/mod v0.29.0
 	golang.org/x/sync v0.17.0
+	golang.org/x/tools v0.38.0
 )
@@ -0,0 +1,69 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lucasrafaldini/fubango/bench"
+)
+
+// benchHarness é o benchmark que Bench insere no pacote
+const benchHarness = `package %s
+
+import (
+	"context"
+	"testing"
+)
+
+func BenchmarkFubango(b *testing.B) {
+	ctx := context.Background()
+	_ = ctx
+	for b.Loop() {
+		%s(%s)
+	}
+}
+`
+
+// BenchOptions controla uma medição de Bench
+type BenchOptions struct {
+	N         int    // valor dos parâmetros int e tamanho dos []int
+	Benchtime string // valor de -test.benchtime
+	Count     int    // número de execuções
+}
+
+// Bench compila o pacote em dir com um benchmark que chama fn em loop, com
+// os mesmos argumentos de Run, e devolve o resumo das Count execuções
+func Bench(dir, tmp string, fn *Func, opts BenchOptions) (bench.Summary, error) {
+	callArgs, err := fn.Args(opts.N)
+	if err != nil {
+		return bench.Summary{}, err
+	}
+	harness := fmt.Sprintf(benchHarness, fn.Package, fn.Name, callArgs) + IntsHelper
+	bin, err := Build(dir, tmp, fn, harness, false)
+	if err != nil {
+		return bench.Summary{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := bin.Command(ctx, "-test.run", "^$", "-test.bench", "^BenchmarkFubango$", "-test.benchmem",
+		"-test.benchtime", opts.Benchtime, "-test.count", fmt.Sprint(max(opts.Count, 1)))
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return bench.Summary{}, fmt.Errorf("medindo %s: %w\n%s", fn.Name, err, LastLines(stdout.String()+stderr.String(), 20))
+	}
+	out, err := bench.Parse(&stdout)
+	if err != nil {
+		return bench.Summary{}, err
+	}
+	sums := bench.Summarize(out.Results)
+	if len(sums) == 0 {
+		return bench.Summary{}, fmt.Errorf("medindo %s: nenhum resultado", fn.Name)
+	}
+	s := sums[0]
+	s.Name = fn.Name
+	return s, nil
+}
@@ -0,0 +1,109 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// HarnessFile é o nome com que o harness aparece no diretório do tópico
+// (apenas no overlay; nada é escrito lá)
+const HarnessFile = "fubango_harness_test.go"
+
+// singlePackage é o import path dos pacotes compilados a partir de uma
+// lista de arquivos
+const singlePackage = "command-line-arguments"
+
+// Binary é um binário de teste compilado por Build
+type Binary struct {
+	Path       string
+	Dir        string // diretório do tópico, usado como diretório de trabalho
+	ImportPath string // prefixo das funções do pacote nas pilhas e traces
+}
+
+// Build compila o pacote em dir com go test -c, acrescentando harness (se
+// não for vazio) como HarnessFile. Se o arquivo de fn é excluído por build
+// tags (como um ruim.go com //go:build ignore) ou o pacote não compila,
+// tenta compilar apenas o arquivo de fn com o harness. O binário e o
+// overlay ficam em tmp.
+func Build(dir, tmp string, fn *Func, harness string, race bool) (*Binary, error) {
+	bin := &Binary{Path: filepath.Join(tmp, fn.Name+".test"), Dir: dir}
+	args := []string{"test", "-c", "-o", bin.Path}
+	if race {
+		args = append(args, "-race")
+	}
+	files := []string{fn.File}
+	if harness != "" {
+		overlay, err := writeOverlay(dir, tmp, fn.Name, harness)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "-overlay", overlay)
+		files = append(files, HarnessFile)
+	}
+
+	var pkgErr error
+	if !fn.Excluded {
+		out, err := goCommand(dir, append(args, ".")...)
+		if err == nil {
+			bin.ImportPath, err = importPath(dir)
+			return bin, err
+		}
+		pkgErr = fmt.Errorf("compilando %s: %w\n%s", dir, err, out)
+	}
+	out, err := goCommand(dir, append(args, files...)...)
+	if err != nil {
+		if pkgErr != nil {
+			return nil, pkgErr
+		}
+		return nil, fmt.Errorf("compilando %s: %w\n%s", fn.File, err, out)
+	}
+	bin.ImportPath = singlePackage
+	return bin, nil
+}
+
+// Command prepara a execução do binário no diretório do tópico
+func (b *Binary) Command(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, b.Path, args...)
+	cmd.Dir = b.Dir
+	return cmd
+}
+
+// writeOverlay grava o harness em tmp e o arquivo de -overlay que o
+// coloca em dir como HarnessFile
+func writeOverlay(dir, tmp, name, harness string) (string, error) {
+	src := filepath.Join(tmp, name+"_harness.go")
+	if err := os.WriteFile(src, []byte(harness), 0o644); err != nil {
+		return "", err
+	}
+	target, err := filepath.Abs(filepath.Join(dir, HarnessFile))
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(map[string]any{"Replace": map[string]string{target: src}})
+	if err != nil {
+		return "", err
+	}
+	overlay := filepath.Join(tmp, name+".overlay.json")
+	return overlay, os.WriteFile(overlay, data, 0o644)
+}
+
+func goCommand(dir string, args ...string) (string, error) {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(out)), err
+}
+
+// importPath devolve o import path do pacote em dir
+func importPath(dir string) (string, error) {
+	out, err := goCommand(dir, "list", "-f", "{{.ImportPath}}", ".")
+	if err != nil {
+		return "", fmt.Errorf("go list em %s: %w\n%s", dir, err, out)
+	}
+	return out, nil
+}
@@ -0,0 +1,152 @@
+// Package runner executa funções dos exemplos do FubanGo em um binário de
+// teste separado.
+//
+// Muitas funções de ruim.go travam, vazam goroutines ou têm data races
+// quando chamadas, por isso ninguém as executa. O runner insere no pacote,
+// via go test -overlay e sem tocar nos arquivos do tópico, um teste que
+// chama a função com argumentos padrão, e observa o processo de fora: com
+// timeout, contagem de goroutines antes e depois e, opcionalmente, o race
+// detector.
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Func é uma função de pacote encontrada nos arquivos de um tópico
+type Func struct {
+	Name     string
+	File     string   // nome do arquivo, relativo ao diretório do tópico
+	Package  string   // nome do pacote declarado no arquivo
+	Params   []string // tipo de cada parâmetro
+	Excluded bool     // o arquivo é excluído do build por build tags
+}
+
+// Find procura a função name nos arquivos .go de dir, incluindo os de
+// teste (onde ficam os benchmarks). Se files não for vazio, apenas esses
+// arquivos são considerados. Tipos e métodos não podem ser executados; o
+// erro, nesse caso e quando name não existe, lista as funções de pacote
+// dos arquivos que não são de teste.
+func Find(dir, name string, files ...string) (*Func, error) {
+	if len(files) == 0 {
+		paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range paths {
+			files = append(files, filepath.Base(p))
+		}
+	}
+	fset := token.NewFileSet()
+	var runnable []string
+	problem := ""
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, file), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range f.Decls {
+			switch d := d.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.Name == name {
+						problem = fmt.Sprintf("%s (%s) é uma declaração de tipo, não executável", name, file)
+					}
+				}
+			case *ast.FuncDecl:
+				if d.Recv != nil {
+					if d.Name.Name == name || receiverType(d)+"."+d.Name.Name == name {
+						problem = fmt.Sprintf("%s (%s) é um método; só funções de pacote podem ser executadas", name, file)
+					}
+					continue
+				}
+				if !strings.HasSuffix(file, "_test.go") {
+					runnable = append(runnable, d.Name.Name)
+				}
+				if d.Name.Name == name {
+					return newFunc(dir, file, f, d)
+				}
+			}
+		}
+	}
+	if problem == "" {
+		problem = fmt.Sprintf("função %s não encontrada em %s", name, strings.Join(files, ", "))
+	}
+	if len(runnable) > 0 {
+		problem += "; funções executáveis: " + strings.Join(runnable, ", ")
+	}
+	return nil, errors.New(problem)
+}
+
+func newFunc(dir, file string, f *ast.File, fd *ast.FuncDecl) (*Func, error) {
+	match, err := build.Default.MatchFile(dir, file)
+	if err != nil {
+		return nil, err
+	}
+	fn := &Func{Name: fd.Name.Name, File: file, Package: f.Name.Name, Excluded: !match}
+	for _, field := range fd.Type.Params.List {
+		for range max(len(field.Names), 1) {
+			fn.Params = append(fn.Params, types.ExprString(field.Type))
+		}
+	}
+	return fn, nil
+}
+
+// receiverType devolve o nome do tipo do receptor de um método (sem * e
+// sem parâmetros de tipo)
+func receiverType(fd *ast.FuncDecl) string {
+	recv := strings.TrimPrefix(types.ExprString(fd.Recv.List[0].Type), "*")
+	name, _, _ := strings.Cut(recv, "[")
+	return name
+}
+
+// IsBenchmark informa se fn é um benchmark, executado com -test.bench
+func (fn *Func) IsBenchmark() bool {
+	return strings.HasPrefix(fn.Name, "Benchmark") && len(fn.Params) == 1 && fn.Params[0] == "*testing.B"
+}
+
+var chanParam = regexp.MustCompile(`^chan(<-)? `)
+
+// Args monta os argumentos da chamada de fn em um harness: ctx para
+// context.Context, n para int, n elementos para []int e um canal novo
+// (sem buffer) para canais
+func (fn *Func) Args(n int) (string, error) {
+	var args []string
+	for _, p := range fn.Params {
+		switch {
+		case p == "context.Context":
+			args = append(args, "ctx")
+		case p == "int":
+			args = append(args, strconv.Itoa(n))
+		case p == "[]int":
+			args = append(args, fmt.Sprintf("fubangoInts(%d)", n))
+		case chanParam.MatchString(p):
+			args = append(args, fmt.Sprintf("make(%s)", p))
+		default:
+			return "", fmt.Errorf("%s: parâmetro do tipo %s não suportado", fn.Name, p)
+		}
+	}
+	return strings.Join(args, ", "), nil
+}
+
+// IntsHelper é a função fubangoInts usada por Args para os parâmetros
+// []int; todo harness que usa Args deve incluí-la
+const IntsHelper = `
+func fubangoInts(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+`
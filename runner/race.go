@@ -0,0 +1,72 @@
+package runner
+
+import (
+	"strings"
+)
+
+// Race é um relatório "WARNING: DATA RACE" do race detector
+type Race struct {
+	Access   string `json:"access"` // "write" ou "read"
+	At       Frame  `json:"at"`
+	Previous string `json:"previous"` // o acesso anterior, com que este conflita
+	PrevAt   Frame  `json:"prevAt"`
+}
+
+// ParseRaces lê os relatórios de data race da saída de erro de um binário
+// compilado com -race. Relatórios repetidos (mesmo par de linhas) aparecem
+// uma vez só. Os frames são os primeiros em arquivos de dir, para apontar
+// para o código do tópico em vez do runtime (ex: runtime.mapassign).
+func ParseRaces(text, dir string) []Race {
+	var races []Race
+	seen := make(map[[2]Frame]bool)
+	for _, block := range strings.Split(text, "WARNING: DATA RACE\n")[1:] {
+		block, _, _ = strings.Cut(block, "==================")
+		var r Race
+		// o primeiro parágrafo é o acesso atual, o segundo o anterior
+		for i, para := range strings.Split(block, "\n\n") {
+			if i > 1 {
+				break
+			}
+			header, stack, _ := strings.Cut(strings.TrimLeft(para, "\n"), "\n")
+			access, _, _ := strings.Cut(header, " at ")
+			access = strings.ToLower(strings.TrimPrefix(access, "Previous "))
+			frame := raceFrame(stack, dir)
+			if i == 0 {
+				r.Access, r.At = access, frame
+			} else {
+				r.Previous, r.PrevAt = access, frame
+			}
+		}
+		if r.Access == "" || seen[[2]Frame{r.At, r.PrevAt}] {
+			continue
+		}
+		seen[[2]Frame{r.At, r.PrevAt}] = true
+		races = append(races, r)
+	}
+	return races
+}
+
+// raceFrame lê as linhas "  função()\n      arquivo:linha +0x..." de um
+// acesso e devolve o primeiro frame em dir, ou o primeiro de todos
+func raceFrame(stack, dir string) Frame {
+	var frames []Frame
+	lines := strings.Split(stack, "\n")
+	for i := 0; i+1 < len(lines); i += 2 {
+		fn := strings.TrimSpace(lines[i])
+		if j := strings.LastIndex(fn, "("); j > 0 {
+			fn = fn[:j]
+		}
+		f := Frame{Func: fn}
+		f.File, f.Line = fileLine(lines[i+1])
+		frames = append(frames, f)
+	}
+	for _, f := range frames {
+		if inDir(f.File, dir) {
+			return f
+		}
+	}
+	if len(frames) > 0 {
+		return frames[0]
+	}
+	return Frame{}
+}
@@ -0,0 +1,321 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// runHarness é o teste que Run insere no pacote. Ele tira um dump de todas
+// as goroutines antes da chamada e outro depois que ela retorna (e as
+// goroutines que ela deixou tiveram settle para chegar aonde vão ficar)
+// ou depois do timeout, e grava tudo em JSON para o processo pai.
+const runHarness = `package %s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestFubangoRun(t *testing.T) {
+	var res struct {
+		Before, After string
+		Returned      bool
+		Panic         string
+		Elapsed       time.Duration
+	}
+	res.Before = fubangoStacks()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(%d))
+	defer cancel()
+	done := make(chan string, 1)
+	start := time.Now()
+	go fubangoRun(ctx, done)
+	select {
+	case res.Panic = <-done:
+		res.Elapsed = time.Since(start)
+		res.Returned = true
+		cancel() // goroutines que respeitam o ctx da chamada não contam como vazadas
+		time.Sleep(time.Duration(%d))
+	case <-ctx.Done():
+		res.Elapsed = time.Since(start)
+	}
+	res.After = fubangoStacks()
+	data, err := json.Marshal(res)
+	if err == nil {
+		err = os.WriteFile(%q, data, 0o644)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func fubangoRun(ctx context.Context, done chan string) {
+	defer func() {
+		if r := recover(); r != nil {
+			done <- fmt.Sprint(r)
+			return
+		}
+		done <- ""
+	}()
+	%s(%s)
+}
+
+func fubangoStacks() string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+`
+
+// Options controla uma execução
+type Options struct {
+	Timeout time.Duration // espera máxima pela função
+	Settle  time.Duration // espera depois do retorno, antes de contar as goroutines
+	N       int           // valor dos parâmetros int e tamanho dos []int
+	Race    bool          // compila com -race
+}
+
+// Outcome é o que se observou em uma execução
+type Outcome struct {
+	Func     string        `json:"func"`
+	File     string        `json:"file"`
+	Race     bool          `json:"race"` // compilado com -race
+	Returned bool          `json:"returned"`
+	Elapsed  time.Duration `json:"elapsed"`
+	Panic    string        `json:"panic,omitempty"` // panic na própria chamada, recuperado pelo harness
+	Crash    string        `json:"crash,omitempty"` // o processo morreu (panic em outra goroutine, fatal error)
+	CrashAt  *Frame        `json:"crashAt,omitempty"`
+	Killed   bool          `json:"killed,omitempty"` // o processo não terminou e foi morto
+
+	// Deadlocked indica que a chamada não retornou e nenhuma goroutine
+	// dela pode progredir: todas estão esperando em canais ou locks.
+	Deadlocked bool       `json:"deadlocked"`
+	Call       *Goroutine `json:"call,omitempty"` // a chamada, se não retornou
+
+	GoroutinesBefore int    `json:"goroutinesBefore"`
+	GoroutinesAfter  int    `json:"goroutinesAfter"`
+	Leaks            []Leak `json:"leaks,omitempty"`
+	Races            []Race `json:"races,omitempty"`
+	Stdout           string `json:"stdout"`
+}
+
+// Leak é um grupo de goroutines criadas durante a chamada que continuavam
+// vivas no final, paradas no mesmo estado e no mesmo ponto
+type Leak struct {
+	Count int    `json:"count"`
+	State string `json:"state"`
+	At    Frame  `json:"at"`
+}
+
+// Leaked é o total de goroutines vazadas
+func (o *Outcome) Leaked() int {
+	n := 0
+	for _, l := range o.Leaks {
+		n += l.Count
+	}
+	return n
+}
+
+// Problems descreve em uma linha cada problema observado; vazio quando a
+// função retornou sem deixar nada para trás
+func (o *Outcome) Problems() []string {
+	var ps []string
+	if o.Crash != "" {
+		p := "o processo morreu: " + o.Crash
+		if o.CrashAt != nil {
+			p += " em " + o.CrashAt.String()
+		}
+		ps = append(ps, p)
+	}
+	if o.Panic != "" {
+		ps = append(ps, "panic na chamada: "+o.Panic)
+	}
+	if o.Killed {
+		ps = append(ps, "o processo não terminou e foi morto")
+	}
+	switch {
+	case o.Deadlocked:
+		ps = append(ps, fmt.Sprintf("deadlock: a chamada está parada em %s em %s", o.Call.State, o.callAt()))
+	case o.Call != nil:
+		ps = append(ps, fmt.Sprintf("tempo esgotado depois de %s (%s em %s)", o.Elapsed.Round(time.Millisecond), o.Call.State, o.callAt()))
+	}
+	switch n := o.Leaked(); {
+	case n == 1:
+		ps = append(ps, "vazou 1 goroutine")
+	case n > 1:
+		ps = append(ps, fmt.Sprintf("vazou %d goroutines", n))
+	}
+	for _, r := range o.Races {
+		ps = append(ps, fmt.Sprintf("data race: %s em %s, %s anterior em %s", r.Access, r.At, r.Previous, r.PrevAt))
+	}
+	return ps
+}
+
+func (o *Outcome) callAt() string {
+	for _, f := range o.Call.Frames {
+		if strings.HasSuffix(f.File, "/"+o.File) {
+			return f.String()
+		}
+	}
+	if len(o.Call.Frames) > 0 {
+		return o.Call.Frames[0].Func
+	}
+	return "?"
+}
+
+// Run compila o pacote em dir com um harness que chama fn e a executa em
+// um processo separado. O processo é morto se não terminar até pouco depois
+// de Timeout e Settle. tmp guarda o binário e o resultado.
+func Run(dir, tmp string, fn *Func, opts Options) (*Outcome, error) {
+	if fn.IsBenchmark() {
+		return nil, fmt.Errorf("%s é um benchmark; use fubango bench", fn.Name)
+	}
+	callArgs, err := fn.Args(opts.N)
+	if err != nil {
+		return nil, err
+	}
+	result := filepath.Join(tmp, fn.Name+".result.json")
+	harness := fmt.Sprintf(runHarness, fn.Package, opts.Timeout, opts.Settle, result, fn.Name, callArgs) + IntsHelper
+	bin, err := Build(dir, tmp, fn, harness, opts.Race)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout+opts.Settle+10*time.Second)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := bin.Command(ctx, "-test.run", "^TestFubangoRun$", "-test.count", "1", "-test.timeout", "0")
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	// o race detector não deve encerrar o processo no primeiro relatório
+	cmd.Env = append(os.Environ(), "GORACE=halt_on_error=0")
+	os.Remove(result)
+	runErr := cmd.Run()
+
+	o := &Outcome{Func: fn.Name, File: fn.File, Race: opts.Race, Stdout: testOutput(stdout.String())}
+	o.Races = ParseRaces(stderr.String(), dir)
+	data, err := os.ReadFile(result)
+	if errors.Is(err, os.ErrNotExist) {
+		if ctx.Err() != nil {
+			o.Killed = true
+			return o, nil
+		}
+		o.Crash, o.CrashAt = crash(stderr.String(), dir)
+		if o.Crash == "" {
+			return nil, fmt.Errorf("executando %s: %w\n%s", fn.Name, runErr, LastLines(stderr.String(), 20))
+		}
+		return o, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Before, After string
+		Returned      bool
+		Panic         string
+		Elapsed       time.Duration
+	}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	o.Returned, o.Panic, o.Elapsed = res.Returned, res.Panic, res.Elapsed
+	o.analyze(ParseStacks(res.Before), ParseStacks(res.After), dir)
+	return o, nil
+}
+
+// analyze compara as goroutines de antes e depois da chamada
+func (o *Outcome) analyze(before, after []Goroutine, dir string) {
+	o.GoroutinesBefore, o.GoroutinesAfter = len(before), len(after)
+	old := make(map[int]bool)
+	for _, g := range before {
+		old[g.ID] = true
+	}
+	leaks := make(map[Leak]int)
+	progress := false // alguma goroutine nova ainda pode andar sozinha
+	for _, g := range after {
+		if old[g.ID] {
+			continue
+		}
+		if g.Calls("fubangoRun") {
+			if !o.Returned {
+				o.Call = &g
+			}
+			continue
+		}
+		progress = progress || !g.Blocked()
+		at, _ := g.Location(dir)
+		leaks[Leak{State: g.State, At: at}]++
+	}
+	for l, n := range leaks {
+		l.Count = n
+		o.Leaks = append(o.Leaks, l)
+	}
+	sort.Slice(o.Leaks, func(i, j int) bool {
+		a, b := o.Leaks[i], o.Leaks[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.At.String()+a.State < b.At.String()+b.State
+	})
+	o.Deadlocked = o.Call != nil && o.Call.Blocked() && !progress
+}
+
+// crash procura a mensagem de um processo que morreu ("panic: ..." ou
+// "fatal error: ...") e o ponto no código do tópico onde ele morreu
+func crash(stderr, dir string) (string, *Frame) {
+	msg := ""
+	for _, line := range strings.Split(stderr, "\n") {
+		if strings.HasPrefix(line, "panic: ") || strings.HasPrefix(line, "fatal error: ") {
+			msg = strings.TrimSuffix(line, " [recovered]")
+			break
+		}
+	}
+	if msg == "" {
+		return "", nil
+	}
+	for _, g := range ParseStacks(stderr) {
+		if at, ok := g.Location(dir); ok {
+			return msg, &at
+		}
+	}
+	return msg, nil
+}
+
+// testOutput tira da saída do binário de teste as linhas do próprio go test
+func testOutput(s string) string {
+	var out []string
+	for _, line := range strings.SplitAfter(s, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "PASS" || trimmed == "FAIL" || strings.HasPrefix(line, "--- ") ||
+			strings.HasPrefix(trimmed, "testing.go:") {
+			continue
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "")
+}
+
+// LastLines devolve as últimas n linhas de s, sem a quebra de linha final
+func LastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
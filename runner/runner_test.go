@@ -0,0 +1,122 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// dir é o diretório do tópico nos arquivos de testdata
+const dir = "/src/exemplos/goroutines"
+
+func readTestdata(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestParseStacks(t *testing.T) {
+	gs := ParseStacks(readTestdata(t, "panic.txt"))
+	if len(gs) != 3 {
+		t.Fatalf("%d goroutines, esperava 3: %+v", len(gs), gs)
+	}
+	g := gs[0]
+	if g.ID != 7 || g.State != "running" || len(g.Frames) != 1 {
+		t.Errorf("goroutine = %+v", g)
+	}
+	if at, ok := g.Location(dir); !ok || at.String() != "ruim.go:74" || at.Func != "command-line-arguments.PanicInGoroutine.func1" {
+		t.Errorf("Location = %+v, %v", at, ok)
+	}
+	if g.CreatedBy == nil || g.CreatedBy.Func != "command-line-arguments.PanicInGoroutine" || g.CreatedBy.Line != 72 {
+		t.Errorf("CreatedBy = %+v", g.CreatedBy)
+	}
+	if gs[2].State != "select" || !gs[2].Blocked() || !gs[2].Calls("TestFubangoRun") {
+		t.Errorf("goroutine 6 = %+v", gs[2])
+	}
+	if _, ok := gs[1].Location(dir); ok {
+		t.Errorf("goroutine 1 não tem frames no tópico: %+v", gs[1])
+	}
+}
+
+func TestCrash(t *testing.T) {
+	msg, at := crash(readTestdata(t, "panic.txt"), dir)
+	if msg != "panic: erro não tratado" || at == nil || at.String() != "ruim.go:74" {
+		t.Errorf("crash = %q, %+v", msg, at)
+	}
+}
+
+func TestParseRaces(t *testing.T) {
+	races := ParseRaces(readTestdata(t, "race.txt"), dir)
+	want := []Race{
+		{Access: "write", At: Frame{"command-line-arguments.(*BadSharedResource).UpdateConcurrently.func1", dir + "/ruim.go", 157},
+			Previous: "write", PrevAt: Frame{"command-line-arguments.(*BadSharedResource).UpdateConcurrently.func1", dir + "/ruim.go", 157}},
+		{Access: "read", At: Frame{"command-line-arguments.BadConcurrentCounter.func1", dir + "/ruim.go", 18},
+			Previous: "write", PrevAt: Frame{"command-line-arguments.BadConcurrentCounter.func1", dir + "/ruim.go", 18}},
+	}
+	if len(races) != len(want) {
+		t.Fatalf("%d races, esperava %d: %+v", len(races), len(want), races)
+	}
+	for i := range want {
+		if races[i] != want[i] {
+			t.Errorf("race %d = %+v, esperava %+v", i, races[i], want[i])
+		}
+	}
+}
+
+func TestFindNotRunnable(t *testing.T) {
+	dir := filepath.Join("..", "exemplos", "03-avancado", "goroutines")
+	tests := map[string]string{
+		"BadSharedResource":                    "BadSharedResource (ruim.go) é uma declaração de tipo, não executável",
+		"BadSharedResource.UpdateConcurrently": "é um método",
+		"Inexistente":                          "função Inexistente não encontrada em ruim.go",
+	}
+	for name, want := range tests {
+		_, err := Find(dir, name, "ruim.go")
+		if err == nil {
+			t.Errorf("Find(%s) não falhou", name)
+			continue
+		}
+		if !strings.Contains(err.Error(), want) || !strings.Contains(err.Error(), "funções executáveis: ") || !strings.Contains(err.Error(), "BadTimeout") {
+			t.Errorf("Find(%s) = %v", name, err)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := filepath.Join("..", "exemplos", "03-avancado", "goroutines")
+	opts := Options{Timeout: 500 * time.Millisecond, Settle: 10 * time.Millisecond, N: 10}
+	tests := []struct {
+		name       string
+		leaked     int
+		returned   bool
+		deadlocked bool
+	}{
+		{"DeadlockWithChannels", 2, true, false},
+		{"AvoidDeadlock", 0, true, false},
+		{"BadTimeout", 1, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn, err := Find(dir, tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			o, err := Run(dir, t.TempDir(), fn, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if o.Leaked() != tt.leaked || o.Returned != tt.returned || o.Deadlocked != tt.deadlocked {
+				t.Errorf("vazadas = %d, retornou = %v, deadlock = %v; problemas: %q",
+					o.Leaked(), o.Returned, o.Deadlocked, o.Problems())
+			}
+			if !tt.returned && (o.Call == nil || o.Call.State != "sleep") {
+				t.Errorf("chamada = %+v", o.Call)
+			}
+		})
+	}
+}
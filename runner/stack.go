@@ -0,0 +1,161 @@
+package runner
+
+import (
+	"bufio"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Frame é uma chamada em uma pilha de goroutine
+type Frame struct {
+	Func string `json:"func"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// Goroutine é uma goroutine de um dump de runtime.Stack ou de um panic
+type Goroutine struct {
+	ID        int     `json:"id"`
+	State     string  `json:"state"` // ex: "chan send", "select", "sleep"
+	Frames    []Frame `json:"frames"`
+	CreatedBy *Frame  `json:"createdBy,omitempty"`
+}
+
+// ParseStacks lê as goroutines de um dump no formato do runtime. Linhas
+// fora dos blocos "goroutine N [...]:" são ignoradas, então a saída de erro
+// inteira de um processo que entrou em panic pode ser passada.
+func ParseStacks(text string) []Goroutine {
+	var gs []Goroutine
+	var g *Goroutine
+	var pending *Frame // função cujo arquivo vem na próxima linha
+	sc := bufio.NewScanner(strings.NewReader(text))
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		line := sc.Text()
+		if id, state, ok := goroutineHeader(line); ok {
+			gs = append(gs, Goroutine{ID: id, State: state})
+			g, pending = &gs[len(gs)-1], nil
+			continue
+		}
+		if g == nil {
+			continue
+		}
+		switch {
+		case line == "":
+			g, pending = nil, nil
+		case strings.HasPrefix(line, "\t"):
+			if pending != nil {
+				pending.File, pending.Line = fileLine(line)
+				pending = nil
+			}
+		case strings.HasPrefix(line, "created by "):
+			fn := strings.TrimPrefix(line, "created by ")
+			if i := strings.Index(fn, " in goroutine "); i >= 0 {
+				fn = fn[:i]
+			}
+			g.CreatedBy = &Frame{Func: fn}
+			pending = g.CreatedBy
+		default:
+			fn := line
+			if i := strings.LastIndex(fn, "("); i > 0 {
+				fn = fn[:i]
+			}
+			g.Frames = append(g.Frames, Frame{Func: fn})
+			pending = &g.Frames[len(g.Frames)-1]
+		}
+	}
+	return gs
+}
+
+// goroutineHeader reconhece "goroutine 7 [chan send, 2 minutes]:"
+func goroutineHeader(line string) (int, string, bool) {
+	rest, ok := strings.CutPrefix(line, "goroutine ")
+	if !ok || !strings.HasSuffix(rest, "]:") {
+		return 0, "", false
+	}
+	num, state, ok := strings.Cut(rest, " ")
+	if !ok {
+		return 0, "", false
+	}
+	id, err := strconv.Atoi(num)
+	if err != nil {
+		return 0, "", false
+	}
+	// com GOTRACEBACK=system o cabeçalho tem "gp=... m=..." antes do estado
+	if i := strings.Index(state, "["); i >= 0 {
+		state = state[i+1:]
+	}
+	state = strings.TrimSuffix(state, "]:")
+	state, _, _ = strings.Cut(state, ",")
+	return id, state, true
+}
+
+// fileLine lê "\t/caminho/ruim.go:116 +0x2c"
+func fileLine(line string) (string, int) {
+	line = strings.TrimSpace(line)
+	if i := strings.LastIndex(line, " +0x"); i >= 0 {
+		line = line[:i]
+	}
+	i := strings.LastIndex(line, ":")
+	if i < 0 {
+		return line, 0
+	}
+	n, _ := strconv.Atoi(line[i+1:])
+	return line[:i], n
+}
+
+// Location devolve o primeiro frame de g em um arquivo de dir (o frame
+// mais interno no código do tópico), ou o frame de criação se nenhum
+// estiver
+func (g *Goroutine) Location(dir string) (Frame, bool) {
+	for _, f := range g.Frames {
+		if inDir(f.File, dir) {
+			return f, true
+		}
+	}
+	if g.CreatedBy != nil && inDir(g.CreatedBy.File, dir) {
+		return *g.CreatedBy, true
+	}
+	return Frame{}, false
+}
+
+// Calls informa se fn aparece em alguma chamada da pilha de g
+func (g *Goroutine) Calls(fn string) bool {
+	for _, f := range g.Frames {
+		if strings.HasSuffix(f.Func, "."+fn) {
+			return true
+		}
+	}
+	return false
+}
+
+// Blocked informa se a goroutine está parada esperando outra goroutine (em
+// um canal, mutex, WaitGroup etc.), e não dormindo, executando ou em I/O
+func (g *Goroutine) Blocked() bool {
+	switch {
+	case strings.HasPrefix(g.State, "chan "), strings.HasPrefix(g.State, "select"),
+		strings.HasPrefix(g.State, "sync."), strings.HasPrefix(g.State, "semacquire"):
+		return true
+	}
+	return false
+}
+
+func inDir(file, dir string) bool {
+	if file == "" {
+		return false
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	return filepath.Dir(file) == abs
+}
+
+// String formata f como "ruim.go:116"
+func (f Frame) String() string {
+	if f.File == "" {
+		return f.Func
+	}
+	return filepath.Base(f.File) + ":" + strconv.Itoa(f.Line)
+}
@@ -0,0 +1,311 @@
+// Package scaffold cria tópicos e anti-padrões novos a partir de modelos.
+//
+// NewTopic gera ruim.go, bom.go, benchmark_test.go, analise.md e um
+// manifesto.json vazio em exemplos/<nível>/<categoria>. AddPattern
+// acrescenta a um tópico a próxima seção numerada do analise.md, as
+// declarações em ruim.go e bom.go, o par de benchmarks e a entrada do
+// manifesto. Os arquivos gerados compilam; os TODOs marcam o que falta
+// escrever. O índice do README e o ROADMAP ficam com quem chama, como em
+// fubango index write.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/lucasrafaldini/fubango/catalog"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+var (
+	//go:embed templates
+	templateFS embed.FS
+	templates  = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+	levelDir    = regexp.MustCompile(`^\d{2}-[a-z0-9]+(-[a-z0-9]+)*$`)
+	categoryDir = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+	tagName     = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+// PackageName deriva o nome do pacote da categoria, como nos tópicos
+// existentes: error-handling vira errorhandling. Nomes de pacotes da
+// biblioteca padrão ganham um x no fim, como contextx.
+func PackageName(category string) string {
+	name := strings.ReplaceAll(category, "-", "")
+	if token.IsKeyword(name) || isStdPackage(name) {
+		name += "x"
+	}
+	return name
+}
+
+func isStdPackage(name string) bool {
+	p, err := build.Import(name, "", build.FindOnly)
+	return err == nil && p.Goroot
+}
+
+// NewTopic cria o tópico level/category em root, com name como nome de
+// exibição, e devolve os arquivos criados, relativos a root
+func NewTopic(root, level, category, name string) ([]string, error) {
+	if !levelDir.MatchString(level) {
+		return nil, fmt.Errorf("nível inválido %q: use o número e o nome, como 03-avancado", level)
+	}
+	if !categoryDir.MatchString(category) {
+		return nil, fmt.Errorf("categoria inválida %q: use minúsculas separadas por hífen, como error-handling", category)
+	}
+	rel := path.Join(catalog.ExamplesDir, level, category)
+	dir := filepath.Join(root, filepath.FromSlash(rel))
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("%s já existe", rel)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	data := struct{ Package, Name string }{PackageName(category), name}
+	var created []string
+	for _, file := range []string{catalog.RuimFile, catalog.BomFile, catalog.BenchmarkFile, catalog.AnalysisFile} {
+		var b bytes.Buffer
+		if err := templates.ExecuteTemplate(&b, file+".tmpl", data); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(filepath.Join(dir, file), b.Bytes(), 0o644); err != nil {
+			return nil, err
+		}
+		created = append(created, path.Join(rel, file))
+	}
+	manifest, err := (&catalog.Manifest{AntiPatterns: []*catalog.ManifestEntry{}}).Encode()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, catalog.ManifestFile), manifest, 0o644); err != nil {
+		return nil, err
+	}
+	return append(created, path.Join(rel, catalog.ManifestFile)), nil
+}
+
+// Pattern descreve um anti-padrão novo
+type Pattern struct {
+	Title     string
+	Ruim, Bom string // funções em ruim.go e bom.go; vazias, derivadas do título
+	Severity  string // padrão: warning
+	Tags      []string
+}
+
+// AddPattern acrescenta p ao tópico t, lido de root, como a próxima seção
+// numerada. Devolve o número do anti-padrão e os arquivos alterados.
+func AddPattern(root string, t *catalog.Topic, p Pattern) (int, []string, error) {
+	p.Title = strings.TrimSpace(p.Title)
+	if p.Title == "" {
+		return 0, nil, errors.New("o anti-padrão precisa de um título")
+	}
+	name := exportedName(p.Title)
+	if p.Ruim == "" {
+		p.Ruim = "Bad" + name
+	}
+	if p.Bom == "" {
+		p.Bom = "Good" + name
+	}
+	if p.Severity == "" {
+		p.Severity = "warning"
+	}
+	if !slices.Contains(catalog.Severities, p.Severity) {
+		return 0, nil, fmt.Errorf("gravidade desconhecida %q (use %s)", p.Severity, strings.Join(catalog.Severities, ", "))
+	}
+	if len(p.Tags) == 0 {
+		p.Tags = []string{t.Category}
+	}
+	for _, tag := range p.Tags {
+		if !tagName.MatchString(tag) {
+			return 0, nil, fmt.Errorf("tag inválida %q: use minúsculas separadas por hífen", tag)
+		}
+	}
+
+	dir := filepath.Join(root, filepath.FromSlash(t.Dir))
+	manifest := t.Manifest
+	if manifest == nil {
+		manifest = &catalog.Manifest{}
+	}
+	number := 1
+	for _, ap := range t.AntiPatterns {
+		number = max(number, ap.Number+1)
+	}
+	for _, e := range manifest.AntiPatterns {
+		number = max(number, e.Number+1)
+	}
+	data := struct {
+		Pattern
+		Number int
+	}{p, number}
+
+	// confere tudo antes de alterar qualquer arquivo
+	for _, check := range []struct{ file, name string }{
+		{catalog.RuimFile, p.Ruim},
+		{catalog.BomFile, p.Bom},
+		{catalog.BenchmarkFile, "Benchmark" + p.Ruim},
+		{catalog.BenchmarkFile, "Benchmark" + p.Bom},
+	} {
+		if !token.IsIdentifier(check.name) {
+			return 0, nil, fmt.Errorf("%q não é um nome válido em Go", check.name)
+		}
+		src, err := catalog.ParseSourceFile(filepath.Join(dir, check.file))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		if src.Lookup(check.name) != nil {
+			return 0, nil, fmt.Errorf("%s já declara %s", check.file, check.name)
+		}
+	}
+
+	var changed []string
+	write := func(file string, content []byte) error {
+		if err := os.WriteFile(filepath.Join(dir, file), content, 0o644); err != nil {
+			return err
+		}
+		changed = append(changed, path.Join(t.Dir, file))
+		return nil
+	}
+
+	section, err := execute("pattern-analise.md.tmpl", data)
+	if err != nil {
+		return 0, nil, err
+	}
+	analysis, err := os.ReadFile(filepath.Join(dir, catalog.AnalysisFile))
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := write(catalog.AnalysisFile, insertSection(analysis, section)); err != nil {
+		return 0, nil, err
+	}
+
+	for _, file := range []string{catalog.RuimFile, catalog.BomFile, catalog.BenchmarkFile} {
+		decl, err := execute("pattern-"+file+".tmpl", data)
+		if err != nil {
+			return 0, nil, err
+		}
+		src, err := os.ReadFile(filepath.Join(dir, file))
+		if errors.Is(err, fs.ErrNotExist) && file == catalog.BenchmarkFile {
+			src = []byte("package " + PackageName(t.Category) + "\n")
+		} else if err != nil {
+			return 0, nil, err
+		}
+		if file == catalog.BenchmarkFile {
+			if src, err = addImport(src, "testing"); err != nil {
+				return 0, nil, fmt.Errorf("%s: %w", file, err)
+			}
+		}
+		if len(src) > 0 && src[len(src)-1] != '\n' {
+			src = append(src, '\n')
+		}
+		if err := write(file, append(src, decl...)); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	manifest.AntiPatterns = append(manifest.AntiPatterns, &catalog.ManifestEntry{
+		ID:        catalog.ManifestID(t.Category, p.Title),
+		Number:    number,
+		Title:     p.Title,
+		Severity:  p.Severity,
+		Tags:      p.Tags,
+		Ruim:      p.Ruim,
+		Bom:       p.Bom,
+		Benchmark: &catalog.BenchmarkPair{Ruim: "Benchmark" + p.Ruim, Bom: "Benchmark" + p.Bom},
+	})
+	encoded, err := manifest.Encode()
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := write(catalog.ManifestFile, encoded); err != nil {
+		return 0, nil, err
+	}
+	return number, changed, nil
+}
+
+func execute(name string, data any) ([]byte, error) {
+	var b bytes.Buffer
+	err := templates.ExecuteTemplate(&b, name, data)
+	return b.Bytes(), err
+}
+
+// exportedName converte um título em um identificador, como
+// "Deadlock com Canais" em DeadlockComCanais
+func exportedName(title string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(strings.TrimPrefix(catalog.ManifestID("", title), "/"), "-") {
+		r := []rune(word)
+		if len(r) == 0 {
+			continue
+		}
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	name := b.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "Pattern" + name
+	}
+	return name
+}
+
+// insertSection põe a seção depois da última seção numerada do analise.md,
+// antes de seções sem número como a conclusão
+func insertSection(analysis, section []byte) []byte {
+	lines := strings.SplitAfter(string(analysis), "\n")
+	insert := len(lines)
+	numbered := false
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "##" {
+			continue
+		}
+		_, err := strconv.Atoi(strings.TrimSuffix(fields[1], "."))
+		switch {
+		case err == nil:
+			numbered, insert = true, len(lines)
+		case insert == len(lines) || numbered:
+			insert, numbered = i, false
+		}
+	}
+	head := strings.Join(lines[:insert], "")
+	if head != "" && !strings.HasSuffix(head, "\n\n") {
+		head = strings.TrimRight(head, "\n") + "\n\n"
+	}
+	return []byte(head + string(section) + strings.Join(lines[insert:], ""))
+}
+
+// addImport garante que src importa pkg
+func addImport(src []byte, pkg string) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	if !astutil.AddImport(fset, f, pkg) {
+		return src, nil
+	}
+	var b bytes.Buffer
+	if err := format.Node(&b, fset, f); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
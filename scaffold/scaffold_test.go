@@ -0,0 +1,104 @@
+package scaffold
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/catalog"
+)
+
+func TestPackageName(t *testing.T) {
+	for category, want := range map[string]string{
+		"generics":       "generics",
+		"error-handling": "errorhandling",
+		"context":        "contextx",
+		"go":             "gox",
+	} {
+		if got := PackageName(category); got != want {
+			t.Errorf("PackageName(%q) = %q, esperado %q", category, got, want)
+		}
+	}
+}
+
+func TestInsertSection(t *testing.T) {
+	section := "## 2. Novo\n\n"
+	for _, tc := range []struct{ src, want string }{
+		{"# A\n\n## 1. Um\ntexto\n\n## Conclusão\nfim\n", "# A\n\n## 1. Um\ntexto\n\n## 2. Novo\n\n## Conclusão\nfim\n"},
+		{"# A\n\n## Introdução\n\n## 1. Um\ntexto\n", "# A\n\n## Introdução\n\n## 1. Um\ntexto\n\n## 2. Novo\n\n"},
+		{"# A\n\n## Conclusão\n", "# A\n\n## 2. Novo\n\n## Conclusão\n"},
+	} {
+		if got := string(insertSection([]byte(tc.src), []byte(section))); got != tc.want {
+			t.Errorf("insertSection(%q) = %q, esperado %q", tc.src, got, tc.want)
+		}
+	}
+}
+
+func TestNewTopicAndPattern(t *testing.T) {
+	root := t.TempDir()
+	files, err := NewTopic(root, "03-avancado", "generics", "Generics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 5 {
+		t.Errorf("arquivos criados = %v", files)
+	}
+	if _, err := NewTopic(root, "03-avancado", "generics", "Generics"); err == nil {
+		t.Error("NewTopic sobrescreveu um tópico existente")
+	}
+	if _, err := NewTopic(root, "avancado", "generics", "Generics"); err == nil {
+		t.Error("nível sem número aceito")
+	}
+
+	for i, title := range []string{"Restrições amplas demais", "Any em vez de parâmetro de tipo"} {
+		c, err := catalog.Load(root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		number, _, err := AddPattern(root, c.Topic("generics"), Pattern{Title: title})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if number != i+1 {
+			t.Errorf("%q: número %d, esperado %d", title, number, i+1)
+		}
+	}
+
+	c, err := catalog.Load(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	topic := c.Topic("generics")
+	if _, _, err := AddPattern(root, topic, Pattern{Title: "Outro", Ruim: "BadRestricoesAmplasDemais"}); err == nil ||
+		!strings.Contains(err.Error(), "já declara") {
+		t.Errorf("declaração repetida aceita: %v", err)
+	}
+	if len(topic.AntiPatterns) != 2 || topic.AntiPatterns[1].Title != "Any em vez de parâmetro de tipo" {
+		t.Fatalf("anti-padrões = %+v", topic.AntiPatterns)
+	}
+	if ap := topic.AntiPatterns[0]; ap.Ruim.Name != "BadRestricoesAmplasDemais" || ap.Bom.Name != "GoodRestricoesAmplasDemais" {
+		t.Errorf("anti-padrão 1 = %+v", ap)
+	}
+	problems, err := c.Validate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range problems {
+		t.Error(p)
+	}
+
+	// o pacote gerado compila e os benchmarks rodam
+	if testing.Short() {
+		return
+	}
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/scaffold\n\ngo 1.21\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("go", "test", "-run", "^$", "-bench", ".", "-benchtime", "1x", "./...")
+	cmd.Dir = root
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go test no tópico gerado: %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,251 @@
+// Package sched resume um runtime/trace pelo estado das goroutines.
+//
+// Nos exemplos de concorrência a diferença entre as versões ruim e boa
+// costuma estar no tempo que as goroutines passam bloqueadas (em um canal
+// sem buffer, em um mutex disputado) e não no tempo de CPU. Summarize lê o
+// trace e soma, para cada função inicial de goroutine, o tempo em cada
+// estado do escalonador.
+package sched
+
+import (
+	"cmp"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/trace"
+)
+
+// Times é o tempo passado em cada estado
+type Times struct {
+	Running  time.Duration `json:"running"`
+	Runnable time.Duration `json:"runnable"` // pronta, esperando um P
+	Chan     time.Duration `json:"chan"`     // bloqueada em send, receive ou select
+	Mutex    time.Duration `json:"mutex"`    // sync.Mutex, sync.RWMutex e sync.Cond
+	Syscall  time.Duration `json:"syscall"`
+	Sleep    time.Duration `json:"sleep"`
+	Other    time.Duration `json:"other"` // WaitGroup, rede, GC etc.
+}
+
+// Blocked é o tempo total bloqueado, sem contar o tempo em syscall
+func (t Times) Blocked() time.Duration {
+	return t.Chan + t.Mutex + t.Sleep + t.Other
+}
+
+// Add soma os tempos de o aos de t
+func (t *Times) Add(o Times) {
+	t.Running += o.Running
+	t.Runnable += o.Runnable
+	t.Chan += o.Chan
+	t.Mutex += o.Mutex
+	t.Syscall += o.Syscall
+	t.Sleep += o.Sleep
+	t.Other += o.Other
+}
+
+// Group reúne as goroutines que começaram na mesma função
+type Group struct {
+	Func       string `json:"func"`
+	Goroutines int    `json:"goroutines"`
+	LiveAtEnd  int    `json:"liveAtEnd"` // ainda vivas quando o trace terminou
+	Times
+}
+
+// Summary é o resumo de um trace
+type Summary struct {
+	Duration  time.Duration `json:"duration"`
+	Created   int           `json:"created"` // goroutines criadas durante o trace
+	PeakLive  int           `json:"peakLive"`
+	LiveAtEnd int           `json:"liveAtEnd"`
+	Groups    []Group       `json:"groups"` // em ordem decrescente de tempo total
+}
+
+// goroutine é o estado corrente de uma goroutine durante a leitura
+type goroutine struct {
+	fn      string
+	counted bool // aceita por focus
+	state   trace.GoState
+	wait    string // categoria do bloqueio quando state é GoWaiting
+	since   trace.Time
+	times   Times
+}
+
+// Summarize lê um trace gerado por runtime/trace (ou go test -trace). O
+// tempo de cada goroutine é contado do seu primeiro evento até o fim do
+// trace ou até ela terminar. Se focus não for nil, apenas as goroutines
+// cuja função inicial é aceita por focus, ou que foram criadas por uma
+// delas ou por código aceito por focus, entram nos grupos e nas contagens,
+// deixando de fora as goroutines do runtime e do go test.
+func Summarize(r io.Reader, focus func(fn string) bool) (*Summary, error) {
+	tr, err := trace.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	s := &Summary{}
+	gs := make(map[trace.GoID]*goroutine)
+	var dead []*goroutine
+	var start, end trace.Time
+	live := 0
+	for {
+		ev, err := tr.ReadEvent()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if start == 0 {
+			start = ev.Time()
+		}
+		end = ev.Time()
+		if ev.Kind() != trace.EventStateTransition {
+			continue
+		}
+		st := ev.StateTransition()
+		if st.Resource.Kind != trace.ResourceGoroutine {
+			continue
+		}
+		from, to := st.Goroutine()
+		id := st.Resource.Goroutine()
+		g := gs[id]
+		if g == nil {
+			g = &goroutine{fn: startFunc(st.Stack), state: from, since: ev.Time()}
+			gs[id] = g
+			g.counted = focus == nil || focus(g.fn)
+			if !g.counted && from == trace.GoNotExist {
+				// criada pelo código em foco, mesmo que comece em outro
+				// pacote (ex: errgroup.(*Group).Go)
+				parent := gs[ev.Goroutine()]
+				g.counted = parent != nil && parent.counted || stackHas(ev.Stack(), focus)
+			}
+			if g.counted {
+				live++
+				if from == trace.GoNotExist {
+					s.Created++
+				}
+			}
+		}
+		g.account(ev.Time())
+		g.state, g.since = to, ev.Time()
+		if to == trace.GoWaiting {
+			g.wait = waitCategory(st.Reason, st.Stack)
+		}
+		if to == trace.GoNotExist {
+			if g.counted {
+				live--
+			}
+			dead = append(dead, g)
+			delete(gs, id)
+		}
+		s.PeakLive = max(s.PeakLive, live)
+	}
+	s.Duration = end.Sub(start)
+
+	groups := make(map[string]*Group)
+	group := func(g *goroutine) *Group {
+		name := cmp.Or(g.fn, "?")
+		gr := groups[name]
+		if gr == nil {
+			gr = &Group{Func: name}
+			groups[name] = gr
+		}
+		gr.Goroutines++
+		gr.Add(g.times)
+		return gr
+	}
+	for _, g := range dead {
+		if g.counted {
+			group(g)
+		}
+	}
+	for _, g := range gs {
+		if g.counted {
+			g.account(end)
+			group(g).LiveAtEnd++
+			s.LiveAtEnd++
+		}
+	}
+	for _, gr := range groups {
+		s.Groups = append(s.Groups, *gr)
+	}
+	sort.Slice(s.Groups, func(i, j int) bool {
+		a, b := s.Groups[i].total(), s.Groups[j].total()
+		if a != b {
+			return a > b
+		}
+		return s.Groups[i].Func < s.Groups[j].Func
+	})
+	return s, nil
+}
+
+func (t Times) total() time.Duration {
+	return t.Running + t.Runnable + t.Syscall + t.Blocked()
+}
+
+// account soma ao estado corrente o tempo desde a última transição
+func (g *goroutine) account(now trace.Time) {
+	d := now.Sub(g.since)
+	switch g.state {
+	case trace.GoRunning:
+		g.times.Running += d
+	case trace.GoRunnable:
+		g.times.Runnable += d
+	case trace.GoSyscall:
+		g.times.Syscall += d
+	case trace.GoWaiting:
+		switch g.wait {
+		case "chan":
+			g.times.Chan += d
+		case "mutex":
+			g.times.Mutex += d
+		case "sleep":
+			g.times.Sleep += d
+		default:
+			g.times.Other += d
+		}
+	}
+}
+
+// waitCategory classifica o motivo de um bloqueio. O runtime registra
+// Mutex, RWMutex e WaitGroup todos como "sync", então a pilha decide.
+func waitCategory(reason string, stack trace.Stack) string {
+	switch reason {
+	case "chan send", "chan receive", "select":
+		return "chan"
+	case "sleep":
+		return "sleep"
+	case "sync.(*Cond).Wait":
+		return "mutex"
+	case "sync":
+		for f := range stack.Frames() {
+			if strings.Contains(f.Func, "sync.(*Mutex)") || strings.Contains(f.Func, "sync.(*RWMutex)") {
+				return "mutex"
+			}
+		}
+	}
+	return "other"
+}
+
+// stackHas informa se alguma função da pilha é aceita por focus
+func stackHas(stack trace.Stack, focus func(string) bool) bool {
+	for f := range stack.Frames() {
+		if focus(f.Func) {
+			return true
+		}
+	}
+	return false
+}
+
+// startFunc devolve a função mais externa da pilha, que para a pilha
+// inicial de uma goroutine é a função passada ao go
+func startFunc(stack trace.Stack) string {
+	fn := ""
+	for f := range stack.Frames() {
+		if f.Func != "runtime.goexit" {
+			fn = f.Func
+		}
+	}
+	return fn
+}
@@ -0,0 +1,71 @@
+package sched
+
+import (
+	"bytes"
+	"runtime/trace"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Skip("trace indisponível:", err)
+	}
+	ch := make(chan int)
+	received := make(chan struct{})
+	go chanReceiver(ch, received)
+
+	var mu sync.Mutex
+	mu.Lock()
+	locked := make(chan struct{})
+	go mutexLocker(&mu, locked)
+
+	leak := make(chan struct{})
+	go chanReceiver(nil, leak) // continua bloqueada quando o trace termina
+
+	time.Sleep(30 * time.Millisecond)
+	ch <- 1
+	mu.Unlock()
+	<-received
+	<-locked
+	trace.Stop()
+	close(leak)
+
+	s, err := Summarize(&buf, func(fn string) bool {
+		return strings.HasSuffix(fn, "sched.chanReceiver") || strings.HasSuffix(fn, "sched.mutexLocker")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Created != 3 || s.PeakLive != 3 || s.LiveAtEnd != 1 {
+		t.Errorf("criadas = %d, pico = %d, vivas ao final = %d; esperado 3, 3, 1", s.Created, s.PeakLive, s.LiveAtEnd)
+	}
+	groups := make(map[string]Group)
+	for _, g := range s.Groups {
+		groups[g.Func[strings.LastIndex(g.Func, ".")+1:]] = g
+	}
+	if g := groups["chanReceiver"]; g.Goroutines != 2 || g.Chan < 50*time.Millisecond {
+		t.Errorf("chanReceiver = %+v, esperado 2 goroutines com >= 50ms em canal", g)
+	}
+	if g := groups["mutexLocker"]; g.Goroutines != 1 || g.Mutex < 25*time.Millisecond {
+		t.Errorf("mutexLocker = %+v, esperado >= 25ms em mutex", g)
+	}
+}
+
+func chanReceiver(ch chan int, done chan struct{}) {
+	if ch == nil {
+		<-done
+		return
+	}
+	<-ch
+	close(done)
+}
+
+func mutexLocker(mu *sync.Mutex, done chan struct{}) {
+	mu.Lock()
+	mu.Unlock()
+	close(done)
+}
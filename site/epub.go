@@ -0,0 +1,125 @@
+package site
+
+import (
+	"archive/zip"
+	"embed"
+	"encoding/xml"
+	"html/template"
+	"io"
+	"time"
+)
+
+var (
+	//go:embed epub
+	epubFS embed.FS
+
+	epubTemplates = template.Must(template.New("").Funcs(template.FuncMap{
+		"date": func(t time.Time) string { return t.Local().Format("02/01/2006") },
+	}).ParseFS(epubFS, "epub/*.xhtml", "epub/*.opf"))
+)
+
+const epubContainer = xml.Header + `<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+<rootfiles>
+<rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+</rootfiles>
+</container>
+`
+
+// book é o livro: uma página de abertura por nível e um capítulo por
+// tópico, na ordem do índice (dos básicos aos casos reais)
+type book struct {
+	*data
+	Modified string // dcterms:modified
+	Levels   []bookLevel
+}
+
+type bookLevel struct {
+	ID, File, Name string
+	Chapters       []bookChapter
+}
+
+type bookChapter struct {
+	*topicPage
+	ID, File string
+}
+
+// epubFile é um arquivo do EPUB e a função que escreve o seu conteúdo
+type epubFile struct {
+	name  string
+	write func(io.Writer) error
+}
+
+// WriteEPUB grava o catálogo como um livro EPUB 3
+func (s *Site) WriteEPUB(w io.Writer) error {
+	d, err := s.load()
+	if err != nil {
+		return err
+	}
+	b := book{data: d, Modified: time.Now().UTC().Format("2006-01-02T15:04:05Z")}
+	for _, topics := range levels(d.Topics) {
+		// IDs do XML não podem começar com dígito, como 01-basicos
+		level := bookLevel{ID: "nivel-" + topics[0].Level, File: topics[0].Level + ".xhtml", Name: d.Labels.Level(topics[0].Level)}
+		for _, t := range topics {
+			p, err := newTopicPage(d, t)
+			if err != nil {
+				return err
+			}
+			name := t.Level + "-" + t.Category
+			level.Chapters = append(level.Chapters, bookChapter{topicPage: p, ID: "cap-" + name, File: name + ".xhtml"})
+		}
+		b.Levels = append(b.Levels, level)
+	}
+
+	zw := zip.NewWriter(w)
+	// o mimetype precisa ser o primeiro arquivo, sem compressão
+	mw, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(mw, "application/epub+zip"); err != nil {
+		return err
+	}
+	// html/template escaparia a declaração XML, então ela fica fora dos templates
+	execute := func(name string, data any) func(io.Writer) error {
+		return func(w io.Writer) error {
+			if _, err := io.WriteString(w, xml.Header); err != nil {
+				return err
+			}
+			return epubTemplates.ExecuteTemplate(w, name, data)
+		}
+	}
+
+	files := []epubFile{
+		{"META-INF/container.xml", func(w io.Writer) error {
+			_, err := io.WriteString(w, epubContainer)
+			return err
+		}},
+		{"OEBPS/content.opf", execute("content.opf", b)},
+		{"OEBPS/style.css", func(w io.Writer) error {
+			data, err := epubFS.ReadFile("epub/style.css")
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(data)
+			return err
+		}},
+		{"OEBPS/title.xhtml", execute("title.xhtml", b)},
+		{"OEBPS/nav.xhtml", execute("nav.xhtml", b)},
+	}
+	for _, l := range b.Levels {
+		files = append(files, epubFile{"OEBPS/" + l.File, execute("level.xhtml", l)})
+		for _, c := range l.Chapters {
+			files = append(files, epubFile{"OEBPS/" + c.File, execute("chapter.xhtml", c)})
+		}
+	}
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if err := f.write(fw); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
@@ -0,0 +1,66 @@
+package site
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// Export grava em dir o site estático: as mesmas páginas de Handler, o CSS
+// e a busca, com os links apontando para index.html. O resultado pode ser
+// publicado em qualquer servidor de arquivos ou aberto direto do disco.
+// Devolve os nomes dos arquivos gravados, relativos a dir.
+func (s *Site) Export(dir string) ([]string, error) {
+	d, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	d.IndexFile = "index.html"
+
+	var written []string
+	write := func(name string, fn func(io.Writer) error) error {
+		var b bytes.Buffer
+		if err := fn(&b); err != nil {
+			return err
+		}
+		dest := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+			return err
+		}
+		written = append(written, name)
+		return os.WriteFile(dest, b.Bytes(), 0o644)
+	}
+
+	if err := write(d.IndexFile, func(w io.Writer) error { return writeIndex(w, d) }); err != nil {
+		return nil, err
+	}
+	for _, t := range d.Topics {
+		name := path.Join(t.Level, t.Category, d.IndexFile)
+		if err := write(name, func(w io.Writer) error { return writeTopic(w, d, t) }); err != nil {
+			return nil, err
+		}
+	}
+	if err := write("search-index.js", func(w io.Writer) error { return writeSearchIndex(w, d) }); err != nil {
+		return nil, err
+	}
+	err = fs.WalkDir(staticFS, "static", func(name string, e fs.DirEntry, err error) error {
+		if err != nil || e.IsDir() {
+			return err
+		}
+		return write(name, func(w io.Writer) error {
+			data, err := staticFS.ReadFile(name)
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(data)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return written, nil
+}
@@ -0,0 +1,148 @@
+package site
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func testSite() *Site {
+	return &Site{Root: "..", Results: []string{"testdata/*.json"}}
+}
+
+func TestExport(t *testing.T) {
+	dir := t.TempDir()
+	files, err := testSite().Export(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"index.html", "03-avancado/goroutines/index.html", "search-index.js", "static/style.css", "static/search.js"} {
+		if !slices.Contains(files, want) {
+			t.Errorf("%s não foi exportado: %v", want, files)
+		}
+	}
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	// os links precisam funcionar abrindo os arquivos direto do disco
+	if index := read("index.html"); !strings.Contains(index, `href="03-avancado/goroutines/index.html#ap-9"`) {
+		t.Error("índice sem links para index.html")
+	}
+	if page := read("04-casos-reais/testes/index.html"); !strings.Contains(page, `<a href="../../index.html">Índice</a>`) {
+		t.Error("página sem link para o índice")
+	}
+	if search := read("search-index.js"); !strings.Contains(search, `"url":"03-avancado/goroutines/index.html#ap-9"`) {
+		t.Error("índice da busca sem links para index.html")
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := testSite().WriteJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	var dump Dump
+	if err := json.Unmarshal(b.Bytes(), &dump); err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, l := range dump.Levels {
+		ids = append(ids, l.ID)
+	}
+	if want := []string{"01-basicos", "02-intermediario", "03-avancado", "04-casos-reais"}; !slices.Equal(ids, want) {
+		t.Fatalf("níveis = %v, esperado %v", ids, want)
+	}
+	if dump.ResultsDate == nil {
+		t.Error("sem a data dos resultados")
+	}
+
+	g := dump.Levels[2].Topics[0]
+	if g.Category != "goroutines" || g.Name != "Goroutines" || !strings.Contains(g.Conclusion, "## Conclusão") {
+		t.Fatalf("tópico inesperado: %s %q", g.Category, g.Name)
+	}
+	ap := g.AntiPatterns[0]
+	if ap.ID != "goroutines/goroutines-sem-controle-de-termino" || !strings.HasPrefix(ap.RuimCode, "func LaunchUncontrolledGoroutines() {") ||
+		!strings.HasPrefix(ap.BomCode, "type WorkerPool struct {") || !strings.Contains(ap.Analysis, "```go") {
+		t.Errorf("anti-padrão inesperado: %+v", ap)
+	}
+	if len(ap.Results) != 1 || ap.Results[0].Procs != 8 || ap.Results[0].Speedup != 5 {
+		t.Errorf("resultados = %+v", ap.Results)
+	}
+}
+
+func TestWriteEPUB(t *testing.T) {
+	var b bytes.Buffer
+	if err := testSite().WriteEPUB(&b); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := zr.File[0]; f.Name != "mimetype" || f.Method != zip.Store {
+		t.Errorf("primeiro arquivo = %s (método %d), esperado mimetype sem compressão", f.Name, f.Method)
+	}
+
+	contents := make(map[string]string)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents[f.Name] = string(data)
+		if ext := filepath.Ext(f.Name); ext == ".xhtml" || ext == ".opf" || ext == ".xml" {
+			if err := wellFormed(data); err != nil {
+				t.Errorf("%s: %v", f.Name, err)
+			}
+		}
+	}
+
+	// os capítulos seguem o índice: dos básicos aos casos reais
+	opf := contents["OEBPS/content.opf"]
+	var spine []string
+	for _, line := range strings.Split(opf[strings.Index(opf, "<spine>"):], "\n") {
+		if id, ok := strings.CutPrefix(line, `<itemref idref="`); ok {
+			spine = append(spine, strings.TrimSuffix(id, `"/>`))
+		}
+	}
+	if len(spine) != 18 || spine[0] != "title" || spine[2] != "nivel-01-basicos" || spine[3] != "cap-01-basicos-variaveis" || spine[17] != "cap-04-casos-reais-testes" {
+		t.Errorf("spine = %v", spine)
+	}
+	chapter := contents["OEBPS/03-avancado-goroutines.xhtml"]
+	for _, want := range []string{`<section class="anti-pattern" id="ap-9" lang="pt-BR" xml:lang="pt-BR">`, "<code>DeadlockWithChannels</code>", "<td>8</td><td>2.5ms</td><td>500.0µs</td><td>5.00×"} {
+		if !strings.Contains(chapter, want) {
+			t.Errorf("capítulo sem %q", want)
+		}
+	}
+}
+
+// wellFormed confere se data é XML bem formado
+func wellFormed(data []byte) error {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		_, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
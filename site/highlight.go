@@ -0,0 +1,96 @@
+package site
+
+import (
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"html"
+	"html/template"
+	"strings"
+)
+
+// Classes CSS usadas no destaque de sintaxe (veja static/style.css)
+const (
+	classKeyword = "kw"
+	classString  = "str"
+	classComment = "com"
+	classNumber  = "num"
+	classBuiltin = "bi" // identificadores predeclarados: int, error, nil, make...
+)
+
+// Highlight destaca a sintaxe de um código Go e devolve uma linha de HTML
+// por linha do fonte. Não precisa que o código compile: os exemplos ruins
+// às vezes não compilam de propósito.
+func Highlight(src []byte) []template.HTML {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	s.Init(file, src, nil, scanner.ScanComments)
+
+	var (
+		lines []template.HTML
+		b     strings.Builder
+	)
+	emit := func(text, class string) {
+		for i, part := range strings.Split(text, "\n") {
+			if i > 0 {
+				lines = append(lines, template.HTML(b.String()))
+				b.Reset()
+			}
+			if part == "" {
+				continue
+			}
+			if class == "" {
+				b.WriteString(html.EscapeString(part))
+				continue
+			}
+			b.WriteString(`<span class="` + class + `">` + html.EscapeString(part) + `</span>`)
+		}
+	}
+
+	last := 0
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue // ponto e vírgula automático
+		}
+		off := file.Offset(pos)
+		if off < last {
+			continue
+		}
+		text := lit
+		if text == "" || tok.IsOperator() {
+			text = tok.String()
+		}
+		if off+len(text) > len(src) || string(src[off:off+len(text)]) != text {
+			continue // literal normalizado pelo scanner (ex: \r em string crua)
+		}
+		emit(string(src[last:off]), "")
+		emit(text, tokenClass(tok, lit))
+		last = off + len(text)
+	}
+	emit(string(src[last:]), "")
+	if b.Len() > 0 {
+		lines = append(lines, template.HTML(b.String()))
+	}
+	return lines
+}
+
+func tokenClass(tok token.Token, lit string) string {
+	switch {
+	case tok.IsKeyword():
+		return classKeyword
+	case tok == token.STRING || tok == token.CHAR:
+		return classString
+	case tok == token.COMMENT:
+		return classComment
+	case tok == token.INT || tok == token.FLOAT || tok == token.IMAG:
+		return classNumber
+	case tok == token.IDENT && types.Universe.Lookup(lit) != nil:
+		return classBuiltin
+	}
+	return ""
+}
@@ -0,0 +1,120 @@
+package site
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/lucasrafaldini/fubango/bench"
+	"github.com/lucasrafaldini/fubango/catalog"
+)
+
+// Dump é o catálogo completo gravado por WriteJSON, para outras
+// ferramentas. Os textos do analise.md continuam em Markdown.
+type Dump struct {
+	Lang        string      `json:"lang"`                  // língua pedida; veja o campo lang de cada tópico e anti-padrão
+	ResultsDate *time.Time  `json:"resultsDate,omitempty"` // data dos benchmarks, se houver
+	Levels      []DumpLevel `json:"levels"`
+}
+
+// DumpLevel é um nível de exemplos, na ordem do índice do README
+type DumpLevel struct {
+	ID     string      `json:"id"`
+	Name   string      `json:"name"`
+	Topics []DumpTopic `json:"topics"`
+}
+
+// DumpTopic é um tópico com os textos do analise.md
+type DumpTopic struct {
+	*catalog.Topic
+	Name         string            `json:"name"` // nome no índice do README
+	Intro        string            `json:"intro"`
+	Conclusion   string            `json:"conclusion,omitempty"`
+	AntiPatterns []DumpAntiPattern `json:"antiPatterns"`
+}
+
+// DumpAntiPattern é um anti-padrão com a sua seção do analise.md, o código
+// das declarações associadas e os resultados do par de benchmarks
+type DumpAntiPattern struct {
+	*catalog.AntiPattern
+	Analysis string       `json:"analysis"`
+	RuimCode string       `json:"ruimCode,omitempty"`
+	BomCode  string       `json:"bomCode,omitempty"`
+	Results  []DumpResult `json:"results,omitempty"`
+}
+
+// DumpResult compara o par de benchmarks em um GOMAXPROCS
+type DumpResult struct {
+	Procs int           `json:"procs"`
+	Ruim  bench.Summary `json:"ruim"`
+	Bom   bench.Summary `json:"bom"`
+	bench.Comparison
+}
+
+// NewDump monta o catálogo completo
+func (s *Site) NewDump() (*Dump, error) {
+	d, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	dump := &Dump{Lang: d.Lang}
+	if d.Results != nil {
+		dump.ResultsDate = &d.Results.Date
+	}
+	for _, topics := range levels(d.Topics) {
+		level := DumpLevel{ID: topics[0].Level, Name: d.Labels.Level(topics[0].Level)}
+		for _, t := range topics {
+			f, err := readTopic(d.Catalog.Root, t)
+			if err != nil {
+				return nil, err
+			}
+			dt := DumpTopic{
+				Topic:      t,
+				Name:       d.Labels.Topic(t),
+				Intro:      strings.TrimSpace(f.intro),
+				Conclusion: strings.TrimSpace(f.outro),
+			}
+			for _, ap := range t.AntiPatterns {
+				dap := DumpAntiPattern{
+					AntiPattern: ap,
+					Analysis:    strings.TrimSpace(f.sections[ap.Number]),
+					RuimCode:    code(f.ruim, ap.Ruim),
+					BomCode:     code(f.bom, ap.Bom),
+				}
+				for _, p := range benchPairs(d.Results, t, ap.Benchmark) {
+					dap.Results = append(dap.Results, DumpResult{Procs: p.Bad.Procs, Ruim: p.Bad, Bom: p.Good, Comparison: p.Compare(alpha)})
+				}
+				dt.AntiPatterns = append(dt.AntiPatterns, dap)
+			}
+			level.Topics = append(level.Topics, dt)
+		}
+		dump.Levels = append(dump.Levels, level)
+	}
+	return dump, nil
+}
+
+// WriteJSON grava o catálogo completo em JSON
+func (s *Site) WriteJSON(w io.Writer) error {
+	dump, err := s.NewDump()
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(dump)
+}
+
+// code devolve o código da declaração de sym (com os métodos, se for um
+// tipo), com uma linha em branco entre os trechos
+func code(src *catalog.Source, sym catalog.Symbol) string {
+	if !sym.Found() {
+		return ""
+	}
+	var parts []string
+	for _, rg := range src.Ranges(sym.Name) {
+		end := min(rg[1], len(src.Lines))
+		parts = append(parts, strings.Join(src.Lines[rg[0]-1:end], "\n"))
+	}
+	return strings.Join(parts, "\n\n")
+}
@@ -0,0 +1,111 @@
+package site
+
+import (
+	"html"
+	"html/template"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lucasrafaldini/fubango/catalog"
+)
+
+var (
+	mdHeading  = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+	mdBullet   = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	mdOrdered  = regexp.MustCompile(`^\s*\d+\.\s+(.*)$`)
+	mdLink     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdStrong   = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	mdEmphasis = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+)
+
+// Markdown converte o subconjunto de Markdown usado nos analise.md:
+// títulos, parágrafos, listas, blocos de código (os de Go com destaque de
+// sintaxe), código inline, negrito, itálico e links.
+func Markdown(src string) template.HTML {
+	var b strings.Builder
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	var para []string
+	flush := func() {
+		if len(para) > 0 {
+			b.WriteString("<p>" + inline(strings.Join(para, " ")) + "</p>\n")
+			para = nil
+		}
+	}
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			flush()
+			lang := strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			b.WriteString(codeBlock(strings.Join(code, "\n"), lang))
+		case trimmed == "":
+			flush()
+		case mdHeading.MatchString(trimmed):
+			flush()
+			m := mdHeading.FindStringSubmatch(trimmed)
+			level := strconv.Itoa(len(m[1]))
+			b.WriteString("<h" + level + ` id="` + html.EscapeString(catalog.Slug(m[2])) + `">` + inline(m[2]) + "</h" + level + ">\n")
+		case mdBullet.MatchString(line), mdOrdered.MatchString(line):
+			flush()
+			tag, item := "ul", mdBullet
+			if !mdBullet.MatchString(line) {
+				tag, item = "ol", mdOrdered
+			}
+			b.WriteString("<" + tag + ">\n")
+			for ; i < len(lines) && item.MatchString(lines[i]); i++ {
+				text := item.FindStringSubmatch(lines[i])[1]
+				// linhas indentadas continuam o item
+				for i+1 < len(lines) && strings.HasPrefix(lines[i+1], "  ") && strings.TrimSpace(lines[i+1]) != "" &&
+					!mdBullet.MatchString(lines[i+1]) && !mdOrdered.MatchString(lines[i+1]) {
+					i++
+					text += " " + strings.TrimSpace(lines[i])
+				}
+				b.WriteString("<li>" + inline(text) + "</li>\n")
+			}
+			i--
+			b.WriteString("</" + tag + ">\n")
+		default:
+			para = append(para, trimmed)
+		}
+	}
+	flush()
+	return template.HTML(b.String())
+}
+
+// codeBlock é um bloco de código; os de Go recebem destaque de sintaxe
+func codeBlock(code, lang string) string {
+	var body string
+	if lang == "go" {
+		var parts []string
+		for _, l := range Highlight([]byte(code)) {
+			parts = append(parts, string(l))
+		}
+		body = strings.Join(parts, "\n")
+	} else {
+		body = html.EscapeString(code)
+	}
+	return `<pre class="code"><code>` + body + "</code></pre>\n"
+}
+
+// inline converte código, negrito, itálico e links de uma linha de texto
+func inline(text string) string {
+	var b strings.Builder
+	for i, part := range strings.Split(text, "`") {
+		if i%2 == 1 {
+			b.WriteString("<code>" + html.EscapeString(part) + "</code>")
+			continue
+		}
+		s := html.EscapeString(part)
+		s = mdLink.ReplaceAllString(s, `<a href="$2">$1</a>`)
+		s = mdStrong.ReplaceAllString(s, "<strong>$1</strong>")
+		s = mdEmphasis.ReplaceAllString(s, "<em>$1</em>")
+		b.WriteString(s)
+	}
+	return b.String()
+}
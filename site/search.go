@@ -0,0 +1,65 @@
+package site
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// searchEntry é um item do índice da busca: um tópico ou um anti-padrão
+type searchEntry struct {
+	Title    string   `json:"title"`
+	Topic    string   `json:"topic"`
+	URL      string   `json:"url"` // relativo à raiz do site
+	ID       string   `json:"id,omitempty"`
+	Severity string   `json:"severity,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Text     string   `json:"text"` // Markdown da seção do analise.md
+}
+
+// searchIndex indexa os tópicos e os anti-padrões na ordem do índice
+func searchIndex(d *data) ([]searchEntry, error) {
+	var entries []searchEntry
+	for _, t := range d.Topics {
+		f, err := readTopic(d.Catalog.Root, t)
+		if err != nil {
+			return nil, err
+		}
+		name := d.Labels.Topic(t)
+		url := t.Level + "/" + t.Category + "/" + d.IndexFile
+		entries = append(entries, searchEntry{
+			Title: name,
+			Topic: d.Labels.Level(t.Level),
+			URL:   url,
+			Text:  t.Title + "\n" + f.intro + f.outro,
+		})
+		for _, ap := range t.AntiPatterns {
+			entries = append(entries, searchEntry{
+				Title:    ap.Title,
+				Topic:    name,
+				URL:      fmt.Sprintf("%s#ap-%d", url, ap.Number),
+				ID:       ap.ID,
+				Severity: ap.Severity,
+				Tags:     ap.Tags,
+				Text:     strings.Join([]string{ap.Ruim.Name, ap.Bom.Name, f.sections[ap.Number]}, "\n"),
+			})
+		}
+	}
+	return entries, nil
+}
+
+// writeSearchIndex grava o índice como um script, e não como JSON, porque
+// navegadores não deixam uma página aberta de file:// buscar outro arquivo
+func writeSearchIndex(w io.Writer, d *data) error {
+	entries, err := searchIndex(d)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "window.fubangoSearch = %s;\n", data)
+	return err
+}
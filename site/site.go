@@ -0,0 +1,441 @@
+// Package site renderiza o catálogo do FubanGo como páginas HTML.
+//
+// Cada tópico vira uma página com ruim.go e bom.go lado a lado, a seção do
+// analise.md de cada anti-padrão e os resultados de benchmark salvos por
+// fubango bench -save. Templates, CSS e o destaque de sintaxe ficam no
+// binário, então as páginas funcionam sem rede.
+//
+// As mesmas páginas podem ser servidas (Handler) ou gravadas como um site
+// estático (Export). WriteJSON e WriteEPUB exportam o catálogo completo
+// para outras ferramentas e como livro.
+package site
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lucasrafaldini/fubango/bench"
+	"github.com/lucasrafaldini/fubango/catalog"
+)
+
+var (
+	//go:embed templates/*.html
+	templateFS embed.FS
+	//go:embed static
+	staticFS embed.FS
+
+	templates = template.Must(template.New("").Funcs(template.FuncMap{
+		"date": func(t time.Time) string { return t.Local().Format("02/01/2006 15:04") },
+	}).ParseFS(templateFS, "templates/*.html"))
+)
+
+// Site serve as páginas do repositório em Root. O catálogo e os resultados
+// são lidos a cada requisição, para que edições nos exemplos apareçam ao
+// recarregar a página.
+type Site struct {
+	Root    string   // raiz do repositório FubanGo
+	Results []string // padrões (glob) dos baselines salvos por fubango bench -save
+	Lang    string   // língua do analise.md (veja catalog.LoadLang); vazio é pt-BR
+}
+
+// Handler devolve as rotas do site:
+//
+//	/                      índice dos tópicos
+//	/<nível>/<categoria>/  página do tópico
+//	/search-index.js       índice da busca
+//	/static/               CSS e o script da busca
+func (s *Site) Handler() http.Handler {
+	mux := http.NewServeMux()
+	static, err := fs.Sub(staticFS, "static")
+	if err != nil {
+		panic(err)
+	}
+	mux.Handle("GET /static/{file}", http.StripPrefix("/static/", http.FileServerFS(static)))
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		s.serve(w, func(d *data, out io.Writer) error {
+			return writeIndex(out, d)
+		})
+	})
+	mux.HandleFunc("GET /search-index.js", func(w http.ResponseWriter, r *http.Request) {
+		d, err := s.load()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		if err := writeSearchIndex(w, d); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	mux.HandleFunc("GET /{level}/{category}/{$}", func(w http.ResponseWriter, r *http.Request) {
+		s.serve(w, func(d *data, out io.Writer) error {
+			t := d.Catalog.Topic(r.PathValue("level") + "/" + r.PathValue("category"))
+			if t == nil {
+				return errNotFound
+			}
+			return writeTopic(out, d, t)
+		})
+	})
+	return mux
+}
+
+var errNotFound = errors.New("tópico não encontrado")
+
+// data é o que as páginas mostram: o catálogo, os nomes e a ordem do
+// índice do README e os resultados de benchmark
+type data struct {
+	Catalog *catalog.Catalog
+	Labels  catalog.IndexLabels
+	Topics  []*catalog.Topic // na ordem do índice
+	Results *bench.Baseline
+	Lang    string // língua pedida; tópicos e seções sem tradução ficam em pt-BR
+
+	// IndexFile completa os links para diretórios. Fica vazio no servidor;
+	// no site exportado é index.html, para que os links funcionem também
+	// abrindo os arquivos direto do disco.
+	IndexFile string
+}
+
+func (s *Site) load() (*data, error) {
+	lang, err := catalog.ParseLang(s.Lang)
+	if err != nil {
+		return nil, err
+	}
+	c, err := catalog.LoadLang(s.Root, lang)
+	if err != nil {
+		return nil, err
+	}
+	readme, err := os.ReadFile(filepath.Join(s.Root, "README.md"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	labels := catalog.ParseIndexLabels(readme)
+	results, err := ReadResults(s.Results)
+	if err != nil {
+		return nil, err
+	}
+	return &data{Catalog: c, Labels: labels, Topics: c.Sorted(labels), Results: results, Lang: lang}, nil
+}
+
+// serve carrega os dados e renderiza a página com write. A página é
+// montada em memória para que um erro vire uma resposta 500 inteira, e não
+// uma página cortada.
+func (s *Site) serve(w http.ResponseWriter, write func(*data, io.Writer) error) {
+	d, err := s.load()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var b strings.Builder
+	switch err := write(d, &b); {
+	case errors.Is(err, errNotFound):
+		http.NotFound(w, nil)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	io.WriteString(w, b.String())
+}
+
+// ReadResults lê os baselines que casam com patterns e junta os
+// resultados mais recentes de cada benchmark (bench.Latest). Sem nenhum
+// arquivo, devolve nil.
+func ReadResults(patterns []string) (*bench.Baseline, error) {
+	var baselines []*bench.Baseline
+	for _, p := range patterns {
+		names, err := filepath.Glob(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			b, err := bench.ReadBaseline(name)
+			if err != nil {
+				return nil, err
+			}
+			baselines = append(baselines, b)
+		}
+	}
+	if len(baselines) == 0 {
+		return nil, nil
+	}
+	return bench.Latest(baselines), nil
+}
+
+// indexPage é o índice do site
+type indexPage struct {
+	*data
+	PageTitle string
+	Base      string // caminho relativo até a raiz do site
+	Levels    [][]*catalog.Topic
+}
+
+func writeIndex(w io.Writer, d *data) error {
+	p := indexPage{data: d, PageTitle: "Anti-padrões em Go", Levels: levels(d.Topics)}
+	return templates.ExecuteTemplate(w, "index.html", p)
+}
+
+// levels agrupa os tópicos, já ordenados, por nível
+func levels(topics []*catalog.Topic) [][]*catalog.Topic {
+	var out [][]*catalog.Topic
+	for i, t := range topics {
+		if i == 0 || topics[i-1].Level != t.Level {
+			out = append(out, nil)
+		}
+		out[len(out)-1] = append(out[len(out)-1], t)
+	}
+	return out
+}
+
+// topicPage é a página de um tópico
+type topicPage struct {
+	*data
+	PageTitle    string
+	Base         string
+	Topic        *catalog.Topic
+	Intro        template.HTML // texto do analise.md antes da primeira seção
+	AntiPatterns []antiPatternView
+	Outro        template.HTML // seções sem número, como a conclusão
+	Ruim, Bom    []codeLine    // arquivos completos
+	Prev, Next   *catalog.Topic
+}
+
+type antiPatternView struct {
+	*catalog.AntiPattern
+	Analysis          template.HTML
+	RuimCode, BomCode []codeLine // declarações associadas pelo manifesto
+	Bench             []benchRow
+}
+
+// codeLine é uma linha de código destacada. ID é a âncora da linha no
+// arquivo completo (ex: ruim-L12); Gap marca linhas omitidas entre trechos.
+type codeLine struct {
+	N    int
+	ID   string
+	HTML template.HTML
+	Gap  bool
+}
+
+// benchRow compara os benchmarks do par de um anti-padrão em um GOMAXPROCS
+type benchRow struct {
+	Procs                 int
+	Ruim, Bom             string // ns/op
+	RuimAllocs, BomAllocs string
+	Speedup               string
+	Significant           bool
+}
+
+func writeTopic(w io.Writer, d *data, t *catalog.Topic) error {
+	p, err := newTopicPage(d, t)
+	if err != nil {
+		return err
+	}
+	return templates.ExecuteTemplate(w, "topic.html", p)
+}
+
+func newTopicPage(d *data, t *catalog.Topic) (*topicPage, error) {
+	f, err := readTopic(d.Catalog.Root, t)
+	if err != nil {
+		return nil, err
+	}
+	p := &topicPage{
+		data:      d,
+		PageTitle: d.Labels.Topic(t),
+		Base:      "../../",
+		Topic:     t,
+		Intro:     Markdown(f.intro),
+		Outro:     Markdown(f.outro),
+		Ruim:      highlight(catalog.RuimFile, f.ruimData),
+		Bom:       highlight(catalog.BomFile, f.bomData),
+	}
+	if i := slices.Index(d.Topics, t); i >= 0 {
+		if i > 0 {
+			p.Prev = d.Topics[i-1]
+		}
+		if i+1 < len(d.Topics) {
+			p.Next = d.Topics[i+1]
+		}
+	}
+	for _, ap := range t.AntiPatterns {
+		v := antiPatternView{
+			AntiPattern: ap,
+			Analysis:    Markdown(f.sections[ap.Number]),
+			RuimCode:    excerpt(f.ruim, p.Ruim, ap.Ruim),
+			BomCode:     excerpt(f.bom, p.Bom, ap.Bom),
+		}
+		for _, pair := range benchPairs(d.Results, t, ap.Benchmark) {
+			v.Bench = append(v.Bench, newBenchRow(pair))
+		}
+		p.AntiPatterns = append(p.AntiPatterns, v)
+	}
+	return p, nil
+}
+
+// topicFiles são os arquivos de um tópico já interpretados. Os textos do
+// analise.md estão na língua do tópico (Topic.Lang), exceto as seções que
+// a tradução ainda não tem.
+type topicFiles struct {
+	intro, outro      string
+	sections          map[int]string // corpo de cada seção numerada do analise.md
+	ruim, bom         *catalog.Source
+	ruimData, bomData []byte
+}
+
+func readTopic(root string, t *catalog.Topic) (*topicFiles, error) {
+	dir := filepath.Join(root, filepath.FromSlash(t.Dir))
+	analysis, err := os.ReadFile(filepath.Join(dir, catalog.AnalysisFile))
+	if err != nil {
+		return nil, err
+	}
+	f := &topicFiles{}
+	f.intro, f.sections, f.outro = splitAnalysis(string(analysis))
+	if t.Lang != catalog.DefaultLang {
+		translated, err := os.ReadFile(filepath.Join(dir, catalog.AnalysisFileFor(t.Lang)))
+		if err != nil {
+			return nil, err
+		}
+		intro, sections, outro := splitAnalysis(string(translated))
+		f.intro, f.outro = intro, outro
+		for n, body := range sections {
+			f.sections[n] = body
+		}
+	}
+	if f.ruim, f.ruimData, err = readSource(filepath.Join(dir, catalog.RuimFile)); err != nil {
+		return nil, err
+	}
+	if f.bom, f.bomData, err = readSource(filepath.Join(dir, catalog.BomFile)); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// splitAnalysis separa o analise.md na introdução (sem o título), no corpo
+// de cada seção numerada e nas demais seções de nível 2, como a conclusão
+func splitAnalysis(src string) (intro string, sections map[int]string, outro string) {
+	sections = make(map[int]string)
+	var introB, outroB strings.Builder
+	number := 0 // seção numerada atual; 0 fora delas
+	for i, line := range strings.SplitAfter(src, "\n") {
+		if i == 0 && strings.HasPrefix(line, "# ") {
+			continue
+		}
+		if strings.HasPrefix(line, "## ") {
+			number = 0
+			if _, err := fmt.Sscanf(line, "## %d.", &number); err == nil {
+				continue
+			}
+		}
+		switch {
+		case number > 0:
+			sections[number] += line
+		case len(sections) == 0:
+			introB.WriteString(line)
+		default:
+			outroB.WriteString(line)
+		}
+	}
+	return introB.String(), sections, outroB.String()
+}
+
+// readSource lê e interpreta um arquivo Go do tópico
+func readSource(name string) (*catalog.Source, []byte, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	src, err := catalog.ParseSource(filepath.Base(name), data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return src, data, nil
+}
+
+// highlight destaca o arquivo file, com âncoras como ruim-L12
+func highlight(file string, data []byte) []codeLine {
+	prefix := strings.TrimSuffix(file, ".go")
+	var lines []codeLine
+	for i, h := range Highlight(data) {
+		lines = append(lines, codeLine{N: i + 1, ID: fmt.Sprintf("%s-L%d", prefix, i+1), HTML: h})
+	}
+	return lines
+}
+
+// excerpt recorta as linhas da declaração de sym (com os métodos, se for
+// um tipo), marcando as lacunas entre os trechos
+func excerpt(src *catalog.Source, lines []codeLine, sym catalog.Symbol) []codeLine {
+	if !sym.Found() {
+		return nil
+	}
+	var out []codeLine
+	for i, rg := range src.Ranges(sym.Name) {
+		if i > 0 {
+			out = append(out, codeLine{Gap: true})
+		}
+		for n := rg[0]; n <= rg[1] && n <= len(lines); n++ {
+			l := lines[n-1]
+			l.ID = ""
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// benchPairs busca nos resultados o par do manifesto, no pacote do tópico,
+// em cada GOMAXPROCS medido
+func benchPairs(results *bench.Baseline, t *catalog.Topic, pair *catalog.BenchmarkPair) []bench.Pair {
+	if results == nil || pair == nil {
+		return nil
+	}
+	find := func(name string, procs int) (bench.Summary, bool) {
+		for _, s := range results.Benchmarks {
+			if s.Name == name && s.Procs == procs && strings.HasSuffix(s.Package, "/"+t.Dir) {
+				return s, true
+			}
+		}
+		return bench.Summary{}, false
+	}
+	var pairs []bench.Pair
+	for _, s := range results.Benchmarks {
+		if s.Name != pair.Ruim || !strings.HasSuffix(s.Package, "/"+t.Dir) {
+			continue
+		}
+		if good, ok := find(pair.Bom, s.Procs); ok {
+			pairs = append(pairs, bench.Pair{Package: s.Package, Bad: s, Good: good})
+		}
+	}
+	return pairs
+}
+
+// alpha é o nível de significância das comparações de benchmark
+const alpha = 0.05
+
+func newBenchRow(p bench.Pair) benchRow {
+	cmp := p.Compare(alpha)
+	return benchRow{
+		Procs:       p.Bad.Procs,
+		Ruim:        bench.FormatValue(int64(p.Bad.NsPerOp), "nanoseconds"),
+		Bom:         bench.FormatValue(int64(p.Good.NsPerOp), "nanoseconds"),
+		RuimAllocs:  formatAllocs(p.Bad),
+		BomAllocs:   formatAllocs(p.Good),
+		Speedup:     fmt.Sprintf("%.2f×", cmp.Speedup),
+		Significant: cmp.Significant,
+	}
+}
+
+func formatAllocs(s bench.Summary) string {
+	if !s.Mem {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f", s.AllocsPerOp)
+}
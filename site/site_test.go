@@ -0,0 +1,164 @@
+package site
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lucasrafaldini/fubango/catalog"
+)
+
+func TestHighlight(t *testing.T) {
+	src := "package p\n\n// soma\nfunc f(s string) int {\n\treturn len(`a\nb`) + 1 // <fim>\n}\n"
+	lines := Highlight([]byte(src))
+	if len(lines) != 7 {
+		t.Fatalf("%d linhas, esperado 7: %q", len(lines), lines)
+	}
+	want := map[int]string{
+		0: `<span class="kw">package</span> p`,
+		2: `<span class="com">// soma</span>`,
+		3: `<span class="kw">func</span> f(s <span class="bi">string</span>) <span class="bi">int</span> {`,
+		4: "\t<span class=\"kw\">return</span> <span class=\"bi\">len</span>(<span class=\"str\">`a</span>",
+		5: "<span class=\"str\">b`</span>) + <span class=\"num\">1</span> <span class=\"com\">// &lt;fim&gt;</span>",
+	}
+	for i, w := range want {
+		if string(lines[i]) != w {
+			t.Errorf("linha %d = %q, esperado %q", i+1, lines[i], w)
+		}
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	src := "## 1. Título\n```go\nx := 1\n```\n**Problemas:**\n- usa `a < b`;\n- veja [o guia](https://go.dev)\n\ntexto *leve*\ncontinua\n"
+	got := string(Markdown(src))
+	for _, want := range []string{
+		`<h2 id="1-título">1. Título</h2>`,
+		`<pre class="code"><code>x := <span class="num">1</span></code></pre>`,
+		`<p><strong>Problemas:</strong></p>`,
+		"<li>usa <code>a &lt; b</code>;</li>",
+		`<li>veja <a href="https://go.dev">o guia</a></li>`,
+		"<p>texto <em>leve</em> continua</p>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("falta %q em:\n%s", want, got)
+		}
+	}
+}
+
+func TestSplitAnalysis(t *testing.T) {
+	src := "# Título\n\nIntro.\n\n## 1. Um\ncorpo 1\n## 2. Dois\ncorpo 2\n\n## Conclusão\nfim\n"
+	intro, sections, outro := splitAnalysis(src)
+	if intro != "\nIntro.\n\n" || sections[1] != "corpo 1\n" || sections[2] != "corpo 2\n\n" || outro != "## Conclusão\nfim\n" {
+		t.Errorf("intro = %q, seções = %q, fim = %q", intro, sections, outro)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	srv := httptest.NewServer(testSite().Handler())
+	defer srv.Close()
+
+	get := func(path string, status int) string {
+		t.Helper()
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != status {
+			t.Fatalf("GET %s = %d, esperado %d\n%s", path, resp.StatusCode, status, body)
+		}
+		return string(body)
+	}
+
+	index := get("/", http.StatusOK)
+	if !strings.Contains(index, `<a href="03-avancado/goroutines/">Goroutines</a>`) {
+		t.Errorf("índice sem o tópico goroutines:\n%s", index)
+	}
+
+	bom, err := catalog.ParseSourceFile(filepath.Join("..", "exemplos", "03-avancado", "goroutines", catalog.BomFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := get("/03-avancado/goroutines/", http.StatusOK)
+	for _, want := range []string{
+		`<a href="#ruim-L109">ruim.go · DeadlockWithChannels</a>`,
+		fmt.Sprintf(`<a href="#bom-L%d">bom.go · AvoidDeadlock</a>`, bom.Lookup("AvoidDeadlock").Line),
+		`<span class="line" id="ruim-L109">`,
+		"<td>8</td><td>2.5ms</td><td>500.0µs</td><td>2001</td><td>12</td><td>5.00×",
+		"Benchmarks de ",
+		`href="../../static/style.css"`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("página sem %q", want)
+		}
+	}
+
+	search := get("/search-index.js", http.StatusOK)
+	if !strings.HasPrefix(search, "window.fubangoSearch = [") || !strings.Contains(search, `"url":"03-avancado/goroutines/#ap-9"`) {
+		t.Errorf("índice da busca inesperado: %.200s", search)
+	}
+
+	get("/03-avancado/inexistente/", http.StatusNotFound)
+	if css := get("/static/style.css", http.StatusOK); !strings.Contains(css, ".side-by-side") {
+		t.Error("style.css incompleto")
+	}
+}
+
+func TestTopicLang(t *testing.T) {
+	// cópia do tópico goroutines com só a seção 1 traduzida
+	root := t.TempDir()
+	src := filepath.Join("..", "exemplos", "03-avancado", "goroutines")
+	dir := filepath.Join(root, "exemplos", "03-avancado", "goroutines")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"ruim.go", "bom.go", "analise.md", "manifesto.json"} {
+		data, err := os.ReadFile(filepath.Join(src, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	en := "# Goroutines Analysis\n\nIntro in English.\n\n## 1. Goroutines Without Termination Control\n\nText in English.\n"
+	if err := os.WriteFile(filepath.Join(dir, "analise.en.md"), []byte(en), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer((&Site{Root: root, Lang: "EN"}).Handler())
+	defer srv.Close()
+	resp, err := http.Get(srv.URL + "/03-avancado/goroutines/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(body)
+	for _, want := range []string{
+		`<h1 lang="en">Goroutines Analysis</h1>`,
+		`<h2 lang="en">1. Goroutines Without Termination Control</h2>`,
+		"<p>Text in English.</p>",
+		`<h2 lang="pt-BR">2. Compartilhamento de Variáveis da Closure</h2>`,
+		"Seção ainda sem tradução; mostrando o original em pt-BR.",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("página sem %q", want)
+		}
+	}
+	if strings.Count(page, "Seção ainda sem tradução") != 11 {
+		t.Errorf("%d seções marcadas como não traduzidas, esperado 11", strings.Count(page, "Seção ainda sem tradução"))
+	}
+}
@@ -0,0 +1,85 @@
+// Busca nos tópicos e anti-padrões usando o índice de search-index.js.
+// Roda inteira no navegador, inclusive com o site aberto direto do disco.
+(function () {
+  "use strict";
+
+  var input = document.getElementById("search");
+  var list = document.getElementById("search-results");
+  if (!input || !list || !window.fubangoSearch) {
+    return;
+  }
+  var base = input.getAttribute("data-base") || "";
+
+  // ignora maiúsculas e acentos: "concorrencia" encontra "Concorrência"
+  function normalize(s) {
+    return s.normalize("NFD").replace(/[\u0300-\u036f]/g, "").toLowerCase();
+  }
+
+  var entries = window.fubangoSearch.map(function (e) {
+    return {
+      entry: e,
+      title: normalize([e.title, e.topic, e.id || "", (e.tags || []).join(" "), e.severity || ""].join(" ")),
+      text: normalize(e.text)
+    };
+  });
+
+  // cada termo precisa aparecer; termos no título valem mais que no texto
+  function search(query) {
+    var terms = normalize(query).split(/\s+/).filter(Boolean);
+    if (terms.length === 0) {
+      return [];
+    }
+    var hits = [];
+    entries.forEach(function (e) {
+      var score = 0;
+      for (var i = 0; i < terms.length; i++) {
+        if (e.title.indexOf(terms[i]) >= 0) {
+          score += 3;
+        } else if (e.text.indexOf(terms[i]) >= 0) {
+          score += 1;
+        } else {
+          return;
+        }
+      }
+      hits.push({ entry: e.entry, score: score });
+    });
+    hits.sort(function (a, b) { return b.score - a.score; });
+    return hits.slice(0, 20);
+  }
+
+  function render() {
+    list.textContent = "";
+    if (input.value.trim() === "") {
+      return;
+    }
+    var hits = search(input.value);
+    if (hits.length === 0) {
+      var none = document.createElement("li");
+      none.className = "muted";
+      none.textContent = "Nada encontrado.";
+      list.appendChild(none);
+      return;
+    }
+    hits.forEach(function (h) {
+      var li = document.createElement("li");
+      var a = document.createElement("a");
+      a.href = base + h.entry.url;
+      a.textContent = h.entry.title;
+      var topic = document.createElement("span");
+      topic.className = "muted";
+      topic.textContent = " · " + h.entry.topic;
+      li.appendChild(a);
+      li.appendChild(topic);
+      list.appendChild(li);
+    });
+  }
+
+  input.addEventListener("input", render);
+  input.form.addEventListener("submit", function (ev) {
+    ev.preventDefault();
+    var first = list.querySelector("a");
+    if (first) {
+      window.location.href = first.href;
+    }
+  });
+})();